@@ -11,7 +11,11 @@ import (
 	// --- ДОБАВЛЕНО: Импорт _ "net/http/pprof" ---
 	_ "net/http/pprof" // Регистрирует обработчики pprof в http.DefaultServeMux
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
+	_ "time/tzdata" // База таймзон для проверки cities.timezone (в образе alpine ее нет)
 
 	// --- Внешние зависимости ---
 	"github.com/go-chi/chi/v5"
@@ -19,12 +23,16 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"                        // Драйвер PostgreSQL (важен _ импорт)
 	"github.com/prometheus/client_golang/prometheus/promhttp" // Обработчик для /metrics
 	"google.golang.org/grpc"                                  // Для gRPC сервера
+	"google.golang.org/grpc/health"                           // Стандартный gRPC health-сервис (readiness для gRPC)
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	// --- Внутренние пакеты ---
 	"github.com/Artem0405/pvz-service/internal/api"                 // HTTP обработчики и middleware
+	"github.com/Artem0405/pvz-service/internal/config"              // Загрузка и валидация конфигурации
 	"github.com/Artem0405/pvz-service/internal/domain"              // Для констант ролей в роутере
 	grpcServer "github.com/Artem0405/pvz-service/internal/grpc"     // Наш gRPC сервер
 	_ "github.com/Artem0405/pvz-service/internal/metrics"           // Импорт для регистрации метрик (побочный эффект)
+	"github.com/Artem0405/pvz-service/internal/notify"              // Доставка токенов сброса пароля
 	"github.com/Artem0405/pvz-service/internal/repository/postgres" // Реализация репозиториев
 	"github.com/Artem0405/pvz-service/internal/service"             // Сервисы бизнес-логики
 	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"                // Сгенерированный код protobuf/grpc
@@ -32,24 +40,14 @@ import (
 
 // initDB инициализирует и проверяет соединение с базой данных.
 // Использует slog для логирования.
-func initDB() (*sql.DB, error) {
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-
-	if dbHost == "" || dbPort == "" || dbUser == "" || dbName == "" {
-		return nil, fmt.Errorf("одна или несколько переменных окружения БД не установлены (DB_HOST, DB_PORT, DB_USER, DB_NAME)")
-	}
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, dbName)
-	slog.Info("Подключение к базе данных...", "host", dbHost, "port", dbPort, "database", dbName)
-	db, err := sql.Open("pgx", dsn)
+func initDB(cfg config.DBConfig) (*sql.DB, error) {
+	slog.Info("Подключение к базе данных...", "host", cfg.Host, "port", cfg.Port, "database", cfg.Name)
+	db, err := sql.Open("pgx", cfg.ConnString())
 	if err != nil {
 		slog.Error("Не удалось инициализировать пул соединений с БД", "error", err)
 		return nil, fmt.Errorf("не удалось открыть соединение с БД: %w", err)
 	}
-	pingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	pingCtx, cancel := context.WithTimeout(context.Background(), cfg.PingTimeout)
 	defer cancel()
 	err = db.PingContext(pingCtx)
 	if err != nil {
@@ -58,91 +56,129 @@ func initDB() (*sql.DB, error) {
 		return nil, fmt.Errorf("не удалось подключиться к БД (ping failed): %w", err)
 	}
 	slog.Info("Соединение с базой данных успешно установлено!")
-	maxOpenConns := 80
-	db.SetMaxOpenConns(maxOpenConns)
-	db.SetMaxIdleConns(maxOpenConns)
-	db.SetConnMaxLifetime(time.Hour)
-	slog.Info("Настроен пул соединений с БД", "MaxOpenConns", maxOpenConns, "MaxIdleConns", maxOpenConns)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	slog.Info("Настроен пул соединений с БД", "MaxOpenConns", cfg.MaxOpenConns, "MaxIdleConns", cfg.MaxIdleConns)
 	return db, nil
 }
 
 // --- ОСНОВНАЯ ФУНКЦИЯ ---
 func main() {
-	// 0. Настройка логгера slog
-	logLevel := slog.LevelInfo
-	if levelStr := os.Getenv("LOG_LEVEL"); levelStr != "" {
-		var lvl slog.Level
-		if err := lvl.UnmarshalText([]byte(levelStr)); err == nil {
-			logLevel = lvl
-		} else {
-			slog.Warn("Некорректный LOG_LEVEL, используется Info", "input", levelStr)
-		}
+	// 0. Чтение конфигурации: значения по умолчанию -> YAML (CONFIG_PATH) -> env
+	cfg, err := config.Load(os.Getenv("CONFIG_PATH"))
+	if err != nil {
+		slog.Error("Ошибка загрузки конфигурации", "error", err)
+		os.Exit(1)
 	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel, AddSource: true}))
+
+	// 1. Настройка логгера slog
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.SlogLevel(), AddSource: true}))
 	slog.SetDefault(logger)
-	slog.Info("PVZ Service starting...")
+	slog.Info("PVZ Service starting...", "env", cfg.Env)
+	slog.Info("Конфигурация загружена", "config_path", os.Getenv("CONFIG_PATH"), "config", cfg.Dump())
 
-	// 1. Чтение конфигурации
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		slog.Error("Переменная окружения JWT_SECRET не установлена!")
-		os.Exit(1)
-	}
-	apiPort := os.Getenv("PORT")
-	if apiPort == "" {
-		apiPort = "8080"
-		slog.Warn("Переменная окружения PORT не установлена, используется порт по умолчанию", "port", apiPort)
-	}
-	apiAddr := ":" + apiPort
-	metricsPort := os.Getenv("METRICS_PORT")
-	if metricsPort == "" {
-		metricsPort = "9000"
-		slog.Warn("Переменная окружения METRICS_PORT не установлена, используется порт по умолчанию", "port", metricsPort)
-	}
-	metricsAddr := ":" + metricsPort
-	grpcPort := os.Getenv("GRPC_PORT")
-	if grpcPort == "" {
-		grpcPort = "3000"
-		slog.Warn("Переменная окружения GRPC_PORT не установлена, используется порт по умолчанию", "port", grpcPort)
-	}
-	grpcListenAddr := ":" + grpcPort
+	apiAddr := ":" + cfg.HTTP.Port
+	metricsAddr := ":" + cfg.Metrics.Port
+	grpcListenAddr := ":" + cfg.GRPC.Port
+	shutdownTimeout := cfg.Shutdown.Timeout
+	// Пауза между снятием readiness и остановкой серверов, чтобы балансировщик успел
+	// заметить 503 на /ready и перестал слать новые запросы. По умолчанию 0.
+	readinessDrainDelay := cfg.Shutdown.ReadinessDelay
+
+	// Контекст отменяется по SIGINT/SIGTERM - это сигнал к graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// 2. Инициализация зависимостей
-	db, err := initDB()
+	db, err := initDB(cfg.DB)
 	if err != nil {
 		slog.Error("Ошибка инициализации базы данных", "error", err)
 		os.Exit(1)
 	}
-	defer func() {
-		slog.Info("Закрытие пула соединений с БД...")
-		if err := db.Close(); err != nil {
-			slog.Error("Ошибка при закрытии пула соединений с БД", "error", err)
-		} else {
-			slog.Info("Пул соединений с БД успешно закрыт.")
-		}
-	}()
+	// Пул БД закрывается явно в конце main - только после остановки HTTP и gRPC серверов
 	slog.Info("Пул соединений с БД инициализирован.")
 
 	pvzRepo := postgres.NewPVZRepo(db)
 	receptionRepo := postgres.NewReceptionRepo(db)
 	userRepo := postgres.NewUserRepo(db)
-	slog.Info("Репозитории инициализированы (PVZ, Reception, User).")
+	cityRepo := postgres.NewCityRepo(db)
+	productTypeRepo := postgres.NewProductTypeRepo(db)
+	tokenRepo := postgres.NewTokenRepo(db)
+	assignmentRepo := postgres.NewAssignmentRepo(db)
+	loginAttemptRepo := postgres.NewLoginAttemptRepo(db)
+	transactor := postgres.NewTransactor(db)
+	slog.Info("Репозитории инициализированы (PVZ, Reception, User, City, ProductType, Token, Assignment, LoginAttempt).")
+
+	// Отозванные токены загружаются до приема запросов, затем догружаются в фоне
+	// (отзывы, сделанные другими экземплярами сервиса)
+	tokenDenylist := service.NewTokenDenylist(tokenRepo)
+	if err := tokenDenylist.Sync(ctx); err != nil {
+		slog.Error("Ошибка загрузки отозванных токенов", "error", err)
+		os.Exit(1)
+	}
+	// Фоновые задачи работают с БД: перед db.Close() дожидаемся их остановки (см. шаг 9)
+	var background sync.WaitGroup
+	runInBackground := func(run func(context.Context)) {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			run(ctx)
+		}()
+	}
+	runInBackground(func(ctx context.Context) { tokenDenylist.Run(ctx, cfg.JWT.DenylistSyncInterval) })
+
+	// Ключи подписи JWT: PEM-файлы из jwt.keys_dir (перечитываются для ротации) или HS256 с jwt.secret
+	jwtKeys, err := service.NewJWTKeySet(cfg.JWT)
+	if err != nil {
+		slog.Error("Ошибка загрузки ключей JWT", "error", err)
+		os.Exit(1)
+	}
+	if cfg.JWT.KeysDir != "" {
+		runInBackground(func(ctx context.Context) { jwtKeys.Run(ctx, cfg.JWT.KeysReloadInterval) })
+	}
+
+	// Счетчики неудачных попыток входа; устаревшие записи периодически удаляются
+	loginLimiter := service.NewLoginLimiter(cfg.LoginProtection, loginAttemptRepo)
+	runInBackground(loginLimiter.Run)
+
+	// Политика паролей (встроенный список распространенных паролей + password_policy.denylist_file)
+	passwordPolicy, err := service.NewPasswordPolicy(cfg.PasswordPolicy)
+	if err != nil {
+		slog.Error("Ошибка загрузки политики паролей", "error", err)
+		os.Exit(1)
+	}
+	// Доставка токенов сброса пароля: log - заглушка без доставки (при env = prod запрещена), file - для локальной работы
+	var resetNotifier service.Notifier = notify.NewLogNotifier()
+	if cfg.PasswordReset.Notifier == config.NotifierFile {
+		resetNotifier = notify.NewFileNotifier(cfg.PasswordReset.File)
+	}
 
-	authService := service.NewAuthService(jwtSecret, userRepo)
-	pvzService := service.NewPVZService(pvzRepo, receptionRepo)
-	receptionService := service.NewReceptionService(receptionRepo)
-	slog.Info("Сервисы инициализированы (Auth, PVZ, Reception).")
+	authService := service.NewAuthService(cfg.JWT, cfg.Registration, cfg.PasswordReset, jwtKeys, userRepo, tokenRepo, tokenDenylist, loginLimiter,
+		passwordPolicy, resetNotifier, transactor)
+	pvzService := service.NewPVZService(pvzRepo, receptionRepo, cityRepo)
+	receptionService := service.NewReceptionService(receptionRepo, pvzRepo, productTypeRepo, assignmentRepo, transactor)
+	cityService := service.NewCityService(cityRepo)
+	productTypeService := service.NewProductTypeService(productTypeRepo)
+	userService := service.NewUserService(userRepo, tokenRepo, pvzRepo, assignmentRepo, tokenDenylist, transactor, cfg.Registration)
+	slog.Info("Сервисы инициализированы (Auth, PVZ, Reception, City, ProductType, User).")
 
-	apiHandler := api.NewHandler(db, authService, pvzService, receptionService)
+	apiHandler := api.NewHandler(db, authService, pvzService, receptionService, cityService, productTypeService, userService, cfg.Limits)
 	slog.Info("API Handler инициализирован.")
 
 	// 3. Настройка роутера chi для HTTP API
+	// IP клиента из заголовков прокси принимается только от http.trusted_proxies (формат проверен в Validate)
+	trustedProxies, err := cfg.HTTP.TrustedProxyPrefixes()
+	if err != nil {
+		slog.Error("Ошибка разбора http.trusted_proxies", "error", err)
+		os.Exit(1)
+	}
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(api.RealIPMiddleware(trustedProxies))
 	r.Use(api.SlogMiddleware(logger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(middleware.Timeout(cfg.HTTP.HandlerTimeout))
 	r.Use(api.PrometheusMiddleware) // Prometheus middleware должен идти до Timeout? Проверить порядок.
 
 	// --- ДОБАВЛЕНО: Регистрация pprof обработчиков ---
@@ -157,49 +193,85 @@ func main() {
 	// 4. Регистрация HTTP маршрутов
 	slog.Info("Регистрация HTTP маршрутов...")
 	r.Get("/health", apiHandler.HandleHealthCheck)
-	r.Post("/dummyLogin", apiHandler.HandleDummyLogin)
+	r.Get("/ready", apiHandler.HandleReadiness)
+	// /dummyLogin выдает токен любой роли без пароля - только для dev/test (jwt.dummy_auth выключен по умолчанию,
+	// при другом env конфигурация с ним не проходит валидацию)
+	if cfg.JWT.DummyAuth {
+		r.Post("/dummyLogin", apiHandler.HandleDummyLogin)
+		slog.Warn("Включен /dummyLogin: токены выдаются без пароля", "env", cfg.Env)
+	}
 	r.Post("/register", apiHandler.HandleRegister)
 	r.Post("/login", apiHandler.HandleLogin)
+	r.Post("/token/refresh", apiHandler.HandleRefreshToken)
+	r.Post("/password/reset-request", apiHandler.HandleRequestPasswordReset)
+	r.Post("/password/reset", apiHandler.HandleResetPassword)
+	r.Get("/.well-known/jwks.json", apiHandler.HandleJWKS)
 
 	// Маршрут для метрик Prometheus - оставляем, т.к. он нужен для Prometheus сервера
 	r.Handle("/metrics", promhttp.Handler())
 
 	r.Group(func(r chi.Router) {
 		r.Use(api.AuthMiddleware(authService))
+		r.Post("/logout", apiHandler.HandleLogout)
+		r.Post("/me/password", apiHandler.HandleChangePassword)
 		r.Get("/pvz", apiHandler.HandleListPVZ)
+		r.Get("/pvz/{pvzId}", apiHandler.HandleGetPVZ)
 		r.Post("/receptions", apiHandler.HandleInitiateReception)
 		r.Post("/products", apiHandler.HandleAddProduct)
+		r.Get("/products", apiHandler.HandleFindProductsByBarcode)
+		r.Post("/pvz/{pvzId}/products:batch", apiHandler.HandleAddProductsBatch)
 		r.Post("/pvz/{pvzId}/delete_last_product", apiHandler.HandleDeleteLastProduct)
+		r.Post("/pvz/{pvzId}/undo_last_deletion", apiHandler.HandleUndoLastDeletion)
+		r.Delete("/receptions/{receptionId}/products/{productId}", apiHandler.HandleDeleteProduct)
 		r.Post("/pvz/{pvzId}/close_last_reception", apiHandler.HandleCloseLastReception)
+		r.Post("/receptions/{receptionId}/cancel", apiHandler.HandleCancelReception)
+		r.Get("/pvz/{pvzId}/receptions", apiHandler.HandleListPVZReceptions)
+		r.Get("/pvz/{pvzId}/receptions/current", apiHandler.HandleGetCurrentReception)
+		r.Get("/receptions/{receptionId}", apiHandler.HandleGetReception)
+		r.Get("/product-types", apiHandler.HandleListProductTypes)
+		r.Get("/product-types/{code}", apiHandler.HandleGetProductType)
 		r.Group(func(r chi.Router) {
 			r.Use(api.RoleMiddleware(domain.RoleModerator))
 			r.Post("/pvz", apiHandler.HandleCreatePVZ)
+			r.Patch("/pvz/{pvzId}", apiHandler.HandleUpdatePVZ)
+			r.Post("/pvz/{pvzId}/deactivate", apiHandler.HandleDeactivatePVZ)
+			r.Post("/pvz/{pvzId}/reactivate", apiHandler.HandleReactivatePVZ)
+			r.Get("/cities", apiHandler.HandleListCities)
+			r.Post("/cities", apiHandler.HandleCreateCity)
+			r.Get("/cities/{code}", apiHandler.HandleGetCity)
+			r.Patch("/cities/{code}", apiHandler.HandleUpdateCity)
+			r.Delete("/cities/{code}", apiHandler.HandleDeleteCity)
+			r.Post("/product-types", apiHandler.HandleCreateProductType)
+			r.Put("/product-types/{code}", apiHandler.HandleUpdateProductType)
+			r.Post("/receptions/{receptionId}/reopen", apiHandler.HandleReopenReception)
+			r.Get("/users", apiHandler.HandleListUsers)
+			r.Post("/users/invites", apiHandler.HandleCreateInvite)
+			r.Get("/users/{userId}", apiHandler.HandleGetUser)
+			r.Post("/users/{userId}/disable", apiHandler.HandleDisableUser)
+			r.Post("/users/{userId}/enable", apiHandler.HandleEnableUser)
+			r.Put("/users/{userId}/role", apiHandler.HandleChangeUserRole)
+			r.Get("/users/{userId}/pvz", apiHandler.HandleListUserPVZs)
+			r.Put("/users/{userId}/pvz/{pvzId}", apiHandler.HandleAssignPVZ)
+			r.Delete("/users/{userId}/pvz/{pvzId}", apiHandler.HandleUnassignPVZ)
 		})
 	})
 	slog.Info("HTTP маршруты успешно зарегистрированы.")
 
 	errChan := make(chan error, 3)
 
-	// 5. Запуск HTTP-сервера для метрик Prometheus (в горутине)
-	//    Теперь он не нужен, если /metrics доступен через основной роутер.
-	//    НО: Оставляем его, если хотим метрики на отдельном порту.
-	//    Если /metrics обрабатывается основным роутером r, эту горутину можно удалить.
-	//    Я оставлю ее, так как у вас был отдельный metricsPort.
-	//    НО УДАЛИЛ ИЗ НЕГО r.Handle("/metrics",...), т.к. он уже есть в основном роутере.
+	// 5. Вспомогательный HTTP-сервер на отдельном порту метрик.
+	//    /metrics обслуживается основным роутером r, здесь только заглушка.
+	metricsMux := http.NewServeMux()
+	metricsMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Metrics server auxiliary handler"))
+	})
+	metricsServer := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+	}
 	go func() {
-		// --- УБРАЛИ регистрацию /metrics здесь, она теперь в роутере r ---
-		metricsMux := http.NewServeMux()
-		// Можно оставить его пустым или добавить свой /health для сервера метрик
-		metricsMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("Metrics server auxiliary handler"))
-		}) // Добавим заглушку
-
-		metricsServer := &http.Server{
-			Addr:              metricsAddr,
-			Handler:           metricsMux, // Используем пустой mux, т.к. /metrics в основном роутере
-			ReadHeaderTimeout: 5 * time.Second,
-		}
 		slog.Info("Starting auxiliary metrics server", "address", metricsServer.Addr)
 		err := metricsServer.ListenAndServe()
 		if err != http.ErrServerClosed { // Логируем только реальные ошибки
@@ -210,23 +282,26 @@ func main() {
 		}
 	}()
 
-	// 6. Запуск gRPC Сервера (в горутине) - без изменений
+	// 6. gRPC сервер. Создаем его здесь, чтобы при остановке вызвать GracefulStop.
+	lis, err := net.Listen("tcp", grpcListenAddr)
+	if err != nil {
+		slog.Error("Failed to listen for gRPC", "address", grpcListenAddr, "error", err)
+		db.Close()
+		os.Exit(1)
+	}
+	pvzGrpcServerImpl := grpcServer.NewPVZServer(pvzRepo, pvzService, receptionService, cfg.Limits)
+	grpcSrv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcServer.AuthUnaryInterceptor(authService)),
+		grpc.StreamInterceptor(grpcServer.AuthStreamInterceptor(authService)),
+	)
+	pb.RegisterPVZServiceServer(grpcSrv, pvzGrpcServerImpl)
+	// Health-сервис gRPC - аналог /ready: NOT_SERVING с начала остановки.
+	// Его методы доступны без токена (см. publicMethods в internal/grpc/interceptors.go).
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(grpcSrv, healthSrv)
 	go func() {
-		lis, err := net.Listen("tcp", grpcListenAddr)
-		if err != nil {
-			slog.Error("Failed to listen for gRPC", "address", grpcListenAddr, "error", err)
-			errChan <- fmt.Errorf("gRPC listen error: %w", err)
-			return
-		}
-		defer func() { /* ... закрытие lis ... */ }()
-
-		pvzGrpcServerImpl := grpcServer.NewPVZServer(pvzRepo)
-		grpcSrv := grpc.NewServer()
-		pb.RegisterPVZServiceServer(grpcSrv, pvzGrpcServerImpl)
-
 		slog.Info("Starting gRPC server", "address", lis.Addr().String())
-		err = grpcSrv.Serve(lis)
-		if err != nil {
+		if err := grpcSrv.Serve(lis); err != nil {
 			slog.Error("gRPC server failed", "error", err)
 			errChan <- fmt.Errorf("gRPC serve error: %w", err)
 		} else {
@@ -234,16 +309,16 @@ func main() {
 		}
 	}()
 
-	// 7. Запуск основного HTTP-сервера API (в горутине) - без изменений
+	// 7. Основной HTTP-сервер API
+	httpServer := &http.Server{
+		Addr:              apiAddr,
+		Handler:           r, // Используем chi роутер с pprof и /metrics
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
 	go func() {
-		httpServer := &http.Server{
-			Addr:              apiAddr,
-			Handler:           r, // Используем chi роутер с pprof и /metrics
-			ReadTimeout:       10 * time.Second,
-			ReadHeaderTimeout: 5 * time.Second,
-			WriteTimeout:      10 * time.Second,
-			IdleTimeout:       120 * time.Second,
-		}
 		slog.Info("Starting API server", "address", httpServer.Addr)
 		err := httpServer.ListenAndServe()
 		if err != http.ErrServerClosed {
@@ -254,9 +329,50 @@ func main() {
 		}
 	}()
 
-	// 8. Ожидание ошибки от любого из серверов - без изменений
-	slog.Info("Application started. Waiting for errors...")
-	serverErr := <-errChan
-	slog.Error("Shutting down due to server error", "error", serverErr)
-	os.Exit(1)
+	// Фоновый обработчик зависших приемок; останавливается вместе с ctx по сигналу завершения
+	if cfg.StaleReceptions.Enabled {
+		runInBackground(service.NewStaleReceptionWorker(cfg.StaleReceptions, receptionService).Run)
+	} else {
+		slog.Info("Обработчик зависших приемок отключен (stale_receptions.enabled=false)")
+	}
+
+	apiHandler.SetReady(true)
+
+	// 8. Ожидание сигнала завершения или ошибки любого из серверов
+	slog.Info("Application started. Waiting for shutdown signal...")
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("Получен сигнал завершения, начинаем graceful shutdown", "timeout", shutdownTimeout)
+	case serverErr := <-errChan:
+		slog.Error("Shutting down due to server error", "error", serverErr)
+		exitCode = 1
+	}
+	stop() // Повторный сигнал завершит процесс немедленно (поведение по умолчанию)
+
+	// 9. Graceful shutdown: сначала снимаем readiness, затем дренируем серверы, затем закрываем БД
+	apiHandler.SetReady(false)
+	healthSrv.Shutdown()
+	if readinessDrainDelay > 0 {
+		slog.Info("Ожидание перед остановкой серверов (readiness снят)", "delay", readinessDrainDelay)
+		time.Sleep(readinessDrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	drainServers(shutdownCtx, httpServer, grpcSrv)
+	shutdownHTTPServer(shutdownCtx, "metrics", metricsServer)
+
+	slog.Info("Ожидание остановки фоновых задач...")
+	background.Wait() // ctx уже отменен (stop), задачи завершают текущую итерацию и выходят
+
+	slog.Info("Закрытие пула соединений с БД...")
+	if err := db.Close(); err != nil {
+		slog.Error("Ошибка при закрытии пула соединений с БД", "error", err)
+	} else {
+		slog.Info("Пул соединений с БД успешно закрыт.")
+	}
+
+	slog.Info("PVZ Service stopped", "exit_code", exitCode)
+	os.Exit(exitCode)
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// shutdownHTTPServer останавливает прием новых соединений и ждет завершения
+// текущих запросов до дедлайна ctx. По истечении дедлайна соединения закрываются принудительно.
+func shutdownHTTPServer(ctx context.Context, name string, srv *http.Server) {
+	slog.Info("Остановка HTTP сервера...", "server", name)
+	if err := srv.Shutdown(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.Warn("HTTP сервер не успел завершить запросы, закрываем соединения принудительно", "server", name)
+		} else {
+			slog.Error("Ошибка при остановке HTTP сервера", "server", name, "error", err)
+		}
+		_ = srv.Close()
+		return
+	}
+	slog.Info("HTTP сервер остановлен", "server", name)
+}
+
+// shutdownGRPCServer вызывает GracefulStop (дожидается активных RPC, включая стримы)
+// и, если дедлайн ctx истек раньше, обрывает оставшиеся RPC через Stop.
+func shutdownGRPCServer(ctx context.Context, srv *grpc.Server) {
+	slog.Info("Остановка gRPC сервера...")
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		slog.Info("gRPC сервер остановлен")
+	case <-ctx.Done():
+		slog.Warn("gRPC сервер не успел завершить RPC, останавливаем принудительно")
+		srv.Stop()
+		<-stopped
+	}
+}
+
+// drainServers параллельно останавливает HTTP API и gRPC сервер
+// и возвращается, только когда оба завершили работу (или истек дедлайн).
+func drainServers(ctx context.Context, httpServer *http.Server, grpcSrv *grpc.Server) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		shutdownHTTPServer(ctx, "api", httpServer)
+	}()
+	go func() {
+		defer wg.Done()
+		shutdownGRPCServer(ctx, grpcSrv)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// HandleListCities - обработчик для GET /cities (только модератор)
+func (h *Handler) HandleListCities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	includeInactive := false
+	if incStr := r.URL.Query().Get("include_inactive"); incStr != "" {
+		v, err := strconv.ParseBool(incStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'include_inactive' (ожидается true/false)")
+			return
+		}
+		includeInactive = v
+	}
+
+	cities, err := h.cityService.ListCities(ctx, includeInactive)
+	if err != nil {
+		respondWithServiceError(ctx, w, err, "Ошибка получения списка городов")
+		return
+	}
+
+	items := make([]City, 0, len(cities))
+	for _, c := range cities {
+		items = append(items, toAPICity(c))
+	}
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+// HandleCreateCity - обработчик для POST /cities (только модератор)
+func (h *Handler) HandleCreateCity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateCityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	city := domain.City{
+		Code:     req.Code,
+		Name:     req.Name,
+		Timezone: req.Timezone,
+		IsActive: true, // По умолчанию город сразу доступен для новых ПВЗ
+	}
+	if req.IsActive != nil {
+		city.IsActive = *req.IsActive
+	}
+
+	created, err := h.cityService.CreateCity(ctx, city)
+	if err != nil {
+		// CITY_VALIDATION -> 400, CITY_ALREADY_EXISTS -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при добавлении города")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, toAPICity(created))
+}
+
+// HandleGetCity - обработчик для GET /cities/{code} (только модератор)
+func (h *Handler) HandleGetCity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	city, err := h.cityService.GetCity(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		// CITY_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при получении города")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPICity(city))
+}
+
+// HandleUpdateCity - обработчик для PATCH /cities/{code} (только модератор)
+func (h *Handler) HandleUpdateCity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req UpdateCityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.Name == nil && req.Timezone == nil && req.IsActive == nil {
+		respondWithError(w, http.StatusBadRequest, "Не передано ни одного изменяемого поля")
+		return
+	}
+
+	city, err := h.cityService.UpdateCity(ctx, chi.URLParam(r, "code"), domain.CityUpdate{
+		Name:     req.Name,
+		Timezone: req.Timezone,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		// CITY_NOT_FOUND -> 404, CITY_VALIDATION -> 400, CITY_ALREADY_EXISTS -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при изменении города")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPICity(city))
+}
+
+// HandleDeleteCity - обработчик для DELETE /cities/{code} (только модератор)
+func (h *Handler) HandleDeleteCity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := h.cityService.DeleteCity(ctx, chi.URLParam(r, "code")); err != nil {
+		// CITY_NOT_FOUND -> 404, CITY_IN_USE -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при удалении города")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toAPICity конвертирует domain.City -> api.City
+func toAPICity(c domain.City) City {
+	out := City{
+		Code:     c.Code,
+		Name:     c.Name,
+		Timezone: c.Timezone,
+		IsActive: c.IsActive,
+	}
+	if !c.CreatedAt.IsZero() {
+		out.CreatedAt = &c.CreatedAt
+	}
+	return out
+}
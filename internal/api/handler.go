@@ -7,8 +7,11 @@ import (
 	"log" // Оставляем для критических ошибок json.Marshal
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/errmap"
 	"github.com/Artem0405/pvz-service/internal/service"
 )
 
@@ -27,33 +30,58 @@ type Handler struct {
 	authService      service.AuthService
 	pvzService       service.PVZService
 	receptionService service.ReceptionService
+	cityService      service.CityService
+	typeService      service.ProductTypeService
+	userService      service.UserService
+	limits           config.LimitsConfig // Размеры страниц списков (GET /pvz)
+	ready            atomic.Bool         // Готовность принимать трафик (GET /ready), false до старта и с начала остановки
 }
 
 // NewHandler - конструктор для Handler.
-func NewHandler(db *sql.DB, authService service.AuthService, pvzService service.PVZService, receptionService service.ReceptionService) *Handler {
+func NewHandler(db *sql.DB, authService service.AuthService, pvzService service.PVZService, receptionService service.ReceptionService, cityService service.CityService, typeService service.ProductTypeService, userService service.UserService, limits config.LimitsConfig) *Handler {
 	return &Handler{
 		db:               db,
 		authService:      authService,
 		pvzService:       pvzService,
 		receptionService: receptionService,
+		cityService:      cityService,
+		typeService:      typeService,
+		userService:      userService,
+		limits:           limits,
 	}
 }
 
 // respondWithError - вспомогательная функция для отправки стандартизированного
 // JSON-ответа об ошибке клиенту. Логирует ошибку на сервере.
-// Использует сгенерированный тип ошибки.
+// Код ошибки берется общий по статусу (BAD_REQUEST, UNAUTHORIZED, ...).
 func respondWithError(w http.ResponseWriter, code int, message string) {
+	respondWithErrorCode(w, code, errmap.CodeForStatus(code), message)
+}
+
+// respondWithErrorCode - то же, что respondWithError, но с явным машиночитаемым кодом ошибки.
+func respondWithErrorCode(w http.ResponseWriter, status int, code, message string) {
 	// Логируем ошибку с использованием slog
-	slog.Warn("Ошибка ответа API", slog.Int("status_code", code), slog.String("message", message))
+	slog.Warn("Ошибка ответа API", slog.Int("status_code", status), slog.String("code", code), slog.String("message", message))
 
-	// --- Используем сгенерированный тип ошибки ---
-	// Предполагаем, что он называется Error и поле Message *string
-	errorResponse := Error{ // <--- Замените Error на ваше имя типа
-		Message: message, // <--- Берем адрес строки, если поле - указатель
+	errorResponse := Error{
+		Code:    code,
+		Message: message,
 	}
-	// --- Конец использования сгенерированного типа ---
 
-	respondWithJSON(w, code, errorResponse)
+	respondWithJSON(w, status, errorResponse)
+}
+
+// respondWithServiceError отвечает клиенту по ошибке сервисного слоя через errmap:
+// доменные ошибки отдаются со своим статусом, кодом и текстом, остальные
+// логируются и скрываются за internalMessage с кодом INTERNAL.
+func respondWithServiceError(ctx context.Context, w http.ResponseWriter, err error, internalMessage string) {
+	status, code := errmap.HTTP(err)
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(ctx, internalMessage, slog.Any("error", err))
+		respondWithErrorCode(w, status, code, internalMessage)
+		return
+	}
+	respondWithErrorCode(w, status, code, err.Error())
 }
 
 // respondWithJSON - вспомогательная функция для кодирования payload в JSON
@@ -64,7 +92,7 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 		// Используем стандартный log для критической ошибки, которую не можем вернуть клиенту
 		log.Printf("Критическая ошибка кодирования JSON ответа: %v", err)
 		// Отправляем простой текстовый JSON, т.к. не можем сформировать стандартный ErrorResponse
-		http.Error(w, `{"message": "Внутренняя ошибка сервера при кодировании ответа"}`, http.StatusInternalServerError)
+		http.Error(w, `{"code": "INTERNAL", "message": "Внутренняя ошибка сервера при кодировании ответа"}`, http.StatusInternalServerError)
 		return
 	}
 
@@ -94,4 +122,21 @@ func (h *Handler) HandleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, map[string]string{"status": "ok", "database": "up"})
 }
 
+// SetReady переключает флаг готовности, который отдает GET /ready.
+// main выставляет true после запуска серверов и false в самом начале graceful shutdown.
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// HandleReadiness - обработчик для GET /ready (readiness probe).
+// В отличие от /health, отвечает 503 сразу, как только началась остановка сервиса,
+// чтобы балансировщик перестал направлять сюда новые запросы.
+func (h *Handler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		respondWithError(w, http.StatusServiceUnavailable, "Сервис не готов принимать запросы")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
 // --- Остальные обработчики находятся в других файлах пакета api ---
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog" // Импортируем slog
+	"net"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
 	"time" // Для расчета длительности запроса
@@ -12,6 +14,8 @@ import (
 	// Импорт chi middleware нужен для обертки ответа
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/errmap"
 	mmetrics "github.com/Artem0405/pvz-service/internal/metrics"
 	"github.com/Artem0405/pvz-service/internal/service"
 )
@@ -21,9 +25,9 @@ import (
 // с другими ключами, которые могут быть добавлены в контекст другими пакетами или middleware.
 type contextKey string
 
-// roleContextKey - конкретный ключ, который мы будем использовать
-// для хранения извлеченной роли пользователя в контексте запроса.
-const roleContextKey = contextKey("userRole") // Используем "userRole" для ясности
+// principalContextKey - ключ, под которым AuthMiddleware хранит в контексте запроса
+// пользователя из токена (domain.Principal: ID, email, роль).
+const principalContextKey = contextKey("principal")
 
 // AuthMiddleware - это функция высшего порядка (фабрика middleware).
 // Она принимает зависимость - сервис аутентификации (как интерфейс AuthService),
@@ -53,14 +57,19 @@ func AuthMiddleware(authService service.AuthService) func(next http.Handler) htt
 			claims, err := authService.ValidateToken(tokenString)
 			if err != nil {
 				slog.Warn("AuthMiddleware: Token validation failed", "error", err.Error()) // <-- ЛОГ 5
-				respondWithError(w, http.StatusUnauthorized, "Невалидный или просроченный токен: "+err.Error())
+				// Статус всегда 401, а код уточняет причину (TOKEN_EXPIRED, TOKEN_MALFORMED, ...)
+				code := errmap.CodeUnauthorized
+				if domainErr, ok := domain.AsError(err); ok {
+					code = domainErr.Code
+				}
+				respondWithErrorCode(w, http.StatusUnauthorized, code, "Невалидный или просроченный токен: "+err.Error())
 				return
 			}
 
-			slog.Debug("AuthMiddleware: Token validated successfully", "role", claims.Role) // <-- ЛОГ 6
+			principal := claims.Principal()
+			slog.Debug("AuthMiddleware: Token validated successfully", "role", principal.Role, "user_id", principal.UserID) // <-- ЛОГ 6
 
-			// ... (сохранение роли в контекст и вызов next) ...
-			ctx := context.WithValue(r.Context(), roleContextKey, claims.Role)
+			ctx := context.WithValue(r.Context(), principalContextKey, principal)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -71,11 +80,22 @@ func AuthMiddleware(authService service.AuthService) func(next http.Handler) htt
 // и может быть использована напрямую в хендлерах при необходимости.
 // Возвращает роль (string) и флаг (bool), указывающий, была ли роль найдена в контексте.
 func GetRoleFromContext(ctx context.Context) (string, bool) {
-	// Пытаемся получить значение по нашему ключу roleContextKey.
-	// Выполняем утверждение типа (type assertion) к string.
-	role, ok := ctx.Value(roleContextKey).(string)
-	// Возвращаем полученное значение и результат утверждения типа.
-	return role, ok
+	principal, ok := PrincipalFromContext(ctx)
+	return principal.Role, ok
+}
+
+// PrincipalFromContext возвращает пользователя запроса, сохраненного AuthMiddleware.
+// Флаг false означает, что запрос не прошел через AuthMiddleware.
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(domain.Principal)
+	return principal, ok
+}
+
+// actorFromContext собирает domain.Actor (кто выполняет действие) для аудита и полей created_by/closed_by.
+// Для токенов /dummyLogin UserID остается пустым.
+func actorFromContext(ctx context.Context) domain.Actor {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.Actor()
 }
 
 // RoleMiddleware - фабрика middleware для проверки наличия у пользователя
@@ -108,6 +128,58 @@ func RoleMiddleware(requiredRole string) func(next http.Handler) http.Handler {
 	}
 }
 
+// RealIPMiddleware заменяет r.RemoteAddr на IP клиента из X-Real-IP / X-Forwarded-For, но только если
+// соединение пришло от доверенного прокси (http.trusted_proxies). Иначе заголовки игнорируются: их может
+// подставить сам клиент, чтобы обойти счетчики попыток входа по IP.
+func RealIPMiddleware(trusted []netip.Prefix) func(next http.Handler) http.Handler {
+	isTrusted := func(addr netip.Addr) bool {
+		for _, p := range trusted {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peer, ok := parseIP(r.RemoteAddr)
+			if !ok || !isTrusted(peer) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if ip, ok := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ok {
+				r.RemoteAddr = ip.String()
+			} else if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+				// Справа налево: первый адрес, добавленный не нашим прокси, - клиент
+				hops := strings.Split(xff, ",")
+				for i := len(hops) - 1; i >= 0; i-- {
+					ip, ok := parseIP(strings.TrimSpace(hops[i]))
+					if !ok {
+						break
+					}
+					r.RemoteAddr = ip.String()
+					if !isTrusted(ip) {
+						break
+					}
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// parseIP разбирает IP с портом или без
+func parseIP(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
 // SlogMiddleware - middleware для структурированного логирования запросов с помощью slog.
 func SlogMiddleware(logger *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -13,22 +13,41 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
-// Defines values for PVZCity.
+// Defines values for AttributePropertyType.
 const (
-	Казань         PVZCity = "Казань"
-	Москва         PVZCity = "Москва"
-	СанктПетербург PVZCity = "Санкт-Петербург"
+	Boolean AttributePropertyType = "boolean"
+	Integer AttributePropertyType = "integer"
+	Number  AttributePropertyType = "number"
+	String  AttributePropertyType = "string"
 )
 
-// Defines values for ProductType.
+// Defines values for AttributeSchemaType.
 const (
-	Обувь       ProductType = "обувь"
-	Одежда      ProductType = "одежда"
-	Электроника ProductType = "электроника"
+	Object AttributeSchemaType = "object"
+)
+
+// Defines values for JSONWebKeyAlg.
+const (
+	EdDSA JSONWebKeyAlg = "EdDSA"
+	RS256 JSONWebKeyAlg = "RS256"
+)
+
+// Defines values for JSONWebKeyKty.
+const (
+	OKP JSONWebKeyKty = "OKP"
+	RSA JSONWebKeyKty = "RSA"
+)
+
+// Defines values for ProductBatchItemResultStatus.
+const (
+	Added    ProductBatchItemResultStatus = "added"
+	Rejected ProductBatchItemResultStatus = "rejected"
+	Skipped  ProductBatchItemResultStatus = "skipped"
 )
 
 // Defines values for ReceptionStatus.
 const (
+	Cancelled  ReceptionStatus = "cancelled"
 	Closed     ReceptionStatus = "closed"
 	InProgress ReceptionStatus = "in_progress"
 )
@@ -41,13 +60,131 @@ const (
 
 // AddProductRequest Запрос на добавление товара в приемку
 type AddProductRequest struct {
+	// Attributes Дополнительные атрибуты товара; проверяются по attributesSchema его типа
+	Attributes *ProductAttributes `json:"attributes,omitempty"`
+
+	// Barcode Штрихкод/SKU товара - печатные ASCII символы без пробелов. Уникален в пределах приемки.
+	Barcode *Barcode `json:"barcode,omitempty"`
+
+	// OrderId Номер заказа во внешней системе (без пробелов)
+	OrderId *OrderId `json:"orderId,omitempty"`
+
 	// PvzId ID ПВЗ, в котором находится активная приемка
 	PvzId openapi_types.UUID `json:"pvzId"`
 
-	// Type Тип товара
+	// Type Тип товара - код активного типа из справочника GET /product-types
 	Type ProductType `json:"type"`
 }
 
+// AddProductsBatchRequest Пачка товаров для открытой приемки ПВЗ
+type AddProductsBatchRequest struct {
+	// AllOrNothing true - товары добавляются, только если приняты все; иначе пачка не добавляется целиком
+	AllOrNothing *bool `json:"allOrNothing,omitempty"`
+
+	// Items Товары в порядке сканирования (не больше limits.product_batch_max, по умолчанию 100)
+	Items []ProductBatchItemRequest `json:"items"`
+}
+
+// AddProductsBatchResponse Итог обработки пачки
+type AddProductsBatchResponse struct {
+	// Added Сколько товаров добавлено
+	Added int                      `json:"added"`
+	Items []ProductBatchItemResult `json:"items"`
+
+	// ReceptionId Открытая приемка, в которую добавлялись товары
+	ReceptionId openapi_types.UUID `json:"receptionId"`
+
+	// Rejected Сколько товаров отклонено
+	Rejected int `json:"rejected"`
+}
+
+// AttributeProperty Описание одного атрибута
+type AttributeProperty struct {
+	Description *string `json:"description,omitempty"`
+
+	// Enum Допустимые значения (только для string)
+	Enum *[]string `json:"enum,omitempty"`
+
+	// MaxLength Максимальная длина (только для string)
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Maximum Максимальное значение (только для number/integer)
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// Minimum Минимальное значение (только для number/integer)
+	Minimum *float64              `json:"minimum,omitempty"`
+	Type    AttributePropertyType `json:"type"`
+}
+
+// AttributePropertyType defines model for AttributeProperty.Type.
+type AttributePropertyType string
+
+// AttributeSchema Схема дополнительных атрибутов - подмножество JSON Schema для объекта с плоскими свойствами. Атрибуты, не описанные в properties, отклоняются.
+type AttributeSchema struct {
+	Properties map[string]AttributeProperty `json:"properties"`
+	Required   *[]string                    `json:"required,omitempty"`
+	Type       *AttributeSchemaType         `json:"type,omitempty"`
+}
+
+// AttributeSchemaType defines model for AttributeSchema.Type.
+type AttributeSchemaType string
+
+// Barcode Штрихкод/SKU товара - печатные ASCII символы без пробелов. Уникален в пределах приемки.
+type Barcode = string
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+
+	// NewPassword Должен удовлетворять политике паролей (password_policy)
+	NewPassword string `json:"newPassword"`
+}
+
+// ChangeUserRoleRequest Новая роль пользователя
+type ChangeUserRoleRequest struct {
+	// Role Роль пользователя в системе
+	Role UserRole `json:"role"`
+}
+
+// City Город из справочника, в котором можно открывать ПВЗ
+type City struct {
+	// Code Стабильный код города (латиница в нижнем регистре, цифры, '-', '_')
+	Code      string     `json:"code"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// IsActive false - новые ПВЗ в городе открыть нельзя (существующие продолжают работать)
+	IsActive bool `json:"isActive"`
+
+	// Name Отображаемое имя; это значение указывается в поле city ПВЗ
+	Name string `json:"name"`
+
+	// Timezone IANA таймзона города
+	Timezone string `json:"timezone"`
+}
+
+// CreateCityRequest defines model for CreateCityRequest.
+type CreateCityRequest struct {
+	Code     string `json:"code"`
+	IsActive *bool  `json:"isActive,omitempty"`
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"`
+}
+
+// CreateInviteRequest Приглашение на регистрацию с заданной ролью
+type CreateInviteRequest struct {
+	// Email Если задан, приглашение действует только для этого email
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Role Роль пользователя в системе
+	Role UserRole `json:"role"`
+}
+
+// DeleteProductRequest Удаление конкретного товара из открытой приемки
+type DeleteProductRequest struct {
+	// Reason Причина удаления (сохраняется в аудите вместе с тем, кто удалил)
+	Reason string `json:"reason"`
+}
+
 // DummyLoginRequest Запрос для получения тестового токена
 type DummyLoginRequest struct {
 	// Role Роль пользователя в системе
@@ -56,6 +193,15 @@ type DummyLoginRequest struct {
 
 // Error Стандартный ответ с ошибкой
 type Error struct {
+	// Code Стабильный машиночитаемый код ошибки. Клиентам следует ветвиться по нему, а не по тексту message.
+	// Доменные коды: AUTH_VALIDATION, INVALID_CREDENTIALS, TOKEN_EXPIRED, TOKEN_MALFORMED,
+	// TOKEN_INVALID_SIGNATURE, TOKEN_INVALID, EMAIL_TAKEN, PVZ_INVALID_CITY, INVALID_PRODUCT_TYPE,
+	// RECEPTION_ALREADY_OPEN, NO_OPEN_RECEPTION, RECEPTION_EMPTY, PRODUCT_NOT_FOUND, RECEPTION_NOT_FOUND, PVZ_NOT_FOUND, PVZ_INACTIVE, CITY_VALIDATION, CITY_NOT_FOUND, CITY_ALREADY_EXISTS, CITY_IN_USE, PRODUCT_TYPE_VALIDATION, PRODUCT_TYPE_NOT_FOUND, PRODUCT_TYPE_ALREADY_EXISTS, INVALID_PRODUCT_ATTRIBUTES, INVALID_PRODUCT_IDENTITY, DUPLICATE_BARCODE,
+	// RECEPTION_CLOSED, STRICT_LIFO, INVALID_DELETION_REASON, NO_DELETED_PRODUCT,
+	// INVALID_RECEPTION_TRANSITION, NEWER_RECEPTION_EXISTS.
+	// Общие коды: BAD_REQUEST, UNAUTHORIZED, FORBIDDEN, NOT_FOUND, CONFLICT, SERVICE_UNAVAILABLE, INTERNAL.
+	Code string `json:"code"`
+
 	// Message Текстовое описание ошибки
 	Message string `json:"message"`
 }
@@ -66,52 +212,197 @@ type InitiateReceptionRequest struct {
 	PvzId openapi_types.UUID `json:"pvzId"`
 }
 
+// Invite Созданное приглашение. Токен показывается только один раз.
+type Invite struct {
+	Email     *openapi_types.Email `json:"email"`
+	ExpiresAt time.Time            `json:"expiresAt"`
+	Id        openapi_types.UUID   `json:"id"`
+
+	// Role Роль пользователя в системе
+	Role UserRole `json:"role"`
+
+	// Token Одноразовый токен приглашения (передается в inviteToken при регистрации)
+	Token string `json:"token"`
+}
+
+// JSONWebKey Открытый ключ подписи JWT (RFC 7517)
+type JSONWebKey struct {
+	Alg JSONWebKeyAlg `json:"alg"`
+	Crv *string       `json:"crv,omitempty"`
+
+	// E Экспонента RSA (base64url)
+	E *string `json:"e,omitempty"`
+
+	// Kid Совпадает с заголовком kid токенов, подписанных этим ключом
+	Kid string        `json:"kid"`
+	Kty JSONWebKeyKty `json:"kty"`
+
+	// N Модуль RSA (base64url)
+	N   *string `json:"n,omitempty"`
+	Use string  `json:"use"`
+
+	// X Открытый ключ Ed25519 (base64url)
+	X *string `json:"x,omitempty"`
+}
+
+// JSONWebKeyAlg defines model for JSONWebKey.Alg.
+type JSONWebKeyAlg string
+
+// JSONWebKeyKty defines model for JSONWebKey.Kty.
+type JSONWebKeyKty string
+
+// JSONWebKeySet defines model for JSONWebKeySet.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
 // LoginUserRequest Данные для входа пользователя
 type LoginUserRequest struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// LogoutRequest Если передан refresh-токен, отзывается и его семейство
+type LogoutRequest struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
 // MessageResponse defines model for MessageResponse.
 type MessageResponse struct {
 	Message string `json:"message"`
 }
 
+// OrderId Номер заказа во внешней системе (без пробелов)
+type OrderId = string
+
 // PVZ Пункт выдачи заказов
 type PVZ struct {
-	// City Город расположения ПВЗ
+	// City Город расположения ПВЗ - имя (name) активного города из справочника GET /cities
 	City PVZCity `json:"city"`
 
+	// DeactivatedAt Дата и время деактивации (null для активного ПВЗ)
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+
 	// Id Уникальный идентификатор ПВЗ
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
+	// IsActive false - ПВЗ деактивирован, скрыт из списков и не принимает новые приемки
+	IsActive *bool `json:"isActive,omitempty"`
+
 	// RegistrationDate Дата и время регистрации ПВЗ
 	RegistrationDate *time.Time `json:"registrationDate,omitempty"`
+
+	// StrictLifo true - из открытой приемки можно удалить только последний добавленный товар
+	StrictLifo *bool `json:"strictLifo,omitempty"`
+}
+
+// PVZAssignment Назначение сотрудника на ПВЗ. Сотрудник работает с приемками только назначенных ПВЗ.
+type PVZAssignment struct {
+	AssignedAt time.Time `json:"assignedAt"`
+
+	// AssignedBy ID модератора, назначившего сотрудника
+	AssignedBy *openapi_types.UUID `json:"assignedBy"`
+	PvzId      openapi_types.UUID  `json:"pvzId"`
 }
 
-// PVZCity Город расположения ПВЗ
-type PVZCity string
+// PVZCity Город расположения ПВЗ - имя (name) активного города из справочника GET /cities
+type PVZCity = string
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
 
 // Product Товар, принятый в ПВЗ
 type Product struct {
+	// Attributes Дополнительные атрибуты товара; проверяются по attributesSchema его типа
+	Attributes *ProductAttributes `json:"attributes,omitempty"`
+
+	// Barcode Штрихкод/SKU товара - печатные ASCII символы без пробелов. Уникален в пределах приемки.
+	Barcode *Barcode `json:"barcode,omitempty"`
+
+	// CreatedBy ID пользователя, добавившего товар (из claim sub; отсутствует для токенов /dummyLogin)
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+
 	// DateTimeAdded Дата и время добавления товара в приемку
 	DateTimeAdded *time.Time `json:"dateTimeAdded,omitempty"`
 
+	// DeletedAt Момент удаления товара (только для удаленных товаров при include_deleted=true)
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// DeletedBy ID пользователя, удалившего товар (если известен)
+	DeletedBy *openapi_types.UUID `json:"deletedBy,omitempty"`
+
 	// Id Уникальный идентификатор товара
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
+	// OrderId Номер заказа во внешней системе (без пробелов)
+	OrderId *OrderId `json:"orderId,omitempty"`
+
 	// ReceptionId ID приемки, к которой относится товар
 	ReceptionId *openapi_types.UUID `json:"receptionId,omitempty"`
 
-	// Type Тип товара
+	// Type Тип товара - код активного типа из справочника GET /product-types
 	Type *ProductType `json:"type,omitempty"`
 }
 
+// ProductAttributes Дополнительные атрибуты товара; проверяются по attributesSchema его типа
+type ProductAttributes map[string]interface{}
+
+// ProductBatchItemRequest Товар пачки (ПВЗ задается в пути запроса)
+type ProductBatchItemRequest struct {
+	// Attributes Дополнительные атрибуты товара; проверяются по attributesSchema его типа
+	Attributes *ProductAttributes `json:"attributes,omitempty"`
+
+	// Barcode Штрихкод/SKU товара - печатные ASCII символы без пробелов. Уникален в пределах приемки.
+	Barcode *Barcode `json:"barcode,omitempty"`
+
+	// OrderId Номер заказа во внешней системе (без пробелов)
+	OrderId *OrderId `json:"orderId,omitempty"`
+
+	// Type Тип товара - код активного типа из справочника GET /product-types
+	Type ProductType `json:"type"`
+}
+
+// ProductBatchItemResult Результат по одному товару пачки
+type ProductBatchItemResult struct {
+	// Error Стандартный ответ с ошибкой
+	Error *Error `json:"error,omitempty"`
+
+	// Index Позиция товара в items запроса
+	Index int `json:"index"`
+
+	// Product Товар, принятый в ПВЗ
+	Product *Product `json:"product,omitempty"`
+
+	// Status added - товар добавлен; rejected - товар отклонен (причина в error); skipped - товар корректен, но пачка allOrNothing отклонена из-за других товаров
+	Status ProductBatchItemResultStatus `json:"status"`
+}
+
+// ProductBatchItemResultStatus added - товар добавлен; rejected - товар отклонен (причина в error); skipped - товар корректен, но пачка allOrNothing отклонена из-за других товаров
+type ProductBatchItemResultStatus string
+
 // ProductInfo Товар, принятый в ПВЗ
 type ProductInfo = Product
 
-// ProductType Тип товара
-type ProductType string
+// ProductType Тип товара - код активного типа из справочника GET /product-types
+type ProductType = string
+
+// ProductTypeInfo Тип товара из справочника
+type ProductTypeInfo struct {
+	// AttributesSchema Схема дополнительных атрибутов - подмножество JSON Schema для объекта с плоскими свойствами. Атрибуты, не описанные в properties, отклоняются.
+	AttributesSchema *AttributeSchema `json:"attributesSchema,omitempty"`
+
+	// Code Тип товара - код активного типа из справочника GET /product-types
+	Code      ProductType `json:"code"`
+	CreatedAt *time.Time  `json:"createdAt,omitempty"`
+
+	// IsActive false - новые товары этого типа добавить нельзя
+	IsActive bool `json:"isActive"`
+
+	// Names Локализованные названия (локаль -> название); "ru" обязателен
+	Names map[string]string `json:"names"`
+}
 
 // PvzListItem Один элемент в списке ПВЗ, включая ПВЗ и его приемки
 type PvzListItem struct {
@@ -133,6 +424,13 @@ type PvzListResponseKeyset struct {
 
 // Reception Запись о приемке товаров
 type Reception struct {
+	// ClosedBy ID пользователя, закрывшего или отменившего приемку. Отсутствует, пока приемка открыта,
+	// если пользователь неизвестен или приемку закрыл фоновый обработчик.
+	ClosedBy *openapi_types.UUID `json:"closedBy,omitempty"`
+
+	// CreatedBy ID пользователя, начавшего приемку (из claim sub; отсутствует для токенов /dummyLogin)
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+
 	// DateTime Дата и время начала приемки
 	DateTime *time.Time `json:"dateTime,omitempty"`
 
@@ -142,7 +440,12 @@ type Reception struct {
 	// PvzId ID пункта выдачи заказов, к которому относится приемка
 	PvzId *openapi_types.UUID `json:"pvzId,omitempty"`
 
-	// Status Статус приемки товаров
+	// StaleFlaggedAt Когда фоновый обработчик пометил приемку как зависшую (stale_receptions.action=flag).
+	// Отсутствует, если приемка не помечалась.
+	StaleFlaggedAt *time.Time `json:"staleFlaggedAt,omitempty"`
+
+	// Status Статус приемки товаров. Переходы: in_progress -> closed (close_last_reception),
+	// closed -> in_progress (reopen, модератор), in_progress -> cancelled (cancel, конечный статус).
 	Status *ReceptionStatus `json:"status,omitempty"`
 }
 
@@ -154,23 +457,100 @@ type ReceptionInfo struct {
 	Reception Reception `json:"reception"`
 }
 
-// ReceptionStatus Статус приемки товаров
+// ReceptionListResponse Страница истории приемок ПВЗ (от новых к старым) и курсор для следующей страницы
+type ReceptionListResponse struct {
+	Items []Reception `json:"items"`
+
+	// NextAfterDateTime Курсор для следующей страницы: dateTime последней приемки
+	NextAfterDateTime *time.Time `json:"next_after_date_time"`
+
+	// NextAfterId Курсор для следующей страницы: id последней приемки
+	NextAfterId *openapi_types.UUID `json:"next_after_id"`
+}
+
+// ReceptionStatus Статус приемки товаров. Переходы: in_progress -> closed (close_last_reception),
+// closed -> in_progress (reopen, модератор), in_progress -> cancelled (cancel, конечный статус).
 type ReceptionStatus string
 
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
 // RegisterUserRequest Данные для регистрации нового пользователя
 type RegisterUserRequest struct {
-	Email    openapi_types.Email `json:"email"`
-	Password string              `json:"password"`
+	Email openapi_types.Email `json:"email"`
+
+	// InviteToken Токен приглашения (POST /users/invites). Без приглашения можно зарегистрироваться
+	// только сотрудником и только при REGISTRATION_MODE=open.
+	InviteToken *string `json:"inviteToken,omitempty"`
+	Password    string  `json:"password"`
 
 	// Role Роль пользователя в системе
-	Role UserRole `json:"role"`
+	Role *UserRole `json:"role,omitempty"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	// NewPassword Должен удовлетворять политике паролей (password_policy)
+	NewPassword string `json:"newPassword"`
+
+	// Token Одноразовый токен сброса, полученный через notifier
+	Token string `json:"token"`
 }
 
 // Token JWT токен доступа
 type Token = string
 
+// TokenPair Пара токенов, выдаваемая /login и /token/refresh
+type TokenPair struct {
+	// ExpiresAt Момент истечения access-токена (jwt.token_ttl)
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// RefreshExpiresAt Момент истечения refresh-токена (jwt.refresh_token_ttl)
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+
+	// RefreshToken Одноразовый refresh-токен; при обмене выдается новый, повторное использование отзывает все семейство
+	RefreshToken string `json:"refreshToken"`
+
+	// Token JWT токен доступа
+	Token Token `json:"token"`
+}
+
+// UpdateCityRequest Изменение города (PATCH). Код неизменяем; отсутствующие поля не меняются.
+type UpdateCityRequest struct {
+	IsActive *bool `json:"isActive,omitempty"`
+
+	// Name Новое имя; переносится на все ПВЗ города
+	Name     *string `json:"name,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// UpdatePVZRequest Изменение ПВЗ (PATCH). Отсутствующие поля не меняются.
+type UpdatePVZRequest struct {
+	// City Город расположения ПВЗ - имя (name) активного города из справочника GET /cities
+	City *PVZCity `json:"city,omitempty"`
+
+	// StrictLifo Включить/выключить строгий LIFO для удаления товаров
+	StrictLifo *bool `json:"strictLifo,omitempty"`
+}
+
+// UpdateProductTypeRequest Полная замена названий, активности и схемы атрибутов (PUT). Отсутствующая схема - атрибуты не допускаются.
+type UpdateProductTypeRequest struct {
+	// AttributesSchema Схема дополнительных атрибутов - подмножество JSON Schema для объекта с плоскими свойствами. Атрибуты, не описанные в properties, отклоняются.
+	AttributesSchema *AttributeSchema  `json:"attributesSchema,omitempty"`
+	IsActive         bool              `json:"isActive"`
+	Names            map[string]string `json:"names"`
+}
+
 // User Данные пользователя (без хеша пароля)
 type User struct {
+	// CreatedAt Время регистрации
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// DisabledAt Время отключения учетной записи модератором
+	DisabledAt *time.Time `json:"disabledAt"`
+
 	// Email Email пользователя (уникальный)
 	Email openapi_types.Email `json:"email"`
 
@@ -181,9 +561,38 @@ type User struct {
 	Role UserRole `json:"role"`
 }
 
+// UserListResponse Страница пользователей (в порядке регистрации) и курсор для следующей страницы
+type UserListResponse struct {
+	Items []User `json:"items"`
+
+	// NextAfterCreatedAt Курсор для следующей страницы: createdAt последнего пользователя
+	NextAfterCreatedAt *time.Time `json:"next_after_created_at"`
+
+	// NextAfterId Курсор для следующей страницы: id последнего пользователя
+	NextAfterId *openapi_types.UUID `json:"next_after_id"`
+}
+
 // UserRole Роль пользователя в системе
 type UserRole string
 
+// GetCitiesParams defines parameters for GetCities.
+type GetCitiesParams struct {
+	// IncludeInactive Включить неактивные города
+	IncludeInactive *bool `form:"include_inactive,omitempty" json:"include_inactive,omitempty"`
+}
+
+// GetProductTypesParams defines parameters for GetProductTypes.
+type GetProductTypesParams struct {
+	// IncludeInactive Включить неактивные типы
+	IncludeInactive *bool `form:"include_inactive,omitempty" json:"include_inactive,omitempty"`
+}
+
+// GetProductsByBarcodeParams defines parameters for GetProductsByBarcode.
+type GetProductsByBarcodeParams struct {
+	// Barcode Штрихкод/SKU товара
+	Barcode Barcode `form:"barcode" json:"barcode"`
+}
+
 // GetPvzListKeysetParams defines parameters for GetPvzListKeyset.
 type GetPvzListKeysetParams struct {
 	// StartDate Начальная дата диапазона (фильтр для приемок)
@@ -200,22 +609,121 @@ type GetPvzListKeysetParams struct {
 
 	// AfterId Курсор: ID последнего элемента предыдущей страницы (для уникальности)
 	AfterId *openapi_types.UUID `form:"after_id,omitempty" json:"after_id,omitempty"`
+
+	// IncludeInactive Включить деактивированные ПВЗ (по умолчанию скрыты)
+	IncludeInactive *bool `form:"include_inactive,omitempty" json:"include_inactive,omitempty"`
+
+	// Mine Только ПВЗ, на которые назначен пользователь токена (требует токена /login)
+	Mine *bool `form:"mine,omitempty" json:"mine,omitempty"`
+}
+
+// GetPvzReceptionsParams defines parameters for GetPvzReceptions.
+type GetPvzReceptionsParams struct {
+	// Status Фильтр по статусу приемки
+	Status *ReceptionStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// StartDate Начальная дата диапазона (по dateTime приемки, включительно)
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона (по dateTime приемки, включительно)
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Limit Количество приемок на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// AfterDateTime Курсор: dateTime последней приемки предыдущей страницы (RFC3339)
+	AfterDateTime *time.Time `form:"after_date_time,omitempty" json:"after_date_time,omitempty"`
+
+	// AfterId Курсор: ID последней приемки предыдущей страницы
+	AfterId *openapi_types.UUID `form:"after_id,omitempty" json:"after_id,omitempty"`
+}
+
+// GetPvzCurrentReceptionParams defines parameters for GetPvzCurrentReception.
+type GetPvzCurrentReceptionParams struct {
+	// IncludeDeleted Включить удаленные товары (с заполненным deletedAt)
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// GetReceptionByIdParams defines parameters for GetReceptionById.
+type GetReceptionByIdParams struct {
+	// IncludeDeleted Включить удаленные товары (с заполненным deletedAt)
+	IncludeDeleted *bool `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Role Фильтр по роли
+	Role *UserRole `form:"role,omitempty" json:"role,omitempty"`
+
+	// Disabled Фильтр по признаку отключения учетной записи
+	Disabled *bool `form:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Limit Количество пользователей на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// AfterCreatedAt Курсор: createdAt последнего пользователя предыдущей страницы (RFC3339)
+	AfterCreatedAt *time.Time `form:"after_created_at,omitempty" json:"after_created_at,omitempty"`
+
+	// AfterId Курсор: ID последнего пользователя предыдущей страницы
+	AfterId *openapi_types.UUID `form:"after_id,omitempty" json:"after_id,omitempty"`
 }
 
+// PostCitiesJSONRequestBody defines body for PostCities for application/json ContentType.
+type PostCitiesJSONRequestBody = CreateCityRequest
+
+// PatchCityJSONRequestBody defines body for PatchCity for application/json ContentType.
+type PatchCityJSONRequestBody = UpdateCityRequest
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody = DummyLoginRequest
 
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody = LoginUserRequest
 
+// PostLogoutJSONRequestBody defines body for PostLogout for application/json ContentType.
+type PostLogoutJSONRequestBody = LogoutRequest
+
+// PostMePasswordJSONRequestBody defines body for PostMePassword for application/json ContentType.
+type PostMePasswordJSONRequestBody = ChangePasswordRequest
+
+// PostPasswordResetJSONRequestBody defines body for PostPasswordReset for application/json ContentType.
+type PostPasswordResetJSONRequestBody = ResetPasswordRequest
+
+// PostPasswordResetRequestJSONRequestBody defines body for PostPasswordResetRequest for application/json ContentType.
+type PostPasswordResetRequestJSONRequestBody = PasswordResetRequest
+
+// PostProductTypesJSONRequestBody defines body for PostProductTypes for application/json ContentType.
+type PostProductTypesJSONRequestBody = ProductTypeInfo
+
+// PutProductTypeJSONRequestBody defines body for PutProductType for application/json ContentType.
+type PutProductTypeJSONRequestBody = UpdateProductTypeRequest
+
 // PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
 type PostProductsJSONRequestBody = AddProductRequest
 
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PatchPvzJSONRequestBody defines body for PatchPvz for application/json ContentType.
+type PatchPvzJSONRequestBody = UpdatePVZRequest
+
+// PostProductsBatchJSONRequestBody defines body for PostProductsBatch for application/json ContentType.
+type PostProductsBatchJSONRequestBody = AddProductsBatchRequest
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody = InitiateReceptionRequest
 
+// DeleteReceptionProductJSONRequestBody defines body for DeleteReceptionProduct for application/json ContentType.
+type DeleteReceptionProductJSONRequestBody = DeleteProductRequest
+
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody = RegisterUserRequest
+
+// PostTokenRefreshJSONRequestBody defines body for PostTokenRefresh for application/json ContentType.
+type PostTokenRefreshJSONRequestBody = RefreshTokenRequest
+
+// PostUserInviteJSONRequestBody defines body for PostUserInvite for application/json ContentType.
+type PostUserInviteJSONRequestBody = CreateInviteRequest
+
+// PutUserRoleJSONRequestBody defines body for PutUserRole for application/json ContentType.
+type PutUserRoleJSONRequestBody = ChangeUserRoleRequest
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// HandleListProductTypes - обработчик для GET /product-types (любой авторизованный пользователь)
+func (h *Handler) HandleListProductTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	includeInactive := false
+	if incStr := r.URL.Query().Get("include_inactive"); incStr != "" {
+		v, err := strconv.ParseBool(incStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'include_inactive' (ожидается true/false)")
+			return
+		}
+		includeInactive = v
+	}
+
+	types, err := h.typeService.ListProductTypes(ctx, includeInactive)
+	if err != nil {
+		respondWithServiceError(ctx, w, err, "Ошибка получения справочника типов товаров")
+		return
+	}
+
+	items := make([]ProductTypeInfo, 0, len(types))
+	for _, pt := range types {
+		items = append(items, toAPIProductType(pt))
+	}
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+// HandleCreateProductType - обработчик для POST /product-types (только модератор)
+func (h *Handler) HandleCreateProductType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ProductTypeInfo
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	created, err := h.typeService.CreateProductType(ctx, domain.ProductTypeInfo{
+		Code:             domain.ProductType(req.Code),
+		Names:            req.Names,
+		IsActive:         req.IsActive,
+		AttributesSchema: toDomainAttributeSchema(req.AttributesSchema),
+	})
+	if err != nil {
+		// PRODUCT_TYPE_VALIDATION -> 400, PRODUCT_TYPE_ALREADY_EXISTS -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при добавлении типа товара")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, toAPIProductType(created))
+}
+
+// HandleGetProductType - обработчик для GET /product-types/{code} (любой авторизованный пользователь)
+func (h *Handler) HandleGetProductType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pt, err := h.typeService.GetProductType(ctx, domain.ProductType(chi.URLParam(r, "code")))
+	if err != nil {
+		// PRODUCT_TYPE_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при получении типа товара")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIProductType(pt))
+}
+
+// HandleUpdateProductType - обработчик для PUT /product-types/{code} (только модератор)
+func (h *Handler) HandleUpdateProductType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req UpdateProductTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	pt, err := h.typeService.UpdateProductType(ctx, domain.ProductTypeInfo{
+		Code:             domain.ProductType(chi.URLParam(r, "code")),
+		Names:            req.Names,
+		IsActive:         req.IsActive,
+		AttributesSchema: toDomainAttributeSchema(req.AttributesSchema),
+	})
+	if err != nil {
+		// PRODUCT_TYPE_NOT_FOUND -> 404, PRODUCT_TYPE_VALIDATION -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при изменении типа товара")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIProductType(pt))
+}
+
+// toAPIProductType конвертирует domain.ProductTypeInfo -> api.ProductTypeInfo
+func toAPIProductType(pt domain.ProductTypeInfo) ProductTypeInfo {
+	out := ProductTypeInfo{
+		Code:     ProductType(pt.Code),
+		Names:    pt.Names,
+		IsActive: pt.IsActive,
+	}
+	if pt.AttributesSchema != nil {
+		schema := AttributeSchema{Properties: make(map[string]AttributeProperty, len(pt.AttributesSchema.Properties))}
+		if pt.AttributesSchema.Type != "" {
+			schemaType := AttributeSchemaType(pt.AttributesSchema.Type)
+			schema.Type = &schemaType
+		}
+		if len(pt.AttributesSchema.Required) > 0 {
+			schema.Required = &pt.AttributesSchema.Required
+		}
+		for name, p := range pt.AttributesSchema.Properties {
+			prop := AttributeProperty{Type: AttributePropertyType(p.Type), MaxLength: p.MaxLength, Minimum: p.Minimum, Maximum: p.Maximum}
+			if p.Description != "" {
+				prop.Description = &p.Description
+			}
+			if len(p.Enum) > 0 {
+				prop.Enum = &p.Enum
+			}
+			schema.Properties[name] = prop
+		}
+		out.AttributesSchema = &schema
+	}
+	if !pt.CreatedAt.IsZero() {
+		out.CreatedAt = &pt.CreatedAt
+	}
+	return out
+}
+
+// toDomainAttributeSchema конвертирует схему атрибутов из запроса (nil -> атрибуты не допускаются)
+func toDomainAttributeSchema(s *AttributeSchema) *domain.AttributeSchema {
+	if s == nil {
+		return nil
+	}
+	out := &domain.AttributeSchema{Properties: make(map[string]domain.AttributeProperty, len(s.Properties))}
+	if s.Type != nil {
+		out.Type = string(*s.Type)
+	}
+	if s.Required != nil {
+		out.Required = *s.Required
+	}
+	for name, p := range s.Properties {
+		prop := domain.AttributeProperty{Type: string(p.Type), MaxLength: p.MaxLength, Minimum: p.Minimum, Maximum: p.Maximum}
+		if p.Description != nil {
+			prop.Description = *p.Description
+		}
+		if p.Enum != nil {
+			prop.Enum = *p.Enum
+		}
+		out.Properties[name] = prop
+	}
+	return out
+}
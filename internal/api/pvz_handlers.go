@@ -1,12 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid" // Нужен для uuid.Parse и *uuid.UUID
 
 	// Используем псевдоним, чтобы избежать конфликта имен, если он был
@@ -34,34 +37,12 @@ func (h *Handler) HandleCreatePVZ(w http.ResponseWriter, r *http.Request) {
 	}
 	createdPVZDomain, err := h.pvzService.CreatePVZ(r.Context(), pvzInput)
 	if err != nil {
-		errMsg := err.Error()
-		if errMsg == "создание ПВЗ возможно только в городах: Москва, Санкт-Петербург, Казань" {
-			respondWithError(w, http.StatusBadRequest, errMsg)
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Ошибка при создании ПВЗ: "+errMsg)
-		}
+		// PVZ_INVALID_CITY -> 400, остальное -> 500
+		respondWithServiceError(r.Context(), w, err, "Ошибка при создании ПВЗ")
 		return
 	}
 
-	// Конвертация domain.PVZ -> api.PVZ
-	// Поля Id и RegistrationDate в api.PVZ - указатели
-	var responseIDPtr *openapi_types.UUID
-	if createdPVZDomain.ID != uuid.Nil {
-		// Так как openapi_types.UUID это псевдоним uuid.UUID,
-		// мы можем просто взять адрес от доменного ID
-		responseIDPtr = &createdPVZDomain.ID
-	}
-	var responseDatePtr *time.Time
-	if !createdPVZDomain.RegistrationDate.IsZero() {
-		responseDatePtr = &createdPVZDomain.RegistrationDate
-	}
-
-	responsePVZ := PVZ{
-		Id:               responseIDPtr,                  // *uuid.UUID
-		City:             PVZCity(createdPVZDomain.City), // api.PVZCity
-		RegistrationDate: responseDatePtr,                // *time.Time
-	}
-	respondWithJSON(w, http.StatusCreated, responsePVZ)
+	respondWithJSON(w, http.StatusCreated, toAPIPVZ(createdPVZDomain))
 }
 
 // HandleListPVZ - обработчик для GET /pvz с использованием Keyset Pagination
@@ -71,13 +52,13 @@ func (h *Handler) HandleListPVZ(w http.ResponseWriter, r *http.Request) {
 
 	// Limit
 	limitStr := q.Get("limit")
-	limit := 10 // Default
+	limit := h.limits.PVZPageDefault
 	if limitStr != "" {
 		l, errConv := strconv.Atoi(limitStr) // Используем другую переменную для ошибки
-		if errConv == nil && l >= 1 && l <= 30 {
+		if errConv == nil && l >= 1 && l <= h.limits.PVZPageMax {
 			limit = l
 		} else {
-			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'limit' (1-30)")
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Некорректное значение для параметра 'limit' (1-%d)", h.limits.PVZPageMax))
 			return
 		}
 	}
@@ -127,8 +108,36 @@ func (h *Handler) HandleListPVZ(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Деактивированные ПВЗ по умолчанию скрыты
+	includeInactive := false
+	if incStr := q.Get("include_inactive"); incStr != "" {
+		v, errParse := strconv.ParseBool(incStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'include_inactive' (ожидается true/false)")
+			return
+		}
+		includeInactive = v
+	}
+
+	// mine=true - только ПВЗ, на которые назначен пользователь токена
+	var assignedTo *uuid.UUID
+	if mineStr := q.Get("mine"); mineStr != "" {
+		mine, errParse := strconv.ParseBool(mineStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'mine' (ожидается true/false)")
+			return
+		}
+		if mine {
+			assignedTo = actorFromContext(r.Context()).KnownUserID()
+			if assignedTo == nil {
+				respondWithError(w, http.StatusBadRequest, "Параметр 'mine' требует токена пользователя (/login)")
+				return
+			}
+		}
+	}
+
 	// --- 2. Вызов сервиса с НОВЫМИ параметрами ---
-	serviceResult, err := h.pvzService.GetPVZList(r.Context(), startDatePtr, endDatePtr, limit, afterRegistrationDatePtr, afterIDPtr)
+	serviceResult, err := h.pvzService.GetPVZList(r.Context(), startDatePtr, endDatePtr, limit, afterRegistrationDatePtr, afterIDPtr, includeInactive, assignedTo)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка получения списка ПВЗ: "+err.Error())
 		return
@@ -146,32 +155,7 @@ func (h *Handler) HandleListPVZ(w http.ResponseWriter, r *http.Request) {
 			apiProducts := make([]ProductInfo, 0, len(domainProducts)) // ProductInfo = Product
 
 			for _, pDomain := range domainProducts {
-				// Конвертируем domain.Product -> api.Product (все поля указатели)
-				var apiProductIDPtr *openapi_types.UUID
-				if pDomain.ID != uuid.Nil {
-					apiProductIDPtr = &pDomain.ID
-				}
-				var apiProductReceptionIDPtr *openapi_types.UUID
-				if pDomain.ReceptionID != uuid.Nil {
-					apiProductReceptionIDPtr = &pDomain.ReceptionID
-				}
-				var apiProductTypePtr *ProductType
-				if pDomain.Type != "" {
-					tempType := ProductType(pDomain.Type)
-					apiProductTypePtr = &tempType
-				}
-				var apiProductDateTimeAddedPtr *time.Time
-				if !pDomain.DateTimeAdded.IsZero() {
-					apiProductDateTimeAddedPtr = &pDomain.DateTimeAdded
-				}
-
-				apiProduct := ProductInfo{
-					Id:            apiProductIDPtr,
-					ReceptionId:   apiProductReceptionIDPtr,
-					DateTimeAdded: apiProductDateTimeAddedPtr,
-					Type:          apiProductTypePtr,
-				}
-				apiProducts = append(apiProducts, apiProduct)
+				apiProducts = append(apiProducts, ProductInfo(toAPIProduct(pDomain)))
 			}
 
 			// Конвертируем domain.Reception -> api.Reception (все поля указатели)
@@ -206,24 +190,9 @@ func (h *Handler) HandleListPVZ(w http.ResponseWriter, r *http.Request) {
 			apiReceptions = append(apiReceptions, apiReceptionItem)
 		}
 
-		// Конвертируем domain.PVZ -> api.PVZ (Id и RegistrationDate - указатели)
-		var apiPvzIDPtr *openapi_types.UUID
-		if pvzDomain.ID != uuid.Nil {
-			apiPvzIDPtr = &pvzDomain.ID
-		}
-		var apiPvzRegDatePtr *time.Time
-		if !pvzDomain.RegistrationDate.IsZero() {
-			apiPvzRegDatePtr = &pvzDomain.RegistrationDate
-		}
-
-		apiPvzBase := PVZ{
-			Id:               apiPvzIDPtr,
-			City:             PVZCity(pvzDomain.City), // Не указатель
-			RegistrationDate: apiPvzRegDatePtr,
-		}
 		apiItem := PvzListItem{
-			Pvz:        apiPvzBase,    // Структура PVZ (не указатель)
-			Receptions: apiReceptions, // Срез ReceptionInfo
+			Pvz:        toAPIPVZ(pvzDomain), // Структура PVZ (не указатель)
+			Receptions: apiReceptions,       // Срез ReceptionInfo
 		}
 		apiItems = append(apiItems, apiItem)
 	}
@@ -237,3 +206,108 @@ func (h *Handler) HandleListPVZ(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, response)
 }
+
+// HandleGetPVZ - обработчик для GET /pvz/{pvzId}
+func (h *Handler) HandleGetPVZ(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	pvz, err := h.pvzService.GetPVZ(ctx, pvzID)
+	if err != nil {
+		// PVZ_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при получении ПВЗ")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIPVZ(pvz))
+}
+
+// HandleUpdatePVZ - обработчик для PATCH /pvz/{pvzId} (только модератор)
+func (h *Handler) HandleUpdatePVZ(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	var req UpdatePVZRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.City == nil && req.StrictLifo == nil {
+		respondWithError(w, http.StatusBadRequest, "Не передано ни одного изменяемого поля")
+		return
+	}
+	update := domain.PVZUpdate{StrictLIFO: req.StrictLifo}
+	if req.City != nil {
+		city := string(*req.City)
+		update.City = &city
+	}
+
+	pvz, err := h.pvzService.UpdatePVZ(ctx, pvzID, update)
+	if err != nil {
+		// PVZ_NOT_FOUND -> 404, PVZ_INVALID_CITY -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при изменении ПВЗ")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIPVZ(pvz))
+}
+
+// HandleDeactivatePVZ - обработчик для POST /pvz/{pvzId}/deactivate (только модератор)
+func (h *Handler) HandleDeactivatePVZ(w http.ResponseWriter, r *http.Request) {
+	h.handleSetPVZActive(w, r, h.pvzService.DeactivatePVZ, "Ошибка при деактивации ПВЗ")
+}
+
+// HandleReactivatePVZ - обработчик для POST /pvz/{pvzId}/reactivate (только модератор)
+func (h *Handler) HandleReactivatePVZ(w http.ResponseWriter, r *http.Request) {
+	h.handleSetPVZActive(w, r, h.pvzService.ReactivatePVZ, "Ошибка при активации ПВЗ")
+}
+
+// handleSetPVZActive - общая часть deactivate/reactivate: разбор pvzId и ответ текущим состоянием ПВЗ
+func (h *Handler) handleSetPVZActive(w http.ResponseWriter, r *http.Request, action func(context.Context, uuid.UUID) (domain.PVZ, error), internalMessage string) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	pvz, err := action(ctx, pvzID)
+	if err != nil {
+		// PVZ_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, internalMessage)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIPVZ(pvz))
+}
+
+// toAPIPVZ конвертирует domain.PVZ -> api.PVZ (пустые значения -> nil)
+func toAPIPVZ(pvz domain.PVZ) PVZ {
+	out := PVZ{
+		City:          PVZCity(pvz.City),
+		IsActive:      &pvz.IsActive,
+		DeactivatedAt: pvz.DeactivatedAt,
+		StrictLifo:    &pvz.StrictLIFO,
+	}
+	if pvz.ID != uuid.Nil {
+		// openapi_types.UUID - псевдоним uuid.UUID, можно взять адрес доменного ID
+		out.Id = &pvz.ID
+	}
+	if !pvz.RegistrationDate.IsZero() {
+		out.RegistrationDate = &pvz.RegistrationDate
+	}
+	return out
+}
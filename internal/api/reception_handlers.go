@@ -1,18 +1,20 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
-	"errors" // Добавляем для errors.Is
+	"fmt"
 
 	// Убираем, если fmt.Sprintf не используется в respondWithError
-	"log/slog"
 	"net/http"
+	"strconv"
 
 	// "strings" // Больше не нужен для проверки ошибок
 	"time"
 
 	"github.com/Artem0405/pvz-service/internal/domain"
-	"github.com/Artem0405/pvz-service/internal/repository" // Нужен для ошибок репозитория
+	"github.com/Artem0405/pvz-service/internal/errmap"
+	"github.com/Artem0405/pvz-service/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -38,20 +40,10 @@ func (h *Handler) HandleInitiateReception(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	receptionDomain, err := h.receptionService.InitiateReception(ctx, req.PvzId)
+	receptionDomain, err := h.receptionService.InitiateReception(ctx, req.PvzId, actorFromContext(ctx))
 	if err != nil {
-		// --- ИСПРАВЛЕНО: Используем errors.Is ---
-		// Проверяем на конкретную ошибку "уже открыта" (предполагаем, что сервис ее возвращает или оборачивает)
-		// Вам может понадобиться определить свою ошибку в пакете service или domain, например domain.ErrReceptionAlreadyOpen
-		// Здесь для примера проверяем исходное сообщение, но лучше проверять тип/значение ошибки.
-		// if errors.Is(err, service.ErrReceptionAlreadyOpen) { // Пример с кастомной ошибкой сервиса
-		if err.Error() == "предыдущая приемка для этого ПВЗ еще не закрыта" { // Оставляем проверку строки, если кастомной ошибки нет
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			// Логируем ошибку сервера
-			slog.ErrorContext(ctx, "Ошибка сервиса при инициации приемки", slog.Any("error", err), slog.Any("pvz_id", req.PvzId))
-			respondWithError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера при инициации приемки") // Не показываем детали внутренней ошибки клиенту
-		}
+		// Статус и код ответа выбирает errmap по доменной ошибке (RECEPTION_ALREADY_OPEN -> 400)
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при инициации приемки")
 		return
 	}
 
@@ -108,54 +100,115 @@ func (h *Handler) HandleAddProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	productTypeDomain := domain.ProductType(req.Type)
-	if productTypeDomain != domain.TypeElectronics && productTypeDomain != domain.TypeClothes && productTypeDomain != domain.TypeShoes {
-		respondWithError(w, http.StatusBadRequest, "Недопустимое значение для поля 'type'. Ожидается 'электроника', 'одежда' или 'обувь'.")
+	// Тип и атрибуты проверяются сервисом по справочнику типов товаров
+	input := domain.ProductInput{Type: domain.ProductType(req.Type)}
+	if req.Barcode != nil {
+		input.Barcode = *req.Barcode
+	}
+	if req.OrderId != nil {
+		input.OrderID = *req.OrderId
+	}
+	if req.Attributes != nil {
+		input.Attributes = *req.Attributes
+	}
+
+	productDomain, err := h.receptionService.AddProduct(ctx, req.PvzId, actorFromContext(ctx), input)
+	if err != nil {
+		// NO_OPEN_RECEPTION / INVALID_PRODUCT_TYPE / INVALID_PRODUCT_ATTRIBUTES / INVALID_PRODUCT_IDENTITY -> 400,
+		// DUPLICATE_BARCODE -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при добавлении товара")
 		return
 	}
 
-	productDomain, err := h.receptionService.AddProduct(ctx, req.PvzId, productTypeDomain)
+	respondWithJSON(w, http.StatusCreated, toAPIProduct(productDomain))
+}
+
+// HandleAddProductsBatch - обработчик для POST /pvz/{pvzId}/products:batch
+func (h *Handler) HandleAddProductsBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
 	if err != nil {
-		// --- ИСПРАВЛЕНО: Используем errors.Is ---
-		// Проверяем на известные ошибки репозитория/сервиса
-		if errors.Is(err, repository.ErrReceptionNotFound) { // Предполагаем, что сервис пробрасывает эту ошибку
-			respondWithError(w, http.StatusBadRequest, "нет открытой приемки для данного ПВЗ, чтобы добавить товар")
-		} else if err.Error() == "недопустимый тип товара" { // Если валидация типа происходит и в сервисе
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			slog.ErrorContext(ctx, "Ошибка сервиса при добавлении товара", slog.Any("error", err), slog.Any("pvz_id", req.PvzId), slog.String("type", string(req.Type)))
-			respondWithError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера при добавлении товара")
-		}
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
 		return
 	}
 
-	// Конвертация domain.Product -> api.Product
-	var apiIdPtr *openapi_types.UUID
-	if productDomain.ID != uuid.Nil {
-		apiIdPtr = &productDomain.ID
+	var req AddProductsBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
 	}
-	var apiReceptionIdPtr *openapi_types.UUID
-	if productDomain.ReceptionID != uuid.Nil {
-		apiReceptionIdPtr = &productDomain.ReceptionID
+	defer r.Body.Close()
+
+	if len(req.Items) == 0 || len(req.Items) > h.limits.ProductBatchMax {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Поле 'items' должно содержать от 1 до %d товаров", h.limits.ProductBatchMax))
+		return
 	}
-	var apiTypePtr *ProductType
-	if productDomain.Type != "" {
-		typeWrapper := ProductType(productDomain.Type)
-		apiTypePtr = &typeWrapper
+	inputs := make([]domain.ProductInput, len(req.Items))
+	for i, item := range req.Items {
+		inputs[i] = domain.ProductInput{Type: domain.ProductType(item.Type)}
+		if item.Barcode != nil {
+			inputs[i].Barcode = *item.Barcode
+		}
+		if item.OrderId != nil {
+			inputs[i].OrderID = *item.OrderId
+		}
+		if item.Attributes != nil {
+			inputs[i].Attributes = *item.Attributes
+		}
 	}
-	var apiDateTimeAddedPtr *time.Time
-	if !productDomain.DateTimeAdded.IsZero() {
-		apiDateTimeAddedPtr = &productDomain.DateTimeAdded
+	allOrNothing := req.AllOrNothing != nil && *req.AllOrNothing
+
+	result, err := h.receptionService.AddProductsBatch(ctx, pvzID, actorFromContext(ctx), inputs, allOrNothing)
+	if err != nil {
+		// NO_OPEN_RECEPTION -> 400, остальное -> 500. Ошибки отдельных товаров приходят в result
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при пакетном добавлении товаров")
+		return
 	}
 
-	productAPI := Product{
-		Id:            apiIdPtr,
-		ReceptionId:   apiReceptionIdPtr,
-		DateTimeAdded: apiDateTimeAddedPtr,
-		Type:          apiTypePtr,
+	resp := AddProductsBatchResponse{
+		ReceptionId: result.ReceptionID,
+		Added:       result.Added,
+		Items:       make([]ProductBatchItemResult, 0, len(result.Items)),
+	}
+	for _, item := range result.Items {
+		out := ProductBatchItemResult{Index: item.Index, Status: ProductBatchItemResultStatus(item.Status)}
+		switch item.Status {
+		case service.BatchItemAdded:
+			product := toAPIProduct(item.Product)
+			out.Product = &product
+		case service.BatchItemRejected:
+			_, code := errmap.HTTP(item.Err)
+			out.Error = &Error{Code: code, Message: item.Err.Error()}
+			resp.Rejected++
+		}
+		resp.Items = append(resp.Items, out)
 	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
 
-	respondWithJSON(w, http.StatusCreated, productAPI)
+// HandleFindProductsByBarcode - обработчик для GET /products?barcode=...
+func (h *Handler) HandleFindProductsByBarcode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	barcode := r.URL.Query().Get("barcode")
+	if barcode == "" {
+		respondWithError(w, http.StatusBadRequest, "Параметр 'barcode' является обязательным")
+		return
+	}
+
+	products, err := h.receptionService.FindProductsByBarcode(ctx, barcode, actorFromContext(ctx))
+	if err != nil {
+		// INVALID_PRODUCT_IDENTITY -> 400, PVZ_NOT_ASSIGNED -> 403, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при поиске товаров")
+		return
+	}
+
+	items := make([]Product, 0, len(products))
+	for _, p := range products {
+		items = append(items, toAPIProduct(p))
+	}
+	respondWithJSON(w, http.StatusOK, items)
 }
 
 // HandleDeleteLastProduct - обработчик для POST /pvz/{pvzId}/delete_last_product
@@ -177,18 +230,10 @@ func (h *Handler) HandleDeleteLastProduct(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = h.receptionService.DeleteLastProduct(ctx, pvzID)
+	err = h.receptionService.DeleteLastProduct(ctx, pvzID, actorFromContext(ctx))
 	if err != nil {
-		// --- ИСПРАВЛЕНО: Используем errors.Is ---
-		if errors.Is(err, repository.ErrReceptionNotFound) {
-			respondWithError(w, http.StatusBadRequest, "нет открытой приемки для данного ПВЗ, чтобы удалить товар")
-		} else if errors.Is(err, repository.ErrProductNotFound) {
-			// Эта ошибка может приходить от GetLastProductFromReception или DeleteProductByID
-			respondWithError(w, http.StatusBadRequest, "в текущей открытой приемке нет товаров для удаления или товар уже удален")
-		} else {
-			slog.ErrorContext(ctx, "Ошибка сервиса при удалении товара", slog.Any("error", err), slog.Any("pvz_id", pvzID))
-			respondWithError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера при удалении товара")
-		}
+		// NO_OPEN_RECEPTION / RECEPTION_EMPTY -> 400, PRODUCT_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при удалении товара")
 		return
 	}
 
@@ -196,6 +241,59 @@ func (h *Handler) HandleDeleteLastProduct(w http.ResponseWriter, r *http.Request
 	respondWithJSON(w, http.StatusOK, responseMessage)
 }
 
+// HandleUndoLastDeletion - обработчик для POST /pvz/{pvzId}/undo_last_deletion
+func (h *Handler) HandleUndoLastDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	product, err := h.receptionService.UndoLastDeletion(ctx, pvzID, actorFromContext(ctx))
+	if err != nil {
+		// NO_OPEN_RECEPTION / NO_DELETED_PRODUCT -> 400, DUPLICATE_BARCODE -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при отмене удаления товара")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIProduct(product))
+}
+
+// HandleDeleteProduct - обработчик для DELETE /receptions/{receptionId}/products/{productId}
+func (h *Handler) HandleDeleteProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	receptionID, err := uuid.Parse(chi.URLParam(r, "receptionId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID приемки в пути: "+err.Error())
+		return
+	}
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID товара в пути: "+err.Error())
+		return
+	}
+
+	var req DeleteProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	err = h.receptionService.DeleteProduct(ctx, receptionID, productID, actorFromContext(ctx), req.Reason)
+	if err != nil {
+		// RECEPTION_CLOSED / STRICT_LIFO / INVALID_DELETION_REASON -> 400,
+		// RECEPTION_NOT_FOUND / PRODUCT_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при удалении товара")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, MessageResponse{Message: "Товар удален из приемки"})
+}
+
 // HandleCloseLastReception - обработчик для POST /pvz/{pvzId}/close_last_reception
 func (h *Handler) HandleCloseLastReception(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -215,16 +313,10 @@ func (h *Handler) HandleCloseLastReception(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	closedReceptionDomain, err := h.receptionService.CloseLastReception(ctx, pvzID)
+	closedReceptionDomain, err := h.receptionService.CloseLastReception(ctx, pvzID, actorFromContext(ctx))
 	if err != nil {
-		// --- ИСПРАВЛЕНО: Используем errors.Is ---
-		if errors.Is(err, repository.ErrReceptionNotFound) {
-			// Эта ошибка может приходить от GetLastOpenReceptionByPVZ или CloseReceptionByID
-			respondWithError(w, http.StatusBadRequest, "не удалось закрыть приемку, так как она не найдена или уже закрыта")
-		} else {
-			slog.ErrorContext(ctx, "Ошибка сервиса при закрытии приемки", slog.Any("error", err), slog.Any("pvz_id", pvzID))
-			respondWithError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера при закрытии приемки")
-		}
+		// NO_OPEN_RECEPTION -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при закрытии приемки")
 		return
 	}
 
@@ -257,6 +349,258 @@ func (h *Handler) HandleCloseLastReception(w http.ResponseWriter, r *http.Reques
 	respondWithJSON(w, http.StatusOK, closedReceptionAPI)
 }
 
+// HandleReopenReception - обработчик для POST /receptions/{receptionId}/reopen (только модератор)
+func (h *Handler) HandleReopenReception(w http.ResponseWriter, r *http.Request) {
+	h.handleReceptionTransition(w, r, h.receptionService.ReopenReception, "Внутренняя ошибка сервера при переоткрытии приемки")
+}
+
+// HandleCancelReception - обработчик для POST /receptions/{receptionId}/cancel
+func (h *Handler) HandleCancelReception(w http.ResponseWriter, r *http.Request) {
+	h.handleReceptionTransition(w, r, h.receptionService.CancelReception, "Внутренняя ошибка сервера при отмене приемки")
+}
+
+// handleReceptionTransition - общая часть reopen/cancel: разбор receptionId и ответ приемкой с новым статусом
+func (h *Handler) handleReceptionTransition(w http.ResponseWriter, r *http.Request, action func(context.Context, uuid.UUID, domain.Actor) (domain.Reception, error), internalMessage string) {
+	ctx := r.Context()
+
+	receptionID, err := uuid.Parse(chi.URLParam(r, "receptionId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID приемки в пути: "+err.Error())
+		return
+	}
+
+	reception, err := action(ctx, receptionID, actorFromContext(ctx))
+	if err != nil {
+		// INVALID_RECEPTION_TRANSITION / PVZ_INACTIVE / RECEPTION_ALREADY_OPEN -> 400,
+		// RECEPTION_NOT_FOUND -> 404, NEWER_RECEPTION_EXISTS -> 409, остальное -> 500
+		respondWithServiceError(ctx, w, err, internalMessage)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIReception(reception))
+}
+
+// HandleGetReception - обработчик для GET /receptions/{receptionId}
+func (h *Handler) HandleGetReception(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	receptionID, err := uuid.Parse(chi.URLParam(r, "receptionId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID приемки в пути: "+err.Error())
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	details, err := h.receptionService.GetReception(ctx, receptionID, actorFromContext(ctx), includeDeleted)
+	if err != nil {
+		// RECEPTION_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при получении приемки")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIReceptionInfo(details))
+}
+
+// HandleGetCurrentReception - обработчик для GET /pvz/{pvzId}/receptions/current
+func (h *Handler) HandleGetCurrentReception(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	details, err := h.receptionService.GetCurrentReception(ctx, pvzID, actorFromContext(ctx), includeDeleted)
+	if err != nil {
+		// RECEPTION_NOT_FOUND (нет открытой приемки) -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при получении текущей приемки")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIReceptionInfo(details))
+}
+
+// HandleListPVZReceptions - обработчик для GET /pvz/{pvzId}/receptions
+func (h *Handler) HandleListPVZReceptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	filter := domain.ReceptionFilter{Limit: h.limits.ReceptionPageDefault}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		l, errConv := strconv.Atoi(limitStr)
+		if errConv != nil || l < 1 || l > h.limits.ReceptionPageMax {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Некорректное значение для параметра 'limit' (1-%d)", h.limits.ReceptionPageMax))
+			return
+		}
+		filter.Limit = l
+	}
+
+	if statusStr := q.Get("status"); statusStr != "" {
+		status := domain.ReceptionStatus(statusStr)
+		if !status.IsValid() {
+			respondWithError(w, http.StatusBadRequest, "Недопустимое значение для параметра 'status'. Ожидается 'in_progress', 'closed' или 'cancelled'.")
+			return
+		}
+		filter.Status = &status
+	}
+
+	if sdStr := q.Get("startDate"); sdStr != "" {
+		t, errParse := time.Parse(time.RFC3339, sdStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат startDate (ожидается RFC3339)")
+			return
+		}
+		filter.StartDate = &t
+	}
+	if edStr := q.Get("endDate"); edStr != "" {
+		t, errParse := time.Parse(time.RFC3339, edStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат endDate (ожидается RFC3339)")
+			return
+		}
+		filter.EndDate = &t
+	}
+
+	afterDateStr := q.Get("after_date_time")
+	afterIDStr := q.Get("after_id")
+	if afterDateStr != "" && afterIDStr != "" {
+		t, errParse := time.Parse(time.RFC3339Nano, afterDateStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат after_date_time (ожидается RFC3339)")
+			return
+		}
+		id, errParse := uuid.Parse(afterIDStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат after_id (ожидается UUID)")
+			return
+		}
+		filter.AfterDateTime, filter.AfterID = &t, &id
+	} else if afterDateStr != "" || afterIDStr != "" {
+		respondWithError(w, http.StatusBadRequest, "Для пагинации необходимо передать оба параметра курсора (after_date_time и after_id) или ни одного")
+		return
+	}
+
+	result, err := h.receptionService.ListReceptions(ctx, pvzID, actorFromContext(ctx), filter)
+	if err != nil {
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при получении истории приемок")
+		return
+	}
+
+	items := make([]Reception, 0, len(result.Receptions))
+	for _, rcp := range result.Receptions {
+		items = append(items, toAPIReception(rcp))
+	}
+	respondWithJSON(w, http.StatusOK, ReceptionListResponse{
+		Items:             items,
+		NextAfterDateTime: result.NextAfterDateTime,
+		NextAfterId:       result.NextAfterID,
+	})
+}
+
+// toAPIReception конвертирует domain.Reception -> api.Reception (пустые значения -> nil)
+func toAPIReception(rcp domain.Reception) Reception {
+	out := Reception{}
+	if rcp.ID != uuid.Nil {
+		out.Id = &rcp.ID
+	}
+	if rcp.PVZID != uuid.Nil {
+		out.PvzId = &rcp.PVZID
+	}
+	if rcp.Status != "" {
+		status := ReceptionStatus(rcp.Status)
+		out.Status = &status
+	}
+	if !rcp.DateTime.IsZero() {
+		out.DateTime = &rcp.DateTime
+	}
+	out.StaleFlaggedAt = rcp.StaleFlaggedAt
+	out.CreatedBy = rcp.CreatedBy
+	out.ClosedBy = rcp.ClosedBy
+	return out
+}
+
+// toAPIProduct конвертирует domain.Product -> api.Product (пустые значения -> nil)
+func toAPIProduct(p domain.Product) Product {
+	out := Product{}
+	if p.ID != uuid.Nil {
+		out.Id = &p.ID
+	}
+	if p.ReceptionID != uuid.Nil {
+		out.ReceptionId = &p.ReceptionID
+	}
+	if p.Type != "" {
+		productType := ProductType(p.Type)
+		out.Type = &productType
+	}
+	if !p.DateTimeAdded.IsZero() {
+		out.DateTimeAdded = &p.DateTimeAdded
+	}
+	if p.Barcode != "" {
+		out.Barcode = &p.Barcode
+	}
+	if p.OrderID != "" {
+		out.OrderId = &p.OrderID
+	}
+	out.Attributes = toAPIProductAttributes(p.Attributes)
+	out.DeletedAt = p.DeletedAt
+	out.DeletedBy = p.DeletedBy
+	out.CreatedBy = p.CreatedBy
+	return out
+}
+
+// parseIncludeDeleted разбирает query параметр include_deleted (по умолчанию false).
+// При некорректном значении отвечает 400 и возвращает ok=false.
+func parseIncludeDeleted(w http.ResponseWriter, r *http.Request) (includeDeleted bool, ok bool) {
+	raw := r.URL.Query().Get("include_deleted")
+	if raw == "" {
+		return false, true
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'include_deleted' (ожидается true/false)")
+		return false, false
+	}
+	return v, true
+}
+
+// toAPIProductAttributes конвертирует атрибуты товара (пустые -> nil, поле не выводится)
+func toAPIProductAttributes(attrs map[string]any) *ProductAttributes {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := ProductAttributes(attrs)
+	return &out
+}
+
+// toAPIReceptionInfo конвертирует приемку с товарами в api.ReceptionInfo
+func toAPIReceptionInfo(details service.ReceptionDetails) ReceptionInfo {
+	products := make([]ProductInfo, 0, len(details.Products))
+	for _, p := range details.Products {
+		products = append(products, ProductInfo(toAPIProduct(p)))
+	}
+	return ReceptionInfo{
+		Reception: toAPIReception(details.Reception),
+		Products:  products,
+	}
+}
+
 // --- Убедитесь, что функция respondWithJSON использует json.NewEncoder ---
 // (Эта функция, вероятно, находится в handler.go или аналогичном файле)
 /*
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types" // Импорт для openapi_types.Email
+)
+
+// HandleListUsers - обработчик для GET /users (только модератор)
+func (h *Handler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := r.URL.Query()
+	filter := domain.UserFilter{Limit: h.limits.UserPageDefault}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		l, errConv := strconv.Atoi(limitStr)
+		if errConv != nil || l < 1 || l > h.limits.UserPageMax {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Некорректное значение для параметра 'limit' (1-%d)", h.limits.UserPageMax))
+			return
+		}
+		filter.Limit = l
+	}
+
+	if role := q.Get("role"); role != "" {
+		if !domain.IsValidRole(role) {
+			respondWithError(w, http.StatusBadRequest, "Недопустимое значение для параметра 'role'. Ожидается 'employee' или 'moderator'.")
+			return
+		}
+		filter.Role = &role
+	}
+
+	if disabledStr := q.Get("disabled"); disabledStr != "" {
+		v, errConv := strconv.ParseBool(disabledStr)
+		if errConv != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректное значение для параметра 'disabled' (ожидается true/false)")
+			return
+		}
+		filter.Disabled = &v
+	}
+
+	afterCreatedStr := q.Get("after_created_at")
+	afterIDStr := q.Get("after_id")
+	if afterCreatedStr != "" && afterIDStr != "" {
+		t, errParse := time.Parse(time.RFC3339Nano, afterCreatedStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат after_created_at (ожидается RFC3339)")
+			return
+		}
+		id, errParse := uuid.Parse(afterIDStr)
+		if errParse != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный формат after_id (ожидается UUID)")
+			return
+		}
+		filter.AfterCreatedAt, filter.AfterID = &t, &id
+	} else if afterCreatedStr != "" || afterIDStr != "" {
+		respondWithError(w, http.StatusBadRequest, "Для пагинации необходимо передать оба параметра курсора (after_created_at и after_id) или ни одного")
+		return
+	}
+
+	result, err := h.userService.ListUsers(ctx, filter)
+	if err != nil {
+		respondWithServiceError(ctx, w, err, "Внутренняя ошибка сервера при получении списка пользователей")
+		return
+	}
+
+	items := make([]User, 0, len(result.Users))
+	for _, u := range result.Users {
+		items = append(items, toAPIUser(u))
+	}
+	respondWithJSON(w, http.StatusOK, UserListResponse{
+		Items:              items,
+		NextAfterCreatedAt: result.NextAfterCreatedAt,
+		NextAfterId:        result.NextAfterID,
+	})
+}
+
+// HandleGetUser - обработчик для GET /users/{userId} (только модератор)
+func (h *Handler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, userID)
+	if err != nil {
+		// USER_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при получении пользователя")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// HandleDisableUser - обработчик для POST /users/{userId}/disable (только модератор)
+func (h *Handler) HandleDisableUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.DisableUser(ctx, userID, actorFromContext(ctx))
+	if err != nil {
+		// USER_NOT_FOUND -> 404, USER_SELF_MODIFICATION -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при отключении пользователя")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// HandleEnableUser - обработчик для POST /users/{userId}/enable (только модератор)
+func (h *Handler) HandleEnableUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.EnableUser(ctx, userID, actorFromContext(ctx))
+	if err != nil {
+		// USER_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при включении пользователя")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// HandleChangeUserRole - обработчик для PUT /users/{userId}/role (только модератор)
+func (h *Handler) HandleChangeUserRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return
+	}
+
+	var req PutUserRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.Role == "" {
+		respondWithError(w, http.StatusBadRequest, "Поле 'role' обязательно")
+		return
+	}
+
+	user, err := h.userService.ChangeUserRole(ctx, userID, string(req.Role), actorFromContext(ctx))
+	if err != nil {
+		// USER_NOT_FOUND -> 404, USER_INVALID_ROLE / USER_SELF_MODIFICATION -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при смене роли пользователя")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// HandleCreateInvite - обработчик для POST /users/invites (только модератор)
+func (h *Handler) HandleCreateInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PostUserInviteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.Role == "" {
+		respondWithError(w, http.StatusBadRequest, "Поле 'role' обязательно")
+		return
+	}
+	email := ""
+	if req.Email != nil {
+		email = string(*req.Email)
+	}
+
+	created, err := h.userService.CreateInvite(ctx, string(req.Role), email, actorFromContext(ctx))
+	if err != nil {
+		// USER_INVALID_ROLE -> 400, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при создании приглашения")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, toAPIInvite(created))
+}
+
+// HandleListUserPVZs - обработчик для GET /users/{userId}/pvz (только модератор)
+func (h *Handler) HandleListUserPVZs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return
+	}
+
+	assignments, err := h.userService.ListUserPVZs(ctx, userID)
+	if err != nil {
+		// USER_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при получении назначений пользователя")
+		return
+	}
+
+	items := make([]PVZAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		items = append(items, PVZAssignment{PvzId: a.PVZID, AssignedAt: a.AssignedAt, AssignedBy: a.AssignedBy})
+	}
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+// HandleAssignPVZ - обработчик для PUT /users/{userId}/pvz/{pvzId} (только модератор)
+func (h *Handler) HandleAssignPVZ(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, pvzID, ok := parseUserPVZPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.AssignPVZ(ctx, userID, pvzID, actorFromContext(ctx)); err != nil {
+		// USER_NOT_FOUND / PVZ_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при назначении пользователя на ПВЗ")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnassignPVZ - обработчик для DELETE /users/{userId}/pvz/{pvzId} (только модератор)
+func (h *Handler) HandleUnassignPVZ(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, pvzID, ok := parseUserPVZPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.UnassignPVZ(ctx, userID, pvzID, actorFromContext(ctx)); err != nil {
+		// ASSIGNMENT_NOT_FOUND -> 404, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка при снятии назначения на ПВЗ")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseUserPVZPath разбирает {userId} и {pvzId} из пути; при ошибке сам отвечает 400
+func parseUserPVZPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID пользователя в пути: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный формат ID ПВЗ в пути: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, pvzID, true
+}
+
+// toAPIUser конвертирует domain.User -> api.User (без хеша пароля)
+func toAPIUser(u domain.User) User {
+	out := User{
+		Id:         &u.ID,
+		Email:      openapi_types.Email(u.Email),
+		Role:       UserRole(u.Role),
+		DisabledAt: u.DisabledAt,
+	}
+	if !u.CreatedAt.IsZero() {
+		out.CreatedAt = &u.CreatedAt
+	}
+	return out
+}
+
+// toAPIInvite конвертирует service.CreatedInvite -> api.Invite
+func toAPIInvite(c service.CreatedInvite) Invite {
+	out := Invite{
+		Id:        c.Invite.ID,
+		Token:     c.Token,
+		Role:      UserRole(c.Invite.Role),
+		ExpiresAt: c.Invite.ExpiresAt,
+	}
+	if c.Invite.Email != "" {
+		email := openapi_types.Email(c.Invite.Email)
+		out.Email = &email
+	}
+	return out
+}
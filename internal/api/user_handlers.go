@@ -3,11 +3,14 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"io"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/Artem0405/pvz-service/internal/domain"
-	"github.com/Artem0405/pvz-service/internal/repository"
-	openapi_types "github.com/oapi-codegen/runtime/types" // Импорт для openapi_types.Email и UUID
+	"github.com/Artem0405/pvz-service/internal/service"
 )
 
 // HandleRegister - обработчик для POST /register
@@ -29,35 +32,26 @@ func (h *Handler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, "Поле 'password' обязательно")
 		return
 	}
-	if req.Role == "" { // Тип PostRegisterJSONBodyRole - базовый string
-		respondWithError(w, http.StatusBadRequest, "Поле 'role' обязательно")
-		return
+	// Роль необязательна: по умолчанию employee, при регистрации по приглашению - роль приглашения
+	role, inviteToken := "", ""
+	if req.Role != nil {
+		role = string(*req.Role)
+	}
+	if req.InviteToken != nil {
+		inviteToken = *req.InviteToken
 	}
 
 	// Передаем значения в сервис, приводя кастомные типы к string
-	userDomain, err := h.authService.Register(r.Context(), string(req.Email), req.Password, string(req.Role))
+	userDomain, err := h.authService.Register(r.Context(), string(req.Email), req.Password, role, inviteToken)
 	if err != nil {
-		// Обработка ошибок (логика остается)
-		if errors.Is(err, repository.ErrUserDuplicateEmail) || err.Error() == "пользователь с таким email уже существует" {
-			respondWithError(w, http.StatusConflict, err.Error()) // 409 Conflict
-		} else if err.Error() == "email и пароль не могут быть пустыми" || err.Error() == "недопустимая роль пользователя" {
-			respondWithError(w, http.StatusBadRequest, err.Error()) // 400 Bad Request
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Не удалось зарегистрировать пользователя")
-		}
+		// EMAIL_TAKEN -> 409, AUTH_VALIDATION -> 400,
+		// REGISTRATION_ROLE_FORBIDDEN / INVITE_REQUIRED / INVITE_INVALID -> 403, остальное -> 500
+		respondWithServiceError(r.Context(), w, err, "Не удалось зарегистрировать пользователя")
 		return
 	}
 
-	// Конвертация domain.User в api.User для ответа
-	// Используем сгенерированный тип User
-	userAPI := User{
-		Id:    &userDomain.ID,                        // Id в DTO - *openapi_types.UUID, берем адрес
-		Email: openapi_types.Email(userDomain.Email), // Приводим string к openapi_types.Email
-		Role:  UserRole(userDomain.Role),             // Приводим string к UserRole
-	}
-
 	// Отвечаем 201 Created с данными пользователя (уже в формате API DTO)
-	respondWithJSON(w, http.StatusCreated, userAPI)
+	respondWithJSON(w, http.StatusCreated, toAPIUser(userDomain))
 }
 
 // HandleLogin - обработчик для POST /login
@@ -78,22 +72,148 @@ func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenString, err := h.authService.Login(r.Context(), string(req.Email), req.Password)
+	pair, err := h.authService.Login(r.Context(), string(req.Email), req.Password, clientIP(r))
 	if err != nil {
-		if err.Error() == "неверный email или пароль" {
-			respondWithError(w, http.StatusUnauthorized, err.Error())
-		} else {
-			respondWithError(w, http.StatusInternalServerError, "Ошибка входа в систему")
+		var throttled *service.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
 		}
+		// INVALID_CREDENTIALS -> 401, USER_DISABLED -> 403, LOGIN_THROTTLED -> 429, остальное -> 500
+		respondWithServiceError(r.Context(), w, err, "Ошибка входа в систему")
 		return
 	}
 
-	// --- ИЗМЕНЕНИЕ: Возвращаем JSON-объект ---
-	// Используем map[string]string для простоты, или сгенерированный тип, если он объект
-	// Предположим, ваш сгенерированный Token - это все еще псевдоним string, поэтому используем map
-	responsePayload := map[string]string{"token": tokenString}
-	respondWithJSON(w, http.StatusOK, responsePayload)
-	// --- Конец изменения ---
+	respondWithJSON(w, http.StatusOK, toAPITokenPair(pair))
+}
+
+// HandleRefreshToken - обработчик для POST /token/refresh (без авторизации, по refresh-токену)
+func (h *Handler) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req PostTokenRefreshJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.RefreshToken == "" {
+		respondWithError(w, http.StatusBadRequest, "Поле 'refreshToken' обязательно")
+		return
+	}
+
+	pair, err := h.authService.RefreshTokens(r.Context(), req.RefreshToken)
+	if err != nil {
+		// REFRESH_TOKEN_INVALID / REFRESH_TOKEN_REUSED -> 401, USER_DISABLED -> 403, остальное -> 500
+		respondWithServiceError(r.Context(), w, err, "Не удалось обновить токены")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPITokenPair(pair))
+}
+
+// HandleLogout - обработчик для POST /logout (любой авторизованный пользователь).
+// Тело необязательно: без refresh-токена отзывается только текущий access-токен.
+func (h *Handler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PostLogoutJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	principal, _ := PrincipalFromContext(ctx)
+	refreshToken := ""
+	if req.RefreshToken != nil {
+		refreshToken = *req.RefreshToken
+	}
+
+	if err := h.authService.Logout(ctx, principal, refreshToken); err != nil {
+		// REFRESH_TOKEN_INVALID -> 401, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Ошибка выхода из системы")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, MessageResponse{Message: "Токены отозваны"})
+}
+
+// HandleChangePassword - обработчик для POST /me/password (любой авторизованный пользователь).
+// Все прежние токены пользователя отзываются, в ответе - новая пара.
+func (h *Handler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PostMePasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		respondWithError(w, http.StatusBadRequest, "Поля 'currentPassword' и 'newPassword' обязательны")
+		return
+	}
+
+	principal, _ := PrincipalFromContext(ctx)
+	pair, err := h.authService.ChangePassword(ctx, principal, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		var throttled *service.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		// CURRENT_PASSWORD_INVALID / PASSWORD_POLICY -> 400, PASSWORD_CHANGE_FORBIDDEN / USER_DISABLED -> 403,
+		// LOGIN_THROTTLED -> 429, остальное -> 500
+		respondWithServiceError(ctx, w, err, "Не удалось сменить пароль")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toAPITokenPair(pair))
+}
+
+// HandleRequestPasswordReset - обработчик для POST /password/reset-request (без авторизации).
+// Отвечает 202 независимо от того, зарегистрирован ли email.
+func (h *Handler) HandleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PostPasswordResetRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.Email == "" {
+		respondWithError(w, http.StatusBadRequest, "Поле 'email' обязательно")
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(r.Context(), string(req.Email)); err != nil {
+		respondWithServiceError(r.Context(), w, err, "Не удалось запросить сброс пароля")
+		return
+	}
+
+	respondWithJSON(w, http.StatusAccepted, MessageResponse{Message: "Если email зарегистрирован, на него отправлен токен сброса пароля"})
+}
+
+// HandleResetPassword - обработчик для POST /password/reset (без авторизации, по токену сброса)
+func (h *Handler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req PostPasswordResetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректное тело запроса: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if req.Token == "" || req.NewPassword == "" {
+		respondWithError(w, http.StatusBadRequest, "Поля 'token' и 'newPassword' обязательны")
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), req.Token, req.NewPassword); err != nil {
+		// PASSWORD_RESET_INVALID / PASSWORD_POLICY -> 400, USER_DISABLED -> 403, остальное -> 500
+		respondWithServiceError(r.Context(), w, err, "Не удалось сбросить пароль")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, MessageResponse{Message: "Пароль изменен"})
 }
 
 // HandleDummyLogin - обработчик для POST /dummyLogin
@@ -128,4 +248,43 @@ func (h *Handler) HandleDummyLogin(w http.ResponseWriter, r *http.Request) {
 	// --- Конец изменения ---
 }
 
+// HandleJWKS - обработчик для GET /.well-known/jwks.json (без авторизации)
+func (h *Handler) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	set := h.authService.JWKS()
+	out := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(set.Keys))}
+	for _, k := range set.Keys {
+		jwk := JSONWebKey{Kty: JSONWebKeyKty(k.Kty), Kid: k.Kid, Use: k.Use, Alg: JSONWebKeyAlg(k.Alg)}
+		if k.N != "" {
+			jwk.N, jwk.E = &k.N, &k.E
+		}
+		if k.X != "" {
+			jwk.Crv, jwk.X = &k.Crv, &k.X
+		}
+		out.Keys = append(out.Keys, jwk)
+	}
+	// Потребители кешируют набор и перезапрашивают его, встретив незнакомый kid
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	respondWithJSON(w, http.StatusOK, out)
+}
+
+// toAPITokenPair конвертирует service.TokenPair -> api.TokenPair
+func toAPITokenPair(pair service.TokenPair) TokenPair {
+	return TokenPair{
+		Token:            Token(pair.AccessToken),
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	}
+}
+
 // --- Определения старых DTO удалены отсюда ---
+
+// clientIP возвращает IP клиента без порта. RemoteAddr - адрес соединения; RealIPMiddleware заменяет его
+// на X-Real-IP / X-Forwarded-For только для запросов от доверенных прокси (http.trusted_proxies).
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig - конфигурация по умолчанию с обязательными полями, проходящая Validate
+func validConfig() Config {
+	cfg := Default()
+	cfg.DB.Host = "db"
+	cfg.DB.Port = "5432"
+	cfg.DB.User = "user"
+	cfg.DB.Password = "password"
+	cfg.DB.Name = "pvzdb"
+	cfg.JWT.Secret = "test-secret"
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, validConfig().Validate())
+
+	testCases := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{"No DB Settings", func(c *Config) { c.DB.Host = "" }, "db: необходимо задать dsn"},
+		{"Keyword DSN", func(c *Config) {
+			c.DB = DBConfig{DSN: "host=db user=app password=s3cret dbname=pvz", MaxOpenConns: 1, PingTimeout: time.Second}
+		}, ""},
+		{"URL DSN", func(c *Config) { c.DB.DSN = "postgres://app:s3cret@db:5432/pvz?sslmode=disable" }, ""},
+		{"Broken DSN", func(c *Config) { c.DB.DSN = "host=db port=notaport" }, "db.dsn: некорректный DSN"},
+		{"Idle Above Open", func(c *Config) { c.DB.MaxIdleConns = c.DB.MaxOpenConns + 1 }, "db.max_idle_conns"},
+		{"Bad Port", func(c *Config) { c.HTTP.Port = "70000" }, "http.port"},
+		{"Duplicate Port", func(c *Config) { c.GRPC.Port = c.HTTP.Port }, "уже используется"},
+		{"Trusted Proxies", func(c *Config) { c.HTTP.TrustedProxies = []string{"10.0.0.0/8", "::1"} }, ""},
+		{"Bad Trusted Proxy", func(c *Config) { c.HTTP.TrustedProxies = []string{"10.0.0.0/33"} }, "http.trusted_proxies"},
+		{"Bad Log Level", func(c *Config) { c.LogLevel = "LOUD" }, "log_level"},
+		{"Bad Env", func(c *Config) { c.Env = "staging" }, "env:"},
+		{"No JWT Secret", func(c *Config) { c.JWT.Secret = "" }, "jwt.secret"},
+		{"Prod With Dummy Auth", func(c *Config) { c.Env = EnvProd; c.JWT.DummyAuth = true }, "jwt.dummy_auth"},
+		{"Test With Dummy Auth", func(c *Config) { c.Env = EnvTest; c.JWT.DummyAuth = true }, ""},
+		{"Prod With Log Notifier", func(c *Config) { c.Env = EnvProd }, "password_reset.notifier"},
+		{"Prod With File Notifier", func(c *Config) {
+			c.Env = EnvProd
+			c.PasswordReset.Notifier, c.PasswordReset.File = NotifierFile, "/var/lib/pvz/reset.jsonl"
+		}, ""},
+		{"Max Password Length Above Bcrypt Limit", func(c *Config) { c.PasswordPolicy.MaxLength = 100 }, "password_policy.max_length"},
+		{"File Notifier Without File", func(c *Config) { c.PasswordReset.Notifier = NotifierFile }, "password_reset.file"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.modify(&cfg)
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.NotContains(t, err.Error(), "s3cret")
+		})
+	}
+}
+
+func TestLoad_EnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log_level: WARN\njwt:\n  token_ttl: 5m\n"), 0o600))
+
+	t.Setenv("DB_HOST", "db")
+	t.Setenv("DB_PORT", "5432")
+	t.Setenv("DB_USER", "user")
+	t.Setenv("DB_NAME", "pvzdb")
+	t.Setenv("JWT_SECRET", "env-secret")
+	t.Setenv("LOG_LEVEL", "DEBUG")
+	t.Setenv("DB_MAX_OPEN_CONNS", "10")
+	t.Setenv("DB_MAX_IDLE_CONNS", "5")
+	t.Setenv("LOGIN_PROTECTION_ENABLED", "false")
+	t.Setenv("PASSWORD_RESET_TOKEN_TTL", "30m")
+	t.Setenv("HTTP_TRUSTED_PROXIES", "10.0.0.1, 172.16.0.0/12")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.False(t, cfg.JWT.DummyAuth, "/dummyLogin по умолчанию выключен")
+	assert.Equal(t, "DEBUG", cfg.LogLevel, "env перекрывает YAML")
+	assert.Equal(t, 5*time.Minute, cfg.JWT.TokenTTL, "значение из YAML без env сохраняется")
+	assert.Equal(t, "env-secret", cfg.JWT.Secret)
+	assert.Equal(t, 10, cfg.DB.MaxOpenConns)
+	assert.False(t, cfg.LoginProtection.Enabled)
+	assert.Equal(t, 30*time.Minute, cfg.PasswordReset.TokenTTL)
+	assert.Equal(t, []string{"10.0.0.1", "172.16.0.0/12"}, cfg.HTTP.TrustedProxies)
+
+	t.Run("Invalid Values Are Reported", func(t *testing.T) {
+		t.Setenv("DB_MAX_OPEN_CONNS", "many")
+		t.Setenv("LOGIN_PROTECTION_ENABLED", "maybe")
+		t.Setenv("JWT_TOKEN_TTL", "soon")
+		_, err := Load(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DB_MAX_OPEN_CONNS")
+		assert.Contains(t, err.Error(), "LOGIN_PROTECTION_ENABLED")
+		assert.Contains(t, err.Error(), "JWT_TOKEN_TTL")
+	})
+
+	t.Run("Unknown YAML Key", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.yaml")
+		require.NoError(t, os.WriteFile(bad, []byte("jwt:\n  tokn_ttl: 5m\n"), 0o600))
+		_, err := Load(bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestRedacted(t *testing.T) {
+	testCases := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{"URL", "postgres://app:s3cret@db:5432/pvz?sslmode=disable", "postgres://app:%2A%2A%2A@db:5432/pvz?sslmode=disable"},
+		{"URL Query Password", "postgres://app@db:5432/pvz?password=s3cret", "postgres://app@db:5432/pvz?password=%2A%2A%2A"},
+		{"Keyword", "host=db user=app password=s3cret dbname=pvz", "host=db user=app password=*** dbname=pvz"},
+		{"Keyword Quoted", "host=db password='s3 cr\\'et' dbname=pvz", "host=db password=*** dbname=pvz"},
+		{"Keyword Spaces", "host=db password = s3cret", "host=db password = ***"},
+		{"Unparsable", "host=db port=notaport password=s3cret", redacted},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.DB.DSN = tc.dsn
+			assert.Equal(t, tc.want, cfg.Redacted().DB.DSN)
+			assert.NotContains(t, cfg.Dump(), "s3cret")
+		})
+	}
+
+	cfg := validConfig()
+	out := cfg.Redacted()
+	assert.Equal(t, redacted, out.DB.Password)
+	assert.Equal(t, redacted, out.JWT.Secret)
+	assert.Equal(t, "password", cfg.DB.Password, "исходная конфигурация не меняется")
+	assert.False(t, strings.Contains(cfg.Dump(), "test-secret"))
+}
@@ -2,22 +2,123 @@ package domain
 
 import "errors"
 
-// Определяем кастомные ошибки для домена аутентификации
+// ErrorKind - категория доменной ошибки. По ней транспортный слой (HTTP, gRPC)
+// выбирает код ответа, не разбирая текст ошибки.
+type ErrorKind int
+
+const (
+	KindInternal     ErrorKind = iota // Неизвестная/внутренняя ошибка
+	KindValidation                    // Некорректные входные данные
+	KindNotFound                      // Сущность не найдена
+	KindConflict                      // Конфликт с уже существующими данными (дубликат)
+	KindInvalidState                  // Операция недопустима в текущем состоянии сущности
+	KindUnauthorized                  // Ошибка аутентификации
+	KindForbidden                     // Недостаточно прав
+	KindRateLimited                   // Слишком много попыток, повторить позже
+)
+
+// Error - доменная ошибка с машиночитаемым кодом.
+// Code стабилен и отдается клиентам, чтобы они могли ветвиться по нему,
+// а не по тексту Message (который может меняться и переводиться).
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+// NewError - конструктор доменной ошибки
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Error реализует интерфейс error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// AsError ищет доменную ошибку в цепочке err (в том числе обернутую через %w).
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// Ошибки аутентификации
+var (
+	ErrAuthValidation            = NewError(KindValidation, "AUTH_VALIDATION", "ошибка валидации данных аутентификации") // Общая ошибка валидации
+	ErrAuthInvalidCredentials    = NewError(KindUnauthorized, "INVALID_CREDENTIALS", "неверный email или пароль")        // Неверные учетные данные
+	ErrAuthTokenExpired          = NewError(KindUnauthorized, "TOKEN_EXPIRED", "токен истек")                            // Токен просрочен
+	ErrAuthTokenMalformed        = NewError(KindUnauthorized, "TOKEN_MALFORMED", "некорректный формат токена")           // Неверный формат токена
+	ErrAuthTokenInvalidSignature = NewError(KindUnauthorized, "TOKEN_INVALID_SIGNATURE", "неверная подпись токена")      // Ошибка проверки подписи
+	ErrAuthTokenInvalid          = NewError(KindUnauthorized, "TOKEN_INVALID", "невалидный токен")                       // Общая ошибка невалидного токена
+	ErrUserEmailTaken            = NewError(KindConflict, "EMAIL_TAKEN", "пользователь с таким email уже существует")    // Email уже зарегистрирован
+	ErrAuthTokenRevoked          = NewError(KindUnauthorized, "TOKEN_REVOKED", "токен отозван")                          // jti в denylist (logout, отзыв семейства)
+	ErrRefreshTokenInvalid       = NewError(KindUnauthorized, "REFRESH_TOKEN_INVALID", "refresh-токен недействителен")   // Не найден, истек или отозван
+	ErrRefreshTokenReused        = NewError(KindUnauthorized, "REFRESH_TOKEN_REUSED", "refresh-токен уже использован")   // Повторное использование: семейство отозвано
+)
+
+// ErrLoginThrottled - вход временно запрещен: задержка после неудачной попытки или блокировка после серии.
+// Отдается до проверки пароля, одинаково для существующих и несуществующих email.
+var ErrLoginThrottled = NewError(KindRateLimited, "LOGIN_THROTTLED", "слишком много неудачных попыток входа, повторите позже")
+
+// Ошибки регистрации и администрирования пользователей
 var (
-	ErrAuthValidation            = errors.New("ошибка валидации данных аутентификации") // Общая ошибка валидации
-	ErrAuthInvalidCredentials    = errors.New("неверный email или пароль")              // Неверные учетные данные
-	ErrAuthTokenExpired          = errors.New("токен истек")                            // Токен просрочен
-	ErrAuthTokenMalformed        = errors.New("некорректный формат токена")             // Неверный формат токена
-	ErrAuthTokenInvalidSignature = errors.New("неверная подпись токена")                // Ошибка проверки подписи
-	ErrAuthTokenInvalid          = errors.New("невалидный токен")                       // Общая ошибка невалидного токена
-	// Можно добавить другие специфичные ошибки домена, если нужно
+	ErrUserNotFound              = NewError(KindNotFound, "USER_NOT_FOUND", "пользователь не найден")
+	ErrUserDisabled              = NewError(KindForbidden, "USER_DISABLED", "учетная запись отключена")                                                 // Вход и обмен refresh-токена отклоняются
+	ErrUserInvalidRole           = NewError(KindValidation, "USER_INVALID_ROLE", "недопустимая роль пользователя")                                      // Допустимы employee и moderator
+	ErrUserSelfModification      = NewError(KindValidation, "USER_SELF_MODIFICATION", "нельзя отключить себя или изменить свою роль")                   // Защита от потери доступа модератором
+	ErrRegistrationRoleForbidden = NewError(KindForbidden, "REGISTRATION_ROLE_FORBIDDEN", "самостоятельно можно зарегистрироваться только сотрудником") // Модераторов создают по приглашению
+	ErrInviteRequired            = NewError(KindForbidden, "INVITE_REQUIRED", "регистрация возможна только по приглашению")                             // registration.mode = invite
+	ErrInviteInvalid             = NewError(KindForbidden, "INVITE_INVALID", "приглашение недействительно")                                             // Не найдено, истекло, использовано или выдано на другой email
 )
 
-// Вы также можете добавить сюда ошибки репозитория, если хотите
-// или оставить их объявление в пакете repository.
-// Например:
-// var (
-// 	ErrUserNotFound        = errors.New("пользователь не найден")
-//  ErrUserDuplicateEmail = errors.New("пользователь с таким email уже существует")
-// )
-// Но лучше, чтобы ошибки, специфичные для репозитория, оставались там.
+// Ошибки смены и сброса пароля
+var (
+	ErrPasswordPolicy          = NewError(KindValidation, "PASSWORD_POLICY", "пароль не соответствует требованиям")               // Длина, классы символов или распространенный пароль; детали - в тексте обертки
+	ErrCurrentPasswordInvalid  = NewError(KindValidation, "CURRENT_PASSWORD_INVALID", "текущий пароль указан неверно")            // POST /me/password
+	ErrPasswordResetInvalid    = NewError(KindValidation, "PASSWORD_RESET_INVALID", "токен сброса пароля недействителен")         // Не найден, истек или уже использован
+	ErrPasswordChangeForbidden = NewError(KindForbidden, "PASSWORD_CHANGE_FORBIDDEN", "смена пароля требует токена пользователя") // Токен /dummyLogin не связан с пользователем
+)
+
+// Ошибки бизнес-логики ПВЗ и приемок.
+// Сервисы возвращают их (или оборачивают через %w), чтобы транспортный слой
+// мог выбрать код ответа через errors.Is / AsError, а не по тексту.
+var (
+	ErrPVZInvalidCity             = NewError(KindValidation, "PVZ_INVALID_CITY", "создание ПВЗ возможно только в городах")                          // Недопустимый город; сервис дописывает список разрешенных
+	ErrInvalidProductType         = NewError(KindValidation, "INVALID_PRODUCT_TYPE", "недопустимый тип товара")                                     // Недопустимый тип товара
+	ErrReceptionAlreadyOpen       = NewError(KindInvalidState, "RECEPTION_ALREADY_OPEN", "предыдущая приемка для этого ПВЗ еще не закрыта")         // Уже есть открытая приемка
+	ErrNoOpenReception            = NewError(KindInvalidState, "NO_OPEN_RECEPTION", "нет открытой приемки для данного ПВЗ")                         // Нет открытой приемки
+	ErrReceptionEmpty             = NewError(KindInvalidState, "RECEPTION_EMPTY", "в текущей открытой приемке нет товаров для удаления")            // В приемке нет товаров
+	ErrProductNotFound            = NewError(KindNotFound, "PRODUCT_NOT_FOUND", "товар не найден")                                                  // Товар не найден (например, удален параллельно)
+	ErrReceptionNotFound          = NewError(KindNotFound, "RECEPTION_NOT_FOUND", "приемка не найдена")                                             // Приемка с таким ID не найдена
+	ErrPVZNotFound                = NewError(KindNotFound, "PVZ_NOT_FOUND", "ПВЗ не найден")                                                        // ПВЗ с таким ID не существует
+	ErrPVZInactive                = NewError(KindInvalidState, "PVZ_INACTIVE", "ПВЗ деактивирован, новые приемки не принимаются")                   // Приемка в деактивированном ПВЗ
+	ErrInvalidProductIdentity     = NewError(KindValidation, "INVALID_PRODUCT_IDENTITY", "некорректный штрихкод или номер заказа")                  // Пробелы/непечатные символы, превышена длина
+	ErrDuplicateBarcode           = NewError(KindConflict, "DUPLICATE_BARCODE", "товар с таким штрихкодом уже есть в этой приемке")                 // Повторное сканирование
+	ErrReceptionClosed            = NewError(KindInvalidState, "RECEPTION_CLOSED", "приемка закрыта, состав товаров менять нельзя")                 // Изменение закрытой приемки
+	ErrStrictLIFO                 = NewError(KindInvalidState, "STRICT_LIFO", "в ПВЗ включен строгий LIFO: удалить можно только последний товар")   // Удаление не последнего товара
+	ErrDeletionReason             = NewError(KindValidation, "INVALID_DELETION_REASON", "укажите причину удаления товара (до 500 символов)")        // Пустая или слишком длинная причина
+	ErrNoDeletedProduct           = NewError(KindInvalidState, "NO_DELETED_PRODUCT", "в открытой приемке нет удаленных товаров для восстановления") // Нечего отменять
+	ErrInvalidReceptionTransition = NewError(KindInvalidState, "INVALID_RECEPTION_TRANSITION", "недопустимая смена статуса приемки")                // Переход не разрешен ReceptionLifecycle
+	ErrNewerReceptionExists       = NewError(KindConflict, "NEWER_RECEPTION_EXISTS", "у ПВЗ есть более новая приемка, переоткрыть эту нельзя")      // Переоткрытие не последней приемки
+	ErrPVZNotAssigned             = NewError(KindForbidden, "PVZ_NOT_ASSIGNED", "сотрудник не назначен на этот ПВЗ")                                // Приемки ПВЗ доступны только назначенным сотрудникам и модераторам
+	ErrAssignmentNotFound         = NewError(KindNotFound, "ASSIGNMENT_NOT_FOUND", "пользователь не назначен на этот ПВЗ")                          // Снятие несуществующего назначения
+)
+
+// Ошибки справочника городов
+var (
+	ErrCityValidation    = NewError(KindValidation, "CITY_VALIDATION", "некорректные данные города")                             // Пустой код/имя, неизвестная таймзона
+	ErrCityNotFound      = NewError(KindNotFound, "CITY_NOT_FOUND", "город не найден")                                           // Города с таким кодом нет
+	ErrCityAlreadyExists = NewError(KindConflict, "CITY_ALREADY_EXISTS", "город с таким кодом или именем уже существует")        // Дубликат кода или имени
+	ErrCityInUse         = NewError(KindConflict, "CITY_IN_USE", "в городе есть ПВЗ, удаление невозможно - деактивируйте город") // На город ссылаются ПВЗ
+)
+
+// Ошибки справочника типов товаров
+var (
+	ErrProductTypeValidation    = NewError(KindValidation, "PRODUCT_TYPE_VALIDATION", "некорректные данные типа товара")           // Пустой код, нет названия "ru", некорректная схема
+	ErrProductTypeNotFound      = NewError(KindNotFound, "PRODUCT_TYPE_NOT_FOUND", "тип товара не найден")                         // Типа с таким кодом нет
+	ErrProductTypeAlreadyExists = NewError(KindConflict, "PRODUCT_TYPE_ALREADY_EXISTS", "тип товара с таким кодом уже существует") // Дубликат кода
+	ErrInvalidProductAttributes = NewError(KindValidation, "INVALID_PRODUCT_ATTRIBUTES", "некорректные атрибуты товара")           // Атрибуты не соответствуют схеме типа
+)
@@ -20,20 +20,96 @@ type LoginRequest struct {
 
 // User - структура пользователя
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"` // Поле добавлено
-	PasswordHash string    `json:"-"`     // Хэш пароля, НЕ отдаем в JSON!
-	Role         string    `json:"role"`
-	// Добавим поля created_at/updated_at, если их нет и они нужны
-	// CreatedAt    time.Time `json:"-"`
-	// UpdatedAt    time.Time `json:"-"`
+	ID           uuid.UUID  `json:"id"`
+	Email        string     `json:"email"` // Поле добавлено
+	PasswordHash string     `json:"-"`     // Хэш пароля, НЕ отдаем в JSON!
+	Role         string     `json:"role"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DisabledAt   *time.Time `json:"disabledAt,omitempty"` // nil - учетная запись активна
+}
+
+// IsDisabled сообщает, отключена ли учетная запись модератором.
+func (u User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// IsValidRole сообщает, является ли role одной из ролей пользователей (employee, moderator).
+func IsValidRole(role string) bool {
+	return role == RoleEmployee || role == RoleModerator
+}
+
+// UserFilter - фильтры и keyset курсор списка пользователей.
+// Пользователи отдаются в порядке регистрации, курсор - (created_at, id) последнего на предыдущей странице.
+type UserFilter struct {
+	Role           *string // nil - любая роль
+	Disabled       *bool   // nil - и активные, и отключенные
+	Limit          int
+	AfterCreatedAt *time.Time // Курсор: оба поля заданы или оба nil
+	AfterID        *uuid.UUID
+}
+
+// UserInvite - приглашение на регистрацию. Сам токен выдается один раз, хранится его хеш.
+type UserInvite struct {
+	ID        uuid.UUID
+	TokenHash []byte
+	Role      string
+	Email     string     // Пусто - для любого email
+	CreatedBy *uuid.UUID // nil - создано токеном без пользователя
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	UsedBy    *uuid.UUID
+}
+
+// PasswordResetToken - одноразовый токен сброса пароля (хранится только хеш значения).
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// PVZAssignment - назначение сотрудника на ПВЗ (таблица user_pvz).
+type PVZAssignment struct {
+	UserID     uuid.UUID
+	PVZID      uuid.UUID
+	AssignedAt time.Time
+	AssignedBy *uuid.UUID // nil - назначено токеном без пользователя
 }
 
 // PVZ ... (остальные структуры без изменений) ...
 type PVZ struct {
-	ID               uuid.UUID `json:"id"`
-	RegistrationDate time.Time `json:"registrationDate"`
-	City             string    `json:"city"`
+	ID               uuid.UUID  `json:"id"`
+	RegistrationDate time.Time  `json:"registrationDate"`
+	City             string     `json:"city"`
+	IsActive         bool       `json:"isActive"`                // false - ПВЗ деактивирован (мягко), новые приемки запрещены
+	DeactivatedAt    *time.Time `json:"deactivatedAt,omitempty"` // Момент деактивации, nil для активного ПВЗ
+	StrictLIFO       bool       `json:"strictLifo"`              // true - удалить можно только последний добавленный товар
+}
+
+// PVZUpdate - изменяемые поля ПВЗ для PATCH /pvz/{pvzId}; nil - поле не меняется.
+type PVZUpdate struct {
+	City       *string
+	StrictLIFO *bool
+}
+
+// City - запись справочника городов, в которых можно открывать ПВЗ.
+// PVZ.City хранит Name города (внешний ключ pvz.city -> cities.name).
+type City struct {
+	Code      string    `json:"code"`     // Стабильный машинный код (msk, spb, ...)
+	Name      string    `json:"name"`     // Отображаемое имя
+	Timezone  string    `json:"timezone"` // IANA таймзона, например Europe/Moscow
+	IsActive  bool      `json:"isActive"` // false - новые ПВЗ в городе открыть нельзя
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CityUpdate - изменяемые поля города для PATCH /cities/{code}; nil - поле не меняется.
+type CityUpdate struct {
+	Name     *string
+	Timezone *string
+	IsActive *bool
 }
 
 type ReceptionStatus string
@@ -41,8 +117,10 @@ type ReceptionStatus string
 const (
 	StatusInProgress ReceptionStatus = "in_progress"
 	StatusClosed     ReceptionStatus = "closed"
+	StatusCancelled  ReceptionStatus = "cancelled" // Приемка начата по ошибке и отменена
 	RoleEmployee                     = "employee"
 	RoleModerator                    = "moderator"
+	RoleSystem                       = "system" // Не выдается в токенах: действия фоновых обработчиков сервиса
 )
 
 type Reception struct {
@@ -50,9 +128,17 @@ type Reception struct {
 	PVZID    uuid.UUID       `json:"pvzId"`
 	DateTime time.Time       `json:"dateTime"`
 	Status   ReceptionStatus `json:"status"`
+	// StaleFlaggedAt - когда фоновый обработчик пометил открытую приемку как зависшую (nil - не помечена)
+	StaleFlaggedAt *time.Time `json:"staleFlaggedAt,omitempty"`
+	CreatedBy      *uuid.UUID `json:"createdBy,omitempty"` // Кто начал приемку, если ID пользователя известен
+	ClosedBy       *uuid.UUID `json:"closedBy,omitempty"`  // Кто закрыл или отменил приемку; nil, пока приемка открыта
 }
+
+// ProductType - код типа товара из справочника product_types (см. ProductTypeInfo).
 type ProductType string
 
+// Коды базовых типов, которые создает миграция справочника. Допустимые типы
+// определяет таблица product_types, а не этот список.
 const (
 	TypeElectronics ProductType = "электроника"
 	TypeClothes     ProductType = "одежда"
@@ -60,10 +146,128 @@ const (
 )
 
 type Product struct {
-	ID            uuid.UUID   `json:"id"`
-	ReceptionID   uuid.UUID   `json:"receptionId"`
-	DateTimeAdded time.Time   `json:"dateTimeAdded"`
-	Type          ProductType `json:"type"`
+	ID            uuid.UUID      `json:"id"`
+	ReceptionID   uuid.UUID      `json:"receptionId"`
+	DateTimeAdded time.Time      `json:"dateTimeAdded"`
+	Type          ProductType    `json:"type"`
+	Barcode       string         `json:"barcode,omitempty"`    // Штрихкод/SKU, уникален в пределах приемки; пусто - не указан
+	OrderID       string         `json:"orderId,omitempty"`    // Номер заказа во внешней системе; пусто - не указан
+	Attributes    map[string]any `json:"attributes,omitempty"` // Дополнительные атрибуты по схеме типа (вес, размер, ...)
+	DeletedAt     *time.Time     `json:"deletedAt,omitempty"`  // Момент мягкого удаления; nil - товар не удален
+	DeletedBy     *uuid.UUID     `json:"deletedBy,omitempty"`  // Кто удалил, если ID пользователя известен
+	CreatedBy     *uuid.UUID     `json:"createdBy,omitempty"`  // Кто добавил, если ID пользователя известен
+}
+
+// ProductInput - данные нового товара для AddProduct.
+type ProductInput struct {
+	Type       ProductType
+	Barcode    string         // Необязателен; повторный штрихкод в той же приемке - ErrDuplicateBarcode
+	OrderID    string         // Необязателен
+	Attributes map[string]any // Проверяются по AttributesSchema типа; nil - без атрибутов
+}
+
+// Actor - кто выполняет операцию; сохраняется в аудите.
+type Actor struct {
+	UserID uuid.UUID // uuid.Nil, если токен не содержит ID пользователя
+	Role   string
+}
+
+// Principal - аутентифицированный пользователь запроса (из claims JWT).
+type Principal struct {
+	UserID uuid.UUID // uuid.Nil для токенов /dummyLogin, у которых нет пользователя
+	Email  string
+	Role   string
+
+	TokenID        uuid.UUID // jti access-токена (нужен для отзыва при logout); uuid.Nil у токенов без jti
+	TokenExpiresAt time.Time
+}
+
+// RefreshToken - выданный refresh-токен (хранится только хеш значения).
+type RefreshToken struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	FamilyID        uuid.UUID // Общий для всех токенов, полученных обменом от одного входа
+	TokenHash       []byte    // SHA-256 значения токена
+	AccessJTI       uuid.UUID // jti access-токена из той же пары
+	AccessExpiresAt time.Time
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+	UsedAt          *time.Time // Обменян на новую пару
+	RevokedAt       *time.Time
+}
+
+// RevokedToken - запись denylist: отозванный access-токен и срок его действия.
+type RevokedToken struct {
+	JTI       uuid.UUID
+	ExpiresAt time.Time
+}
+
+// LoginAttemptScope - по чему считаются неудачные попытки входа.
+type LoginAttemptScope string
+
+const (
+	LoginScopeEmail LoginAttemptScope = "email" // Email из запроса (в нижнем регистре), в том числе незарегистрированный
+	LoginScopeIP    LoginAttemptScope = "ip"    // IP-адрес клиента
+)
+
+// LoginAttemptKey - счетчик неудачных попыток входа.
+type LoginAttemptKey struct {
+	Scope LoginAttemptScope
+	Value string
+}
+
+// LoginAttempt - состояние счетчика: число неудачных попыток подряд и время последней.
+type LoginAttempt struct {
+	Key           LoginAttemptKey
+	Failures      int
+	LastFailureAt time.Time
+	// PrevFailureAt - время последней неудачи до этой попытки (заполняется при резерве, нулевое - записи не было).
+	// Восстанавливается при снятии резерва, чтобы успешные входы не продлевали окно счетчика.
+	PrevFailureAt time.Time
+}
+
+// Actor возвращает пользователя запроса как исполнителя операции для аудита.
+func (p Principal) Actor() Actor {
+	return Actor{UserID: p.UserID, Role: p.Role}
+}
+
+// KnownUserID возвращает ID пользователя или nil, если он неизвестен (для колонок *_by).
+func (a Actor) KnownUserID() *uuid.UUID {
+	if a.UserID == uuid.Nil {
+		return nil
+	}
+	id := a.UserID
+	return &id
+}
+
+// SystemActor - актор для действий, которые сервис выполняет сам (например, автозакрытие приемок).
+var SystemActor = Actor{Role: RoleSystem}
+
+// ReceptionAuditAction - действие над приемкой, сохраняемое в reception_audit.
+type ReceptionAuditAction string
+
+const (
+	AuditAutoClose ReceptionAuditAction = "auto_close" // Зависшая приемка закрыта автоматически
+	AuditStaleFlag ReceptionAuditAction = "stale_flag" // Зависшая приемка помечена для модератора
+)
+
+// ReceptionAuditEntry - запись аудита действия над приемкой.
+type ReceptionAuditEntry struct {
+	ID          uuid.UUID
+	ReceptionID uuid.UUID
+	Action      ReceptionAuditAction
+	Actor       Actor
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// ProductDeletion - запись аудита удаления товара из открытой приемки.
+type ProductDeletion struct {
+	ID        uuid.UUID
+	Product   Product // Снимок удаленного товара
+	DeletedAt time.Time
+	DeletedBy Actor
+	Reason    string
 }
 
 // GetPVZListResult ...
@@ -73,3 +277,23 @@ type GetPVZListResult struct {
 	Products   map[uuid.UUID][]Product   `json:"-"`
 	TotalPVZs  int                       `json:"totalCount"` // Отдаем общее количество
 }
+
+// ReceptionFilter - фильтры и keyset курсор для истории приемок одного ПВЗ.
+// Приемки отдаются от новых к старым, курсор - (date_time, id) последней приемки предыдущей страницы.
+type ReceptionFilter struct {
+	Status        *ReceptionStatus // nil - любой статус
+	StartDate     *time.Time       // Нижняя граница date_time (включительно)
+	EndDate       *time.Time       // Верхняя граница date_time (включительно)
+	Limit         int
+	AfterDateTime *time.Time // Курсор: оба поля заданы или оба nil
+	AfterID       *uuid.UUID
+}
+
+// StaleReceptionFilter - отбор кандидатов в зависшие приемки (только in_progress).
+// Приемка подходит, если выполнено хотя бы одно из заданных условий.
+type StaleReceptionFilter struct {
+	OpenedBefore *time.Time // Начата раньше этого момента; nil - условие не используется
+	IdleBefore   *time.Time // Последний товар (или начало приемки, если товаров нет) раньше этого момента; nil - не используется
+	SkipFlagged  bool       // Не возвращать уже помеченные приемки
+	Limit        int
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ProductTypeInfo - запись справочника типов товаров (таблица product_types).
+type ProductTypeInfo struct {
+	Code             ProductType       `json:"code"`
+	Names            map[string]string `json:"names"`                      // Локаль -> название; "ru" обязателен
+	IsActive         bool              `json:"isActive"`                   // false - новые товары этого типа добавить нельзя
+	AttributesSchema *AttributeSchema  `json:"attributesSchema,omitempty"` // nil - дополнительные атрибуты не допускаются
+	CreatedAt        time.Time         `json:"createdAt"`
+}
+
+// DefaultLocale - локаль, название на которой обязательно для каждого типа товара.
+const DefaultLocale = "ru"
+
+// Типы значений атрибутов (как в JSON Schema)
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeInteger = "integer"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeSchema - схема дополнительных атрибутов товара. Это подмножество JSON Schema
+// для объекта с плоскими свойствами: {"type": "object", "properties": {...}, "required": [...]}.
+// Атрибуты, не описанные в properties, отклоняются.
+type AttributeSchema struct {
+	Type       string                       `json:"type,omitempty"` // Только "object" (можно не указывать)
+	Properties map[string]AttributeProperty `json:"properties"`
+	Required   []string                     `json:"required,omitempty"`
+}
+
+// AttributeProperty - описание одного атрибута.
+type AttributeProperty struct {
+	Type        string   `json:"type"`                  // string, number, integer, boolean
+	Description string   `json:"description,omitempty"` // Подсказка для клиентов
+	Enum        []string `json:"enum,omitempty"`        // Допустимые значения (только для string)
+	MaxLength   *int     `json:"maxLength,omitempty"`   // Максимальная длина (только для string)
+	Minimum     *float64 `json:"minimum,omitempty"`     // Границы значения (только для number/integer)
+	Maximum     *float64 `json:"maximum,omitempty"`
+}
+
+// Check проверяет корректность самой схемы (при создании/изменении типа товара).
+func (s *AttributeSchema) Check() error {
+	if s.Type != "" && s.Type != "object" {
+		return fmt.Errorf("%w: type схемы должен быть \"object\"", ErrProductTypeValidation)
+	}
+	if len(s.Properties) == 0 {
+		return fmt.Errorf("%w: в схеме атрибутов нет ни одного свойства", ErrProductTypeValidation)
+	}
+	for name, prop := range s.Properties {
+		if name == "" {
+			return fmt.Errorf("%w: пустое имя атрибута", ErrProductTypeValidation)
+		}
+		switch prop.Type {
+		case AttributeTypeString:
+			if prop.Minimum != nil || prop.Maximum != nil {
+				return fmt.Errorf("%w: атрибут %q: minimum/maximum допустимы только для чисел", ErrProductTypeValidation, name)
+			}
+		case AttributeTypeNumber, AttributeTypeInteger:
+			if len(prop.Enum) > 0 || prop.MaxLength != nil {
+				return fmt.Errorf("%w: атрибут %q: enum/maxLength допустимы только для строк", ErrProductTypeValidation, name)
+			}
+			if prop.Minimum != nil && prop.Maximum != nil && *prop.Minimum > *prop.Maximum {
+				return fmt.Errorf("%w: атрибут %q: minimum больше maximum", ErrProductTypeValidation, name)
+			}
+		case AttributeTypeBoolean:
+			if len(prop.Enum) > 0 || prop.MaxLength != nil || prop.Minimum != nil || prop.Maximum != nil {
+				return fmt.Errorf("%w: атрибут %q: для boolean ограничения не поддерживаются", ErrProductTypeValidation, name)
+			}
+		default:
+			return fmt.Errorf("%w: атрибут %q: неизвестный тип %q (ожидается string, number, integer или boolean)", ErrProductTypeValidation, name, prop.Type)
+		}
+	}
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; !ok {
+			return fmt.Errorf("%w: обязательный атрибут %q не описан в properties", ErrProductTypeValidation, name)
+		}
+	}
+	return nil
+}
+
+// ValidateAttributes проверяет атрибуты товара по схеме. Значения ожидаются в виде,
+// который дает encoding/json (числа - float64). schema == nil означает, что атрибуты не допускаются.
+func (s *AttributeSchema) ValidateAttributes(attrs map[string]any) error {
+	if s == nil {
+		if len(attrs) > 0 {
+			return fmt.Errorf("%w: тип товара не поддерживает дополнительные атрибуты", ErrInvalidProductAttributes)
+		}
+		return nil
+	}
+
+	for _, name := range s.Required {
+		if _, ok := attrs[name]; !ok {
+			return fmt.Errorf("%w: не указан обязательный атрибут %q", ErrInvalidProductAttributes, name)
+		}
+	}
+
+	// Сортируем имена, чтобы при нескольких ошибках сообщение было детерминированным
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			return fmt.Errorf("%w: неизвестный атрибут %q", ErrInvalidProductAttributes, name)
+		}
+		if err := prop.validateValue(attrs[name]); err != nil {
+			return fmt.Errorf("%w: атрибут %q: %s", ErrInvalidProductAttributes, name, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateValue проверяет одно значение атрибута; текст ошибки дописывается к ErrInvalidProductAttributes.
+func (p AttributeProperty) validateValue(value any) error {
+	switch p.Type {
+	case AttributeTypeString:
+		str, ok := value.(string)
+		if !ok {
+			return errors.New("ожидается строка")
+		}
+		if len(p.Enum) > 0 && !slices.Contains(p.Enum, str) {
+			return fmt.Errorf("допустимые значения: %v", p.Enum)
+		}
+		if p.MaxLength != nil && len([]rune(str)) > *p.MaxLength {
+			return fmt.Errorf("длина больше %d", *p.MaxLength)
+		}
+	case AttributeTypeNumber, AttributeTypeInteger:
+		num, ok := value.(float64)
+		if !ok {
+			return errors.New("ожидается число")
+		}
+		if p.Type == AttributeTypeInteger && num != math.Trunc(num) {
+			return errors.New("ожидается целое число")
+		}
+		if p.Minimum != nil && num < *p.Minimum {
+			return fmt.Errorf("значение меньше %v", *p.Minimum)
+		}
+		if p.Maximum != nil && num > *p.Maximum {
+			return fmt.Errorf("значение больше %v", *p.Maximum)
+		}
+	case AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return errors.New("ожидается true/false")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "fmt"
+
+// ReceptionEvent - действие, меняющее статус приемки.
+type ReceptionEvent string
+
+const (
+	EventClose  ReceptionEvent = "close"  // Приемка завершена
+	EventCancel ReceptionEvent = "cancel" // Приемка начата по ошибке
+	EventReopen ReceptionEvent = "reopen" // Модератор возвращает закрытую приемку в работу
+)
+
+// ReceptionStateMachine - допустимые переходы статуса приемки: статус -> событие -> новый статус.
+type ReceptionStateMachine map[ReceptionStatus]map[ReceptionEvent]ReceptionStatus
+
+// ReceptionLifecycle - жизненный цикл приемки. Сервисы проверяют по нему каждую смену статуса:
+//
+//	in_progress --close--> closed --reopen--> in_progress
+//	in_progress --cancel--> cancelled (конечный статус)
+var ReceptionLifecycle = ReceptionStateMachine{
+	StatusInProgress: {
+		EventClose:  StatusClosed,
+		EventCancel: StatusCancelled,
+	},
+	StatusClosed: {
+		EventReopen: StatusInProgress,
+	},
+}
+
+// Next возвращает статус после события или ErrInvalidReceptionTransition, если переход не разрешен.
+func (m ReceptionStateMachine) Next(from ReceptionStatus, event ReceptionEvent) (ReceptionStatus, error) {
+	to, ok := m[from][event]
+	if !ok {
+		return "", fmt.Errorf("%w: %s из статуса %s", ErrInvalidReceptionTransition, event, from)
+	}
+	return to, nil
+}
+
+// IsValid сообщает, известен ли статус (для проверки фильтров из запроса).
+func (s ReceptionStatus) IsValid() bool {
+	switch s {
+	case StatusInProgress, StatusClosed, StatusCancelled:
+		return true
+	}
+	return false
+}
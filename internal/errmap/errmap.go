@@ -0,0 +1,87 @@
+// Package errmap - единственное место, где доменные ошибки (domain.Error)
+// переводятся в коды транспорта: HTTP статус + стабильный code и gRPC codes.Code.
+package errmap
+
+import (
+	"net/http"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"google.golang.org/grpc/codes"
+)
+
+// Общие коды для ошибок, которые не являются доменными
+// (валидация запроса в хендлере, авторизация в middleware, внутренние ошибки).
+const (
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeInternal           = "INTERNAL"
+)
+
+// mapping - соответствие категории ошибки кодам транспорта.
+type mapping struct {
+	httpStatus int
+	grpcCode   codes.Code
+}
+
+var kindMappings = map[domain.ErrorKind]mapping{
+	domain.KindValidation:   {http.StatusBadRequest, codes.InvalidArgument},
+	domain.KindNotFound:     {http.StatusNotFound, codes.NotFound},
+	domain.KindConflict:     {http.StatusConflict, codes.AlreadyExists},
+	domain.KindInvalidState: {http.StatusBadRequest, codes.FailedPrecondition}, // 400 - как в исходном контракте API
+	domain.KindUnauthorized: {http.StatusUnauthorized, codes.Unauthenticated},
+	domain.KindForbidden:    {http.StatusForbidden, codes.PermissionDenied},
+	domain.KindRateLimited:  {http.StatusTooManyRequests, codes.ResourceExhausted},
+}
+
+// HTTP возвращает HTTP статус и стабильный код ошибки.
+// Для ошибок вне каталога domain возвращает 500 и CodeInternal.
+func HTTP(err error) (int, string) {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		return http.StatusInternalServerError, CodeInternal
+	}
+	m, ok := kindMappings[domainErr.Kind]
+	if !ok {
+		return http.StatusInternalServerError, domainErr.Code
+	}
+	return m.httpStatus, domainErr.Code
+}
+
+// GRPC возвращает gRPC код и стабильный код ошибки.
+// Для ошибок вне каталога domain возвращает codes.Internal и CodeInternal.
+func GRPC(err error) (codes.Code, string) {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		return codes.Internal, CodeInternal
+	}
+	m, ok := kindMappings[domainErr.Kind]
+	if !ok {
+		return codes.Internal, domainErr.Code
+	}
+	return m.grpcCode, domainErr.Code
+}
+
+// CodeForStatus возвращает общий код для ответа с ошибкой, сформированного
+// напрямую по HTTP статусу (без доменной ошибки).
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,77 @@
+package errmap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestKindMappings(t *testing.T) {
+	testCases := []struct {
+		kind       domain.ErrorKind
+		httpStatus int
+		grpcCode   codes.Code
+	}{
+		{domain.KindInternal, http.StatusInternalServerError, codes.Internal},
+		{domain.KindValidation, http.StatusBadRequest, codes.InvalidArgument},
+		{domain.KindNotFound, http.StatusNotFound, codes.NotFound},
+		{domain.KindConflict, http.StatusConflict, codes.AlreadyExists},
+		{domain.KindInvalidState, http.StatusBadRequest, codes.FailedPrecondition},
+		{domain.KindUnauthorized, http.StatusUnauthorized, codes.Unauthenticated},
+		{domain.KindForbidden, http.StatusForbidden, codes.PermissionDenied},
+		{domain.KindRateLimited, http.StatusTooManyRequests, codes.ResourceExhausted},
+	}
+
+	covered := make(map[domain.ErrorKind]bool, len(testCases))
+	for _, tc := range testCases {
+		covered[tc.kind] = true
+		t.Run(fmt.Sprintf("Kind %d", tc.kind), func(t *testing.T) {
+			err := domain.NewError(tc.kind, "SOME_CODE", "сообщение")
+
+			status, code := HTTP(err)
+			assert.Equal(t, tc.httpStatus, status)
+			assert.Equal(t, "SOME_CODE", code)
+
+			grpcCode, reason := GRPC(err)
+			assert.Equal(t, tc.grpcCode, grpcCode)
+			assert.Equal(t, "SOME_CODE", reason)
+
+			// Обернутая доменная ошибка отображается так же
+			status, code = HTTP(fmt.Errorf("контекст: %w", err))
+			assert.Equal(t, tc.httpStatus, status)
+			assert.Equal(t, "SOME_CODE", code)
+		})
+	}
+
+	// Новая категория в domain должна попасть и в таблицу теста
+	for kind := range kindMappings {
+		assert.True(t, covered[kind], "категория %d не покрыта тестом", kind)
+	}
+}
+
+func TestNonDomainError(t *testing.T) {
+	err := errors.New("db connection lost")
+
+	status, code := HTTP(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, CodeInternal, code)
+
+	grpcCode, reason := GRPC(err)
+	assert.Equal(t, codes.Internal, grpcCode)
+	assert.Equal(t, CodeInternal, reason)
+}
+
+func TestCodeForStatus(t *testing.T) {
+	assert.Equal(t, CodeBadRequest, CodeForStatus(http.StatusBadRequest))
+	assert.Equal(t, CodeUnauthorized, CodeForStatus(http.StatusUnauthorized))
+	assert.Equal(t, CodeForbidden, CodeForStatus(http.StatusForbidden))
+	assert.Equal(t, CodeNotFound, CodeForStatus(http.StatusNotFound))
+	assert.Equal(t, CodeConflict, CodeForStatus(http.StatusConflict))
+	assert.Equal(t, CodeServiceUnavailable, CodeForStatus(http.StatusServiceUnavailable))
+	assert.Equal(t, CodeInternal, CodeForStatus(http.StatusTeapot))
+}
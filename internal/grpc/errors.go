@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Artem0405/pvz-service/internal/errmap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain - значение ErrorInfo.Domain в деталях gRPC статуса.
+const errorDomain = "pvz-service"
+
+// toStatusError переводит ошибку сервисного слоя в gRPC статус через errmap.
+// Доменные ошибки отдаются клиенту с текстом и стабильным кодом в ErrorInfo.Reason,
+// остальные логируются и возвращаются как Internal без деталей.
+func toStatusError(ctx context.Context, method string, err error) error {
+	code, reason := errmap.GRPC(err)
+	if code == codes.Internal {
+		slog.ErrorContext(ctx, "gRPC: внутренняя ошибка сервиса", "method", method, "error", err)
+		return statusWithReason(codes.Internal, reason, "внутренняя ошибка сервера")
+	}
+	return statusWithReason(code, reason, err.Error())
+}
+
+// statusWithReason собирает gRPC статус с ErrorInfo{Reason: reason}, чтобы клиенты
+// могли ветвиться по тому же коду, что и HTTP клиенты по полю code.
+func statusWithReason(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		// Детали не сериализовались - отдаем статус без них
+		return st.Err()
+	}
+	return detailed.Err()
+}
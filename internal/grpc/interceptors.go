@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/errmap"
+	"github.com/Artem0405/pvz-service/internal/service"
+	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextKey - кастомный тип для ключа в контексте (аналогично api.contextKey).
+type contextKey string
+
+// principalContextKey - ключ, под которым интерсептор кладет пользователя из токена в контекст.
+const principalContextKey = contextKey("principal")
+
+// methodRoles задает требуемую роль для RPC методов.
+// Пустая строка означает, что достаточно любого валидного токена. Метод без записи здесь
+// (и не из publicMethods) запрещен: новый RPC нужно явно добавить в карту.
+// Требования совпадают с HTTP роутером: создавать ПВЗ может только модератор,
+// остальные операции доступны любой авторизованной роли.
+var methodRoles = map[string]string{
+	pb.PVZService_GetPVZList_FullMethodName:         "",
+	pb.PVZService_CreatePVZ_FullMethodName:          domain.RoleModerator,
+	pb.PVZService_InitiateReception_FullMethodName:  "",
+	pb.PVZService_AddProduct_FullMethodName:         "",
+	pb.PVZService_DeleteLastProduct_FullMethodName:  "",
+	pb.PVZService_CloseLastReception_FullMethodName: "",
+	pb.PVZService_ListPVZs_FullMethodName:           "",
+	pb.PVZService_ListPVZsStream_FullMethodName:     "",
+}
+
+// publicMethods - методы, доступные без токена (проверки health от балансировщика/оркестратора).
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// AuthUnaryInterceptor проверяет JWT из метаданных запроса и требования к роли
+// для unary RPC. Аналог api.AuthMiddleware + api.RoleMiddleware.
+func AuthUnaryInterceptor(authService service.AuthService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		authCtx, err := authorize(ctx, authService, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authCtx, req)
+	}
+}
+
+// AuthStreamInterceptor - то же самое для streaming RPC.
+func AuthStreamInterceptor(authService service.AuthService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authCtx, err := authorize(ss.Context(), authService, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+// authServerStream подменяет контекст стрима, чтобы обработчик видел пользователя.
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize извлекает bearer токен из метаданных, валидирует его и проверяет роль.
+// Возвращает контекст с пользователем из токена (domain.Principal).
+func authorize(ctx context.Context, authService service.AuthService, fullMethod string) (context.Context, error) {
+	if publicMethods[fullMethod] {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		slog.WarnContext(ctx, "gRPC auth: метаданные отсутствуют", "method", fullMethod)
+		return nil, status.Error(codes.Unauthenticated, "отсутствуют метаданные authorization")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		slog.WarnContext(ctx, "gRPC auth: authorization отсутствует", "method", fullMethod)
+		return nil, status.Error(codes.Unauthenticated, "отсутствуют метаданные authorization")
+	}
+
+	headerParts := strings.Split(values[0], " ")
+	if len(headerParts) != 2 || strings.ToLower(headerParts[0]) != "bearer" {
+		slog.WarnContext(ctx, "gRPC auth: неверный формат authorization", "method", fullMethod)
+		return nil, status.Error(codes.Unauthenticated, "неверный формат authorization (ожидается 'Bearer <token>')")
+	}
+
+	claims, err := authService.ValidateToken(headerParts[1])
+	if err != nil {
+		slog.WarnContext(ctx, "gRPC auth: токен не прошел валидацию", "method", fullMethod, "error", err.Error())
+		// Код уточняет причину (TOKEN_EXPIRED, TOKEN_MALFORMED, ...), как и в HTTP AuthMiddleware
+		_, reason := errmap.GRPC(err)
+		if reason == errmap.CodeInternal {
+			reason = errmap.CodeUnauthorized
+		}
+		return nil, statusWithReason(codes.Unauthenticated, reason, "невалидный или просроченный токен: "+err.Error())
+	}
+
+	requiredRole, known := methodRoles[fullMethod]
+	if !known {
+		// Забытый в methodRoles метод не должен становиться доступным любой роли
+		slog.ErrorContext(ctx, "gRPC auth: для метода нет правила роли, доступ запрещен", "method", fullMethod)
+		return nil, status.Errorf(codes.PermissionDenied, "доступ к методу %s не настроен", fullMethod)
+	}
+	principal := claims.Principal()
+	if requiredRole != "" && principal.Role != requiredRole {
+		return nil, status.Errorf(codes.PermissionDenied, "доступ запрещен. Требуется роль: '%s', у вас роль: '%s'", requiredRole, principal.Role)
+	}
+
+	return context.WithValue(ctx, principalContextKey, principal), nil
+}
+
+// GetRoleFromContext возвращает роль пользователя, сохраненную интерсептором.
+func GetRoleFromContext(ctx context.Context) (string, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	return principal.Role, ok
+}
+
+// PrincipalFromContext возвращает пользователя из токена, сохраненного интерсептором.
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(domain.Principal)
+	return principal, ok
+}
+
+// actorFromContext собирает domain.Actor для аудита (аналогично api.actorFromContext).
+func actorFromContext(ctx context.Context) domain.Actor {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.Actor()
+}
@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	mocks "github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/Artem0405/pvz-service/internal/service"
+	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newTestAuth создает сервис аутентификации с HS256 и возвращает его вместе с denylist
+func newTestAuth(t *testing.T) (service.AuthService, *service.TokenDenylist) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.JWT.Secret = "grpc-interceptor-test-secret"
+	cfg.JWT.DummyAuth = true
+	keys, err := service.NewJWTKeySet(cfg.JWT)
+	require.NoError(t, err)
+	policy, err := service.NewPasswordPolicy(cfg.PasswordPolicy)
+	require.NoError(t, err)
+
+	tokenRepo := mocks.NewTokenRepository(t)
+	denylist := service.NewTokenDenylist(tokenRepo)
+	auth := service.NewAuthService(cfg.JWT, cfg.Registration, cfg.PasswordReset, keys, mocks.NewUserRepository(t), tokenRepo, denylist,
+		service.NewLoginLimiter(config.LoginProtectionConfig{}, nil), policy, nopNotifier{}, mocks.NewTransactor(t))
+	return auth, denylist
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) SendPasswordReset(context.Context, string, string, time.Time) error { return nil }
+
+// withToken - входящий контекст с метаданными authorization
+func withToken(t *testing.T, auth service.AuthService, role string) context.Context {
+	t.Helper()
+	token, err := auth.GenerateToken(role)
+	require.NoError(t, err)
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// callUnary вызывает интерсептор для метода и сообщает, дошел ли вызов до обработчика
+func callUnary(ctx context.Context, auth service.AuthService, method string) (bool, error) {
+	reached := false
+	_, err := AuthUnaryInterceptor(auth)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		reached = true
+		_, ok := PrincipalFromContext(ctx)
+		if !ok && method != healthpb.Health_Check_FullMethodName {
+			return nil, status.Error(codes.Internal, "пользователь не сохранен в контексте")
+		}
+		return nil, nil
+	})
+	return reached, err
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	auth, denylist := newTestAuth(t)
+
+	t.Run("Public Method Without Token", func(t *testing.T) {
+		reached, err := callUnary(context.Background(), auth, healthpb.Health_Check_FullMethodName)
+		require.NoError(t, err)
+		assert.True(t, reached)
+	})
+
+	t.Run("Missing Token", func(t *testing.T) {
+		reached, err := callUnary(context.Background(), auth, pb.PVZService_ListPVZs_FullMethodName)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.False(t, reached)
+	})
+
+	t.Run("Allowed Role", func(t *testing.T) {
+		reached, err := callUnary(withToken(t, auth, domain.RoleModerator), auth, pb.PVZService_CreatePVZ_FullMethodName)
+		require.NoError(t, err)
+		assert.True(t, reached)
+	})
+
+	t.Run("Role Mismatch", func(t *testing.T) {
+		reached, err := callUnary(withToken(t, auth, domain.RoleEmployee), auth, pb.PVZService_CreatePVZ_FullMethodName)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.False(t, reached)
+	})
+
+	t.Run("Unknown Method Denied", func(t *testing.T) {
+		reached, err := callUnary(withToken(t, auth, domain.RoleModerator), auth, "/pvz.v1.PVZService/DropEverything")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.False(t, reached)
+	})
+
+	t.Run("Revoked Token", func(t *testing.T) {
+		ctx := withToken(t, auth, domain.RoleEmployee)
+		md, _ := metadata.FromIncomingContext(ctx)
+		claims, err := auth.ValidateToken(md.Get("authorization")[0][len("Bearer "):])
+		require.NoError(t, err)
+		principal := claims.Principal()
+		denylist.Add(domain.RevokedToken{JTI: principal.TokenID, ExpiresAt: principal.TokenExpiresAt})
+
+		reached, err := callUnary(ctx, auth, pb.PVZService_ListPVZs_FullMethodName)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.False(t, reached)
+	})
+}
+
+func TestAuthStreamInterceptor_UnknownMethodDenied(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	ss := &authServerStream{ctx: withToken(t, auth, domain.RoleModerator)}
+	err := AuthStreamInterceptor(auth)(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pvz.v1.PVZService/UnknownStream"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			t.Fatal("обработчик не должен вызываться")
+			return nil
+		})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// Каждый RPC сервиса должен иметь правило в methodRoles, иначе он недоступен
+func TestMethodRoles_CoverService(t *testing.T) {
+	for _, m := range pb.PVZService_ServiceDesc.Methods {
+		_, ok := methodRoles["/"+pb.PVZService_ServiceDesc.ServiceName+"/"+m.MethodName]
+		assert.True(t, ok, "нет правила для %s", m.MethodName)
+	}
+	for _, s := range pb.PVZService_ServiceDesc.Streams {
+		_, ok := methodRoles["/"+pb.PVZService_ServiceDesc.ServiceName+"/"+s.StreamName]
+		assert.True(t, ok, "нет правила для %s", s.StreamName)
+	}
+}
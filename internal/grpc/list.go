@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/service"
+	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pvzPage - одна страница списка ПВЗ вместе с курсором на следующую.
+type pvzPage struct {
+	items         []*pb.PVZWithReceptions
+	nextAfterDate *time.Time
+	nextAfterID   *uuid.UUID
+}
+
+// ListPVZs возвращает одну страницу ПВЗ с keyset курсором (registration_date + id).
+func (s *PVZServer) ListPVZs(ctx context.Context, req *pb.ListPVZsRequest) (*pb.ListPVZsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = s.limits.PVZPageDefault
+	}
+	if limit < 1 || limit > s.limits.PVZPageMax {
+		return nil, status.Errorf(codes.InvalidArgument, "некорректное значение limit (1-%d)", s.limits.PVZPageMax)
+	}
+
+	// Курсор: оба поля или ни одного
+	var afterDate *time.Time
+	var afterID *uuid.UUID
+	if req.GetAfterRegistrationDate() != nil && req.GetAfterId() != "" {
+		t := req.GetAfterRegistrationDate().AsTime()
+		id, err := uuid.Parse(req.GetAfterId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "некорректный формат after_id: %v", err)
+		}
+		afterDate, afterID = &t, &id
+	} else if req.GetAfterRegistrationDate() != nil || req.GetAfterId() != "" {
+		return nil, status.Error(codes.InvalidArgument, "для пагинации необходимо передать оба параметра курсора (after_registration_date и after_id) или ни одного")
+	}
+
+	page, err := s.listPage(ctx, limit, afterDate, afterID, req.GetIncludeReceptions(), req.GetIncludeInactive(), optionalTime(req.GetStartDate()), optionalTime(req.GetEndDate()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListPVZsResponse{Items: page.items}
+	if page.nextAfterID != nil {
+		resp.NextAfterRegistrationDate = timestamppb.New(*page.nextAfterDate)
+		resp.NextAfterId = page.nextAfterID.String()
+	}
+	return resp, nil
+}
+
+// ListPVZsStream отдает все ПВЗ порциями по chunk_size, читая таблицу постранично
+// тем же keyset курсором, поэтому память сервера не зависит от размера таблицы.
+func (s *PVZServer) ListPVZsStream(req *pb.ListPVZsStreamRequest, stream grpc.ServerStreamingServer[pb.ListPVZsStreamResponse]) error {
+	ctx := stream.Context()
+
+	chunkSize := int(req.GetChunkSize())
+	if chunkSize == 0 {
+		chunkSize = s.limits.StreamChunkDefault
+	}
+	if chunkSize < 1 || chunkSize > s.limits.StreamChunkMax {
+		return status.Errorf(codes.InvalidArgument, "некорректное значение chunk_size (1-%d)", s.limits.StreamChunkMax)
+	}
+	startDate, endDate := optionalTime(req.GetStartDate()), optionalTime(req.GetEndDate())
+
+	var afterDate *time.Time
+	var afterID *uuid.UUID
+	sent := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		page, err := s.listPage(ctx, chunkSize, afterDate, afterID, req.GetIncludeReceptions(), req.GetIncludeInactive(), startDate, endDate)
+		if err != nil {
+			return err
+		}
+		if len(page.items) > 0 {
+			if err := stream.Send(&pb.ListPVZsStreamResponse{Items: page.items}); err != nil {
+				slog.WarnContext(ctx, "gRPC ListPVZsStream: не удалось отправить порцию", "error", err)
+				return err
+			}
+			sent += len(page.items)
+		}
+		if page.nextAfterID == nil {
+			break
+		}
+		afterDate, afterID = page.nextAfterDate, page.nextAfterID
+	}
+
+	slog.InfoContext(ctx, "gRPC ListPVZsStream завершен", "count", sent, "chunk_size", chunkSize)
+	return nil
+}
+
+// listPage читает одну страницу ПВЗ через PVZService: с includeReceptions - вместе с приемками
+// и товарами (фильтр по датам, как в HTTP), иначе только ПВЗ. Курсор и отбор деактивированных ПВЗ
+// (только при includeInactive) определяет сервис.
+func (s *PVZServer) listPage(ctx context.Context, limit int, afterDate *time.Time, afterID *uuid.UUID, includeReceptions, includeInactive bool, startDate, endDate *time.Time) (pvzPage, error) {
+	var page pvzPage
+
+	var result service.GetPVZListResult
+	var err error
+	if includeReceptions {
+		result, err = s.pvzService.GetPVZList(ctx, startDate, endDate, limit, afterDate, afterID, includeInactive, nil)
+	} else {
+		result, err = s.pvzService.ListPVZs(ctx, limit, afterDate, afterID, includeInactive, nil)
+	}
+	if err != nil {
+		return page, toStatusError(ctx, "ListPVZs", err)
+	}
+
+	page.items = make([]*pb.PVZWithReceptions, 0, len(result.PVZs))
+	for _, p := range result.PVZs {
+		item := &pb.PVZWithReceptions{Pvz: toProtoPVZ(p)}
+		for _, rcp := range result.Receptions[p.ID] {
+			products := result.Products[rcp.ID]
+			protoProducts := make([]*pb.Product, 0, len(products))
+			for _, prod := range products {
+				protoProducts = append(protoProducts, toProtoProduct(prod))
+			}
+			item.Receptions = append(item.Receptions, &pb.ReceptionWithProducts{
+				Reception: toProtoReception(rcp),
+				Products:  protoProducts,
+			})
+		}
+		page.items = append(page.items, item)
+	}
+	page.nextAfterDate, page.nextAfterID = result.NextAfterRegistrationDate, result.NextAfterID
+	return page, nil
+}
+
+// optionalTime конвертирует необязательный Timestamp в *time.Time.
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
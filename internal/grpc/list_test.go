@@ -0,0 +1,241 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/service"
+	svcmocks "github.com/Artem0405/pvz-service/internal/service/mocks"
+	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newListTestServer - PVZServer с моком PVZService и лимитами по умолчанию (страница 10/30, порция 100/500)
+func newListTestServer(t *testing.T) (*PVZServer, *svcmocks.PVZService) {
+	t.Helper()
+	pvzService := svcmocks.NewPVZService(t)
+	return NewPVZServer(nil, pvzService, svcmocks.NewReceptionService(t), config.Default().Limits), pvzService
+}
+
+// testPVZs возвращает n ПВЗ с возрастающей датой регистрации
+func testPVZs(n int) []domain.PVZ {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	pvzs := make([]domain.PVZ, n)
+	for i := range pvzs {
+		pvzs[i] = domain.PVZ{ID: uuid.New(), City: "Москва", RegistrationDate: base.Add(time.Duration(i) * time.Hour), IsActive: true}
+	}
+	return pvzs
+}
+
+// pageResult - ответ сервиса для страницы ПВЗ; курсор указывает на последний ПВЗ, если full
+func pageResult(pvzs []domain.PVZ, full bool) service.GetPVZListResult {
+	result := service.GetPVZListResult{PVZs: pvzs}
+	if full {
+		last := pvzs[len(pvzs)-1]
+		result.NextAfterRegistrationDate, result.NextAfterID = &last.RegistrationDate, &last.ID
+	}
+	return result
+}
+
+// fakeListStream собирает отправленные порции ListPVZsStream
+type fakeListStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.ListPVZsStreamResponse
+}
+
+func (s *fakeListStream) Context() context.Context { return s.ctx }
+
+func (s *fakeListStream) Send(resp *pb.ListPVZsStreamResponse) error {
+	s.chunks = append(s.chunks, resp)
+	return nil
+}
+
+func TestListPVZs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Default Limit And Next Cursor", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzs := testPVZs(10)
+		pvzService.On("ListPVZs", mock.Anything, 10, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs, true), nil).Once()
+
+		resp, err := server.ListPVZs(ctx, &pb.ListPVZsRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 10)
+		assert.Equal(t, pvzs[0].ID.String(), resp.Items[0].Pvz.Id)
+		assert.Empty(t, resp.Items[0].Receptions)
+		assert.Equal(t, pvzs[9].ID.String(), resp.NextAfterId)
+		assert.True(t, pvzs[9].RegistrationDate.Equal(resp.NextAfterRegistrationDate.AsTime()))
+	})
+
+	t.Run("Cursor And Max Limit Are Passed To Service", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		afterDate := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+		afterID := uuid.New()
+		pvzService.On("ListPVZs", mock.Anything, 30, &afterDate, &afterID, true, (*uuid.UUID)(nil)).
+			Return(pageResult(testPVZs(2), false), nil).Once()
+
+		resp, err := server.ListPVZs(ctx, &pb.ListPVZsRequest{
+			Limit:                 30,
+			AfterRegistrationDate: timestamppb.New(afterDate),
+			AfterId:               afterID.String(),
+			IncludeInactive:       true,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+		assert.Empty(t, resp.NextAfterId, "на последней странице курсора нет")
+		assert.Nil(t, resp.NextAfterRegistrationDate)
+	})
+
+	t.Run("Include Receptions Nests Receptions And Products", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzs := testPVZs(2)
+		startDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		endDate := startDate.Add(24 * time.Hour)
+		reception := domain.Reception{ID: uuid.New(), PVZID: pvzs[0].ID, DateTime: startDate.Add(time.Hour), Status: domain.StatusClosed}
+		products := []domain.Product{
+			{ID: uuid.New(), ReceptionID: reception.ID, Type: domain.TypeElectronics, DateTimeAdded: startDate.Add(2 * time.Hour)},
+			{ID: uuid.New(), ReceptionID: reception.ID, Type: domain.TypeClothes, DateTimeAdded: startDate.Add(3 * time.Hour)},
+		}
+		result := pageResult(pvzs, false)
+		result.Receptions = map[uuid.UUID][]domain.Reception{pvzs[0].ID: {reception}}
+		result.Products = map[uuid.UUID][]domain.Product{reception.ID: products}
+		pvzService.On("GetPVZList", mock.Anything, &startDate, &endDate, 10, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).
+			Return(result, nil).Once()
+
+		resp, err := server.ListPVZs(ctx, &pb.ListPVZsRequest{
+			IncludeReceptions: true,
+			StartDate:         timestamppb.New(startDate),
+			EndDate:           timestamppb.New(endDate),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2)
+		require.Len(t, resp.Items[0].Receptions, 1)
+		got := resp.Items[0].Receptions[0]
+		assert.Equal(t, reception.ID.String(), got.Reception.Id)
+		assert.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_CLOSED, got.Reception.Status)
+		require.Len(t, got.Products, 2)
+		assert.Equal(t, products[0].ID.String(), got.Products[0].Id)
+		assert.Equal(t, string(domain.TypeClothes), got.Products[1].Type)
+		assert.Empty(t, resp.Items[1].Receptions, "ПВЗ без приемок за период")
+	})
+
+	t.Run("Invalid Requests", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			req  *pb.ListPVZsRequest
+		}{
+			{"Limit Above Max", &pb.ListPVZsRequest{Limit: 31}},
+			{"Negative Limit", &pb.ListPVZsRequest{Limit: -1}},
+			{"Only After Date", &pb.ListPVZsRequest{AfterRegistrationDate: timestamppb.Now()}},
+			{"Only After ID", &pb.ListPVZsRequest{AfterId: uuid.NewString()}},
+			{"Bad After ID", &pb.ListPVZsRequest{AfterRegistrationDate: timestamppb.Now(), AfterId: "not-a-uuid"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				server, _ := newListTestServer(t) // Сервис не вызывается: мок без ожиданий
+
+				_, err := server.ListPVZs(ctx, tc.req)
+
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+
+	t.Run("Service Error", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzService.On("ListPVZs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(service.GetPVZListResult{}, assert.AnError).Once()
+
+		_, err := server.ListPVZs(ctx, &pb.ListPVZsRequest{})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestListPVZsStream(t *testing.T) {
+	t.Run("Partial Last Chunk", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzs := testPVZs(5)
+		pvzService.On("ListPVZs", mock.Anything, 2, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs[:2], true), nil).Once()
+		pvzService.On("ListPVZs", mock.Anything, 2, &pvzs[1].RegistrationDate, &pvzs[1].ID, false, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs[2:4], true), nil).Once()
+		pvzService.On("ListPVZs", mock.Anything, 2, &pvzs[3].RegistrationDate, &pvzs[3].ID, false, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs[4:], false), nil).Once()
+		stream := &fakeListStream{ctx: context.Background()}
+
+		err := server.ListPVZsStream(&pb.ListPVZsStreamRequest{ChunkSize: 2}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 3)
+		assert.Len(t, stream.chunks[0].Items, 2)
+		assert.Len(t, stream.chunks[1].Items, 2)
+		require.Len(t, stream.chunks[2].Items, 1)
+		assert.Equal(t, pvzs[4].ID.String(), stream.chunks[2].Items[0].Pvz.Id)
+	})
+
+	t.Run("Full Last Chunk Then Empty Page", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzs := testPVZs(4)
+		pvzService.On("ListPVZs", mock.Anything, 2, (*time.Time)(nil), (*uuid.UUID)(nil), true, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs[:2], true), nil).Once()
+		pvzService.On("ListPVZs", mock.Anything, 2, &pvzs[1].RegistrationDate, &pvzs[1].ID, true, (*uuid.UUID)(nil)).
+			Return(pageResult(pvzs[2:], true), nil).Once()
+		pvzService.On("ListPVZs", mock.Anything, 2, &pvzs[3].RegistrationDate, &pvzs[3].ID, true, (*uuid.UUID)(nil)).
+			Return(pageResult(nil, false), nil).Once()
+		stream := &fakeListStream{ctx: context.Background()}
+
+		err := server.ListPVZsStream(&pb.ListPVZsStreamRequest{ChunkSize: 2, IncludeInactive: true}, stream)
+
+		require.NoError(t, err)
+		assert.Len(t, stream.chunks, 2, "пустая страница не отправляется")
+	})
+
+	t.Run("Default Chunk Size With Receptions", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzService.On("GetPVZList", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 100, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).
+			Return(pageResult(testPVZs(3), false), nil).Once()
+		stream := &fakeListStream{ctx: context.Background()}
+
+		err := server.ListPVZsStream(&pb.ListPVZsStreamRequest{IncludeReceptions: true}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 1)
+		assert.Len(t, stream.chunks[0].Items, 3)
+	})
+
+	t.Run("Max Chunk Size Is Accepted", func(t *testing.T) {
+		server, pvzService := newListTestServer(t)
+		pvzService.On("ListPVZs", mock.Anything, 500, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).
+			Return(pageResult(nil, false), nil).Once()
+		stream := &fakeListStream{ctx: context.Background()}
+
+		require.NoError(t, server.ListPVZsStream(&pb.ListPVZsStreamRequest{ChunkSize: 500}, stream))
+		assert.Empty(t, stream.chunks)
+	})
+
+	t.Run("Chunk Size Above Max", func(t *testing.T) {
+		server, _ := newListTestServer(t)
+		stream := &fakeListStream{ctx: context.Background()}
+
+		err := server.ListPVZsStream(&pb.ListPVZsStreamRequest{ChunkSize: 501}, stream)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Empty(t, stream.chunks)
+	})
+}
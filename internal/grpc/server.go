@@ -4,10 +4,15 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
 	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/Artem0405/pvz-service/internal/service"
 	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1" // Важно: pb - это ваш сгенерированный пакет
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,22 +20,29 @@ import (
 type PVZServer struct {
 	pb.UnimplementedPVZServiceServer // Встраивание для совместимости
 
-	pvzRepo repository.PVZRepository // Зависимость
+	pvzRepo          repository.PVZRepository // Зависимость
+	pvzService       service.PVZService       // Бизнес-логика ПВЗ (создание)
+	receptionService service.ReceptionService // Бизнес-логика приемок и товаров
+	limits           config.LimitsConfig      // Размеры страниц ListPVZs и порций ListPVZsStream
 }
 
 // NewPVZServer конструктор
-func NewPVZServer(pvzRepo repository.PVZRepository) *PVZServer {
+func NewPVZServer(pvzRepo repository.PVZRepository, pvzService service.PVZService, receptionService service.ReceptionService, limits config.LimitsConfig) *PVZServer {
 	return &PVZServer{
-		pvzRepo: pvzRepo,
+		pvzRepo:          pvzRepo,
+		pvzService:       pvzService,
+		receptionService: receptionService,
+		limits:           limits,
 	}
 }
 
-// GetPVZList реализация RPC метода
+// GetPVZList реализация RPC метода.
+// Загружает все ПВЗ одним ответом; для больших таблиц используйте ListPVZs или ListPVZsStream.
 func (s *PVZServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
 	slog.InfoContext(ctx, "gRPC GetPVZList request received")
 
 	// 1. Вызов репозитория
-	domainPVZs, err := s.pvzRepo.GetAllPVZs(ctx)
+	domainPVZs, err := s.pvzRepo.GetAllPVZs(ctx, req.GetIncludeInactive())
 	if err != nil {
 		slog.ErrorContext(ctx, "gRPC: Failed to get all PVZs from repository", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to retrieve PVZ list: %v", err)
@@ -39,11 +51,7 @@ func (s *PVZServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (
 	// 2. Конвертация Domain -> Protobuf
 	protoPVZs := make([]*pb.PVZ, len(domainPVZs)) // Предварительно выделяем память
 	for i, domainPVZ := range domainPVZs {
-		protoPVZs[i] = &pb.PVZ{ // Создаем Protobuf сообщение
-			Id:               domainPVZ.ID.String(),                       // uuid.UUID -> string
-			RegistrationDate: timestamppb.New(domainPVZ.RegistrationDate), // time.Time -> timestamppb.Timestamp
-			City:             domainPVZ.City,                              // string -> string
-		}
+		protoPVZs[i] = toProtoPVZ(domainPVZ)
 	}
 
 	slog.InfoContext(ctx, "gRPC: Successfully retrieved and converted PVZ list", "count", len(protoPVZs))
@@ -54,3 +62,152 @@ func (s *PVZServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (
 	}
 	return response, nil
 }
+
+// CreatePVZ создает ПВЗ через service.PVZService (та же валидация города, что и в HTTP).
+func (s *PVZServer) CreatePVZ(ctx context.Context, req *pb.CreatePVZRequest) (*pb.CreatePVZResponse, error) {
+	if req.GetCity() == "" {
+		return nil, status.Error(codes.InvalidArgument, "поле 'city' является обязательным")
+	}
+
+	createdPVZ, err := s.pvzService.CreatePVZ(ctx, domain.PVZ{City: req.GetCity()})
+	if err != nil {
+		return nil, toStatusError(ctx, "CreatePVZ", err)
+	}
+
+	return &pb.CreatePVZResponse{Pvz: toProtoPVZ(createdPVZ)}, nil
+}
+
+// InitiateReception начинает новую приемку для ПВЗ.
+func (s *PVZServer) InitiateReception(ctx context.Context, req *pb.InitiateReceptionRequest) (*pb.InitiateReceptionResponse, error) {
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	reception, err := s.receptionService.InitiateReception(ctx, pvzID, actorFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(ctx, "InitiateReception", err)
+	}
+
+	return &pb.InitiateReceptionResponse{Reception: toProtoReception(reception)}, nil
+}
+
+// AddProduct добавляет товар в последнюю открытую приемку ПВЗ.
+func (s *PVZServer) AddProduct(ctx context.Context, req *pb.AddProductRequest) (*pb.AddProductResponse, error) {
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetType() == "" {
+		return nil, status.Error(codes.InvalidArgument, "поле 'type' является обязательным")
+	}
+
+	product, err := s.receptionService.AddProduct(ctx, pvzID, actorFromContext(ctx), domain.ProductInput{
+		Type:       domain.ProductType(req.GetType()),
+		Barcode:    req.GetBarcode(),
+		OrderID:    req.GetOrderId(),
+		Attributes: req.GetAttributes().AsMap(),
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, "AddProduct", err)
+	}
+
+	return &pb.AddProductResponse{Product: toProtoProduct(product)}, nil
+}
+
+// DeleteLastProduct удаляет последний добавленный товар из открытой приемки ПВЗ.
+func (s *PVZServer) DeleteLastProduct(ctx context.Context, req *pb.DeleteLastProductRequest) (*pb.DeleteLastProductResponse, error) {
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.receptionService.DeleteLastProduct(ctx, pvzID, actorFromContext(ctx)); err != nil {
+		return nil, toStatusError(ctx, "DeleteLastProduct", err)
+	}
+
+	return &pb.DeleteLastProductResponse{}, nil
+}
+
+// CloseLastReception закрывает последнюю открытую приемку ПВЗ.
+func (s *PVZServer) CloseLastReception(ctx context.Context, req *pb.CloseLastReceptionRequest) (*pb.CloseLastReceptionResponse, error) {
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	reception, err := s.receptionService.CloseLastReception(ctx, pvzID, actorFromContext(ctx))
+	if err != nil {
+		return nil, toStatusError(ctx, "CloseLastReception", err)
+	}
+
+	return &pb.CloseLastReceptionResponse{Reception: toProtoReception(reception)}, nil
+}
+
+// parsePVZID разбирает UUID ПВЗ из запроса, возвращая InvalidArgument при ошибке.
+func parsePVZID(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "поле 'pvz_id' является обязательным")
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "некорректный формат pvz_id: %v", err)
+	}
+	return id, nil
+}
+
+// --- Конвертация Domain -> Protobuf ---
+
+func toProtoPVZ(p domain.PVZ) *pb.PVZ {
+	out := &pb.PVZ{
+		Id:               p.ID.String(),                       // uuid.UUID -> string
+		RegistrationDate: timestamppb.New(p.RegistrationDate), // time.Time -> timestamppb.Timestamp
+		City:             p.City,                              // string -> string
+		IsActive:         p.IsActive,
+		StrictLifo:       p.StrictLIFO,
+	}
+	if p.DeactivatedAt != nil {
+		out.DeactivatedAt = timestamppb.New(*p.DeactivatedAt)
+	}
+	return out
+}
+
+func toProtoReception(r domain.Reception) *pb.Reception {
+	return &pb.Reception{
+		Id:       r.ID.String(),
+		PvzId:    r.PVZID.String(),
+		DateTime: timestamppb.New(r.DateTime),
+		Status:   toProtoReceptionStatus(r.Status),
+	}
+}
+
+func toProtoReceptionStatus(s domain.ReceptionStatus) pb.ReceptionStatus {
+	switch s {
+	case domain.StatusInProgress:
+		return pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+	case domain.StatusClosed:
+		return pb.ReceptionStatus_RECEPTION_STATUS_CLOSED
+	case domain.StatusCancelled:
+		return pb.ReceptionStatus_RECEPTION_STATUS_CANCELLED
+	default:
+		return pb.ReceptionStatus_RECEPTION_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoProduct(p domain.Product) *pb.Product {
+	out := &pb.Product{
+		Id:            p.ID.String(),
+		ReceptionId:   p.ReceptionID.String(),
+		DateTimeAdded: timestamppb.New(p.DateTimeAdded),
+		Type:          string(p.Type),
+		Barcode:       p.Barcode,
+		OrderId:       p.OrderID,
+	}
+	if len(p.Attributes) > 0 {
+		// Атрибуты прошли проверку схемой и состоят из JSON-значений, ошибка здесь не ожидается
+		if attrs, err := structpb.NewStruct(p.Attributes); err == nil {
+			out.Attributes = attrs
+		}
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	svcmocks "github.com/Artem0405/pvz-service/internal/service/mocks"
+	pb "github.com/Artem0405/pvz-service/pkg/pvz/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestServer - PVZServer с моками PVZService и ReceptionService
+func newTestServer(t *testing.T) (*PVZServer, *svcmocks.PVZService, *svcmocks.ReceptionService) {
+	t.Helper()
+	pvzService := svcmocks.NewPVZService(t)
+	receptionService := svcmocks.NewReceptionService(t)
+	return NewPVZServer(nil, pvzService, receptionService, config.Default().Limits), pvzService, receptionService
+}
+
+// withPrincipal - контекст после AuthUnaryInterceptor: пользователь из токена
+func withPrincipal(principal domain.Principal) context.Context {
+	return context.WithValue(context.Background(), principalContextKey, principal)
+}
+
+// assertStatus проверяет gRPC код и ErrorInfo.Reason ошибки
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "ожидался gRPC статус, получено %v", err)
+	assert.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reason, info.Reason)
+	assert.Equal(t, errorDomain, info.Domain)
+}
+
+func TestPVZServer_CreatePVZ(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		server, pvzService, _ := newTestServer(t)
+		created := domain.PVZ{ID: uuid.New(), City: "Казань", RegistrationDate: time.Now(), IsActive: true}
+		pvzService.On("CreatePVZ", mock.Anything, domain.PVZ{City: "Казань"}).Return(created, nil).Once()
+
+		resp, err := server.CreatePVZ(ctx, &pb.CreatePVZRequest{City: "Казань"})
+
+		require.NoError(t, err)
+		assert.Equal(t, created.ID.String(), resp.Pvz.Id)
+		assert.Equal(t, "Казань", resp.Pvz.City)
+		assert.True(t, resp.Pvz.IsActive)
+	})
+
+	t.Run("Empty City", func(t *testing.T) {
+		server, _, _ := newTestServer(t)
+
+		_, err := server.CreatePVZ(ctx, &pb.CreatePVZRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("City Not Allowed", func(t *testing.T) {
+		server, pvzService, _ := newTestServer(t)
+		pvzService.On("CreatePVZ", mock.Anything, mock.Anything).Return(domain.PVZ{}, domain.ErrPVZInvalidCity).Once()
+
+		_, err := server.CreatePVZ(ctx, &pb.CreatePVZRequest{City: "Тверь"})
+
+		assertStatus(t, err, codes.InvalidArgument, domain.ErrPVZInvalidCity.Code)
+	})
+}
+
+func TestPVZServer_Receptions(t *testing.T) {
+	pvzID := uuid.New()
+	actor := domain.Actor{UserID: uuid.New(), Role: domain.RoleEmployee}
+	ctx := withPrincipal(domain.Principal{UserID: actor.UserID, Email: "employee@example.com", Role: actor.Role})
+
+	t.Run("InitiateReception Success", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		reception := domain.Reception{ID: uuid.New(), PVZID: pvzID, DateTime: time.Now(), Status: domain.StatusInProgress}
+		receptionService.On("InitiateReception", mock.Anything, pvzID, actor).Return(reception, nil).Once()
+
+		resp, err := server.InitiateReception(ctx, &pb.InitiateReceptionRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, reception.ID.String(), resp.Reception.Id)
+		assert.Equal(t, pvzID.String(), resp.Reception.PvzId)
+		assert.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS, resp.Reception.Status)
+	})
+
+	t.Run("InitiateReception Open Reception Exists", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("InitiateReception", mock.Anything, pvzID, actor).Return(domain.Reception{}, domain.ErrReceptionAlreadyOpen).Once()
+
+		_, err := server.InitiateReception(ctx, &pb.InitiateReceptionRequest{PvzId: pvzID.String()})
+
+		assertStatus(t, err, codes.FailedPrecondition, domain.ErrReceptionAlreadyOpen.Code)
+	})
+
+	t.Run("AddProduct Success", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		attrs, err := structpb.NewStruct(map[string]any{"size": "M"})
+		require.NoError(t, err)
+		input := domain.ProductInput{Type: domain.TypeClothes, Barcode: "4600000000001", OrderID: "ORD-1", Attributes: map[string]any{"size": "M"}}
+		product := domain.Product{ID: uuid.New(), ReceptionID: uuid.New(), Type: domain.TypeClothes, Barcode: "4600000000001", OrderID: "ORD-1",
+			Attributes: map[string]any{"size": "M"}, DateTimeAdded: time.Now()}
+		receptionService.On("AddProduct", mock.Anything, pvzID, actor, input).Return(product, nil).Once()
+
+		resp, err := server.AddProduct(ctx, &pb.AddProductRequest{
+			PvzId:      pvzID.String(),
+			Type:       string(domain.TypeClothes),
+			Barcode:    "4600000000001",
+			OrderId:    "ORD-1",
+			Attributes: attrs,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, product.ID.String(), resp.Product.Id)
+		assert.Equal(t, product.ReceptionID.String(), resp.Product.ReceptionId)
+		assert.Equal(t, "ORD-1", resp.Product.OrderId)
+		assert.Equal(t, "M", resp.Product.Attributes.AsMap()["size"])
+	})
+
+	t.Run("AddProduct No Open Reception", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("AddProduct", mock.Anything, pvzID, actor, mock.Anything).Return(domain.Product{}, domain.ErrNoOpenReception).Once()
+
+		_, err := server.AddProduct(ctx, &pb.AddProductRequest{PvzId: pvzID.String(), Type: string(domain.TypeShoes)})
+
+		assertStatus(t, err, codes.FailedPrecondition, domain.ErrNoOpenReception.Code)
+	})
+
+	t.Run("AddProduct Empty Type", func(t *testing.T) {
+		server, _, _ := newTestServer(t)
+
+		_, err := server.AddProduct(ctx, &pb.AddProductRequest{PvzId: pvzID.String()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DeleteLastProduct Success", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("DeleteLastProduct", mock.Anything, pvzID, actor).Return(nil).Once()
+
+		_, err := server.DeleteLastProduct(ctx, &pb.DeleteLastProductRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteLastProduct Empty Reception", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("DeleteLastProduct", mock.Anything, pvzID, actor).Return(domain.ErrReceptionEmpty).Once()
+
+		_, err := server.DeleteLastProduct(ctx, &pb.DeleteLastProductRequest{PvzId: pvzID.String()})
+
+		assertStatus(t, err, codes.FailedPrecondition, domain.ErrReceptionEmpty.Code)
+	})
+
+	t.Run("CloseLastReception Success", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		reception := domain.Reception{ID: uuid.New(), PVZID: pvzID, DateTime: time.Now(), Status: domain.StatusClosed}
+		receptionService.On("CloseLastReception", mock.Anything, pvzID, actor).Return(reception, nil).Once()
+
+		resp, err := server.CloseLastReception(ctx, &pb.CloseLastReceptionRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, reception.ID.String(), resp.Reception.Id)
+		assert.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_CLOSED, resp.Reception.Status)
+	})
+
+	t.Run("CloseLastReception No Open Reception", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("CloseLastReception", mock.Anything, pvzID, actor).Return(domain.Reception{}, domain.ErrNoOpenReception).Once()
+
+		_, err := server.CloseLastReception(ctx, &pb.CloseLastReceptionRequest{PvzId: pvzID.String()})
+
+		assertStatus(t, err, codes.FailedPrecondition, domain.ErrNoOpenReception.Code)
+	})
+
+	t.Run("Unexpected Error Is Internal Without Details Leak", func(t *testing.T) {
+		server, _, receptionService := newTestServer(t)
+		receptionService.On("CloseLastReception", mock.Anything, pvzID, actor).Return(domain.Reception{}, assert.AnError).Once()
+
+		_, err := server.CloseLastReception(ctx, &pb.CloseLastReceptionRequest{PvzId: pvzID.String()})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, status.Convert(err).Message(), assert.AnError.Error())
+	})
+
+	t.Run("Invalid PVZ ID", func(t *testing.T) {
+		server, _, _ := newTestServer(t) // Сервис не вызывается: мок без ожиданий
+		for _, raw := range []string{"", "not-a-uuid"} {
+			_, err := server.InitiateReception(ctx, &pb.InitiateReceptionRequest{PvzId: raw})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "InitiateReception %q", raw)
+			_, err = server.AddProduct(ctx, &pb.AddProductRequest{PvzId: raw, Type: string(domain.TypeShoes)})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "AddProduct %q", raw)
+			_, err = server.DeleteLastProduct(ctx, &pb.DeleteLastProductRequest{PvzId: raw})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "DeleteLastProduct %q", raw)
+			_, err = server.CloseLastReception(ctx, &pb.CloseLastReceptionRequest{PvzId: raw})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "CloseLastReception %q", raw)
+		}
+	})
+}
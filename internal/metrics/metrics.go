@@ -44,4 +44,28 @@ var (
 			Help: "Total number of added products.",
 		},
 	)
+
+	StaleReceptionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pvz_stale_receptions_total",
+			Help: "Total number of stale receptions handled by the background worker.",
+		},
+		[]string{"action"}, // closed, flagged, failed
+	)
+
+	LoginLockoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pvz_login_lockouts_total",
+			Help: "Total number of login lockouts after repeated failed attempts.",
+		},
+		[]string{"scope"}, // email, ip
+	)
+
+	LoginThrottledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pvz_login_throttled_total",
+			Help: "Total number of login attempts rejected by backoff or lockout.",
+		},
+		[]string{"scope"}, // email, ip
+	)
 )
@@ -0,0 +1,72 @@
+// Package notify - реализации service.Notifier для локальной работы: токен сброса пароля
+// пишется в лог сервиса или дописывается в файл. Для рассылки писем нужна своя реализация.
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// passwordResetMessage - запись о токене сброса пароля (одна строка JSON в файле).
+type passwordResetMessage struct {
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// LogNotifier - заглушка: токен сброса никуда не доставляется, в лог пишется только факт его выдачи.
+// Сам токен - секрет и в лог не попадает; получить его локально можно через FileNotifier.
+type LogNotifier struct{}
+
+// NewLogNotifier - конструктор для LogNotifier.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// SendPasswordReset пишет в лог email и начало SHA-256 хеша токена (по нему запись находится в
+// password_reset_tokens.token_hash).
+func (n *LogNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	sum := sha256.Sum256([]byte(token))
+	slog.InfoContext(ctx, "Выдан токен сброса пароля (notifier: log, токен не доставляется)",
+		"email", email, "token_sha256_prefix", hex.EncodeToString(sum[:4]), "expires_at", expiresAt)
+	return nil
+}
+
+// FileNotifier дописывает токены сброса в файл в формате JSON Lines.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier - конструктор для FileNotifier. Файл создается при первой записи.
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// SendPasswordReset дописывает токен сброса в файл.
+func (n *FileNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	line, err := json.Marshal(passwordResetMessage{Email: email, Token: token, ExpiresAt: expiresAt, SentAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования сообщения: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть файл уведомлений %s: %w", n.path, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("не удалось записать в файл уведомлений %s: %w", n.path, err)
+	}
+	return nil
+}
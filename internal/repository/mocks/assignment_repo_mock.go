@@ -0,0 +1,125 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AssignmentRepository is an autogenerated mock type for the AssignmentRepository type
+type AssignmentRepository struct {
+	mock.Mock
+}
+
+// AssignPVZ provides a mock function with given fields: ctx, assignment
+func (_m *AssignmentRepository) AssignPVZ(ctx context.Context, assignment domain.PVZAssignment) error {
+	ret := _m.Called(ctx, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignPVZ")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZAssignment) error); ok {
+		r0 = rf(ctx, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsAssigned provides a mock function with given fields: ctx, userID, pvzID
+func (_m *AssignmentRepository) IsAssigned(ctx context.Context, userID uuid.UUID, pvzID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAssigned")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID, pvzID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAssignmentsByUser provides a mock function with given fields: ctx, userID
+func (_m *AssignmentRepository) ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]domain.PVZAssignment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAssignmentsByUser")
+	}
+
+	var r0 []domain.PVZAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.PVZAssignment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.PVZAssignment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PVZAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnassignPVZ provides a mock function with given fields: ctx, userID, pvzID
+func (_m *AssignmentRepository) UnassignPVZ(ctx context.Context, userID uuid.UUID, pvzID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignPVZ")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, pvzID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAssignmentRepository creates a new instance of AssignmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAssignmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AssignmentRepository {
+	mock := &AssignmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,169 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CityRepository is an autogenerated mock type for the CityRepository type
+type CityRepository struct {
+	mock.Mock
+}
+
+// CreateCity provides a mock function with given fields: ctx, city
+func (_m *CityRepository) CreateCity(ctx context.Context, city domain.City) error {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.City) error); ok {
+		r0 = rf(ctx, city)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCity provides a mock function with given fields: ctx, code
+func (_m *CityRepository) DeleteCity(ctx context.Context, code string) error {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCityByCode provides a mock function with given fields: ctx, code
+func (_m *CityRepository) GetCityByCode(ctx context.Context, code string) (domain.City, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCityByCode")
+	}
+
+	var r0 domain.City
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.City, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.City); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(domain.City)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCityByName provides a mock function with given fields: ctx, name
+func (_m *CityRepository) GetCityByName(ctx context.Context, name string) (domain.City, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCityByName")
+	}
+
+	var r0 domain.City
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.City, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.City); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(domain.City)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCities provides a mock function with given fields: ctx, includeInactive
+func (_m *CityRepository) ListCities(ctx context.Context, includeInactive bool) ([]domain.City, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCities")
+	}
+
+	var r0 []domain.City
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]domain.City, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []domain.City); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.City)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCity provides a mock function with given fields: ctx, city
+func (_m *CityRepository) UpdateCity(ctx context.Context, city domain.City) error {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.City) error); ok {
+		r0 = rf(ctx, city)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCityRepository creates a new instance of CityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CityRepository {
+	mock := &CityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+// DeleteLoginAttemptsBefore provides a mock function with given fields: ctx, before
+func (_m *LoginAttemptRepository) DeleteLoginAttemptsBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLoginAttemptsBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginAttempts provides a mock function with given fields: ctx, keys
+func (_m *LoginAttemptRepository) GetLoginAttempts(ctx context.Context, keys []domain.LoginAttemptKey) ([]domain.LoginAttempt, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginAttempts")
+	}
+
+	var r0 []domain.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.LoginAttemptKey) ([]domain.LoginAttempt, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.LoginAttemptKey) []domain.LoginAttempt); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.LoginAttemptKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, key, at, window
+func (_m *LoginAttemptRepository) RecordLoginFailure(ctx context.Context, key domain.LoginAttemptKey, at time.Time, window time.Duration) (domain.LoginAttempt, error) {
+	ret := _m.Called(ctx, key, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginFailure")
+	}
+
+	var r0 domain.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoginAttemptKey, time.Time, time.Duration) (domain.LoginAttempt, error)); ok {
+		return rf(ctx, key, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoginAttemptKey, time.Time, time.Duration) domain.LoginAttempt); ok {
+		r0 = rf(ctx, key, at, window)
+	} else {
+		r0 = ret.Get(0).(domain.LoginAttempt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LoginAttemptKey, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, key, at, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseLoginAttempt provides a mock function with given fields: ctx, reserved
+func (_m *LoginAttemptRepository) ReleaseLoginAttempt(ctx context.Context, reserved domain.LoginAttempt) error {
+	ret := _m.Called(ctx, reserved)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLoginAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoginAttempt) error); ok {
+		r0 = rf(ctx, reserved)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetLoginAttempts provides a mock function with given fields: ctx, key
+func (_m *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key domain.LoginAttemptKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LoginAttemptKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,123 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductTypeRepository is an autogenerated mock type for the ProductTypeRepository type
+type ProductTypeRepository struct {
+	mock.Mock
+}
+
+// CreateProductType provides a mock function with given fields: ctx, productType
+func (_m *ProductTypeRepository) CreateProductType(ctx context.Context, productType domain.ProductTypeInfo) error {
+	ret := _m.Called(ctx, productType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProductType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductTypeInfo) error); ok {
+		r0 = rf(ctx, productType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetProductType provides a mock function with given fields: ctx, code
+func (_m *ProductTypeRepository) GetProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductType")
+	}
+
+	var r0 domain.ProductTypeInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductType) (domain.ProductTypeInfo, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductType) domain.ProductTypeInfo); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(domain.ProductTypeInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ProductType) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProductTypes provides a mock function with given fields: ctx, includeInactive
+func (_m *ProductTypeRepository) ListProductTypes(ctx context.Context, includeInactive bool) ([]domain.ProductTypeInfo, error) {
+	ret := _m.Called(ctx, includeInactive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductTypes")
+	}
+
+	var r0 []domain.ProductTypeInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]domain.ProductTypeInfo, error)); ok {
+		return rf(ctx, includeInactive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []domain.ProductTypeInfo); ok {
+		r0 = rf(ctx, includeInactive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProductTypeInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProductType provides a mock function with given fields: ctx, productType
+func (_m *ProductTypeRepository) UpdateProductType(ctx context.Context, productType domain.ProductTypeInfo) error {
+	ret := _m.Called(ctx, productType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProductType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductTypeInfo) error); ok {
+		r0 = rf(ctx, productType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewProductTypeRepository creates a new instance of ProductTypeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductTypeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductTypeRepository {
+	mock := &ProductTypeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -48,9 +48,9 @@ func (_m *PVZRepository) CreatePVZ(ctx context.Context, pvz domain.PVZ) (uuid.UU
 	return r0, r1
 }
 
-// GetAllPVZs provides a mock function with given fields: ctx
-func (_m *PVZRepository) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
-	ret := _m.Called(ctx)
+// GetAllPVZs provides a mock function with given fields: ctx, includeInactive
+func (_m *PVZRepository) GetAllPVZs(ctx context.Context, includeInactive bool) ([]domain.PVZ, error) {
+	ret := _m.Called(ctx, includeInactive)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllPVZs")
@@ -58,19 +58,19 @@ func (_m *PVZRepository) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
 
 	var r0 []domain.PVZ
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PVZ, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]domain.PVZ, error)); ok {
+		return rf(ctx, includeInactive)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.PVZ); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []domain.PVZ); ok {
+		r0 = rf(ctx, includeInactive)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.PVZ)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, includeInactive)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,9 +78,37 @@ func (_m *PVZRepository) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
 	return r0, r1
 }
 
-// ListPVZs provides a mock function with given fields: ctx, limit, afterRegistrationDate, afterID
-func (_m *PVZRepository) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID) ([]domain.PVZ, error) {
-	ret := _m.Called(ctx, limit, afterRegistrationDate, afterID)
+// GetPVZByID provides a mock function with given fields: ctx, id
+func (_m *PVZRepository) GetPVZByID(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZByID")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPVZs provides a mock function with given fields: ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo
+func (_m *PVZRepository) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) ([]domain.PVZ, error) {
+	ret := _m.Called(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListPVZs")
@@ -88,19 +116,19 @@ func (_m *PVZRepository) ListPVZs(ctx context.Context, limit int, afterRegistrat
 
 	var r0 []domain.PVZ
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID) ([]domain.PVZ, error)); ok {
-		return rf(ctx, limit, afterRegistrationDate, afterID)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) ([]domain.PVZ, error)); ok {
+		return rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID) []domain.PVZ); ok {
-		r0 = rf(ctx, limit, afterRegistrationDate, afterID)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) []domain.PVZ); ok {
+		r0 = rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.PVZ)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time, *uuid.UUID) error); ok {
-		r1 = rf(ctx, limit, afterRegistrationDate, afterID)
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) error); ok {
+		r1 = rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,6 +136,42 @@ func (_m *PVZRepository) ListPVZs(ctx context.Context, limit int, afterRegistrat
 	return r0, r1
 }
 
+// SetPVZActive provides a mock function with given fields: ctx, id, active
+func (_m *PVZRepository) SetPVZActive(ctx context.Context, id uuid.UUID, active bool) error {
+	ret := _m.Called(ctx, id, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPVZActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, id, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePVZ provides a mock function with given fields: ctx, pvz
+func (_m *PVZRepository) UpdatePVZ(ctx context.Context, pvz domain.PVZ) error {
+	ret := _m.Called(ctx, pvz)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePVZ")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZ) error); ok {
+		r0 = rf(ctx, pvz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPVZRepository creates a new instance of PVZRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPVZRepository(t interface {
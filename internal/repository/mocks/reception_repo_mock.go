@@ -48,22 +48,34 @@ func (_m *ReceptionRepository) AddProductToReception(ctx context.Context, produc
 	return r0, r1
 }
 
-// CloseReceptionByID provides a mock function with given fields: ctx, receptionID
-func (_m *ReceptionRepository) CloseReceptionByID(ctx context.Context, receptionID uuid.UUID) error {
-	ret := _m.Called(ctx, receptionID)
+// AddProductsToReception provides a mock function with given fields: ctx, products
+func (_m *ReceptionRepository) AddProductsToReception(ctx context.Context, products []domain.Product) ([]domain.Product, error) {
+	ret := _m.Called(ctx, products)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CloseReceptionByID")
+		panic("no return value specified for AddProductsToReception")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, receptionID)
+	var r0 []domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Product) ([]domain.Product, error)); ok {
+		return rf(ctx, products)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Product) []domain.Product); ok {
+		r0 = rf(ctx, products)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Product) error); ok {
+		r1 = rf(ctx, products)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // CreateReception provides a mock function with given fields: ctx, reception
@@ -96,17 +108,35 @@ func (_m *ReceptionRepository) CreateReception(ctx context.Context, reception do
 	return r0, r1
 }
 
-// DeleteProductByID provides a mock function with given fields: ctx, productID
-func (_m *ReceptionRepository) DeleteProductByID(ctx context.Context, productID uuid.UUID) error {
-	ret := _m.Called(ctx, productID)
+// DeleteProductByID provides a mock function with given fields: ctx, productID, actor
+func (_m *ReceptionRepository) DeleteProductByID(ctx context.Context, productID uuid.UUID, actor domain.Actor) error {
+	ret := _m.Called(ctx, productID, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteProductByID")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r0 = rf(ctx, productID, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlagReceptionStale provides a mock function with given fields: ctx, receptionID
+func (_m *ReceptionRepository) FlagReceptionStale(ctx context.Context, receptionID uuid.UUID) error {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagReceptionStale")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, productID)
+		r0 = rf(ctx, receptionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -114,6 +144,34 @@ func (_m *ReceptionRepository) DeleteProductByID(ctx context.Context, productID
 	return r0
 }
 
+// GetLastDeletedProductFromReception provides a mock function with given fields: ctx, receptionID
+func (_m *ReceptionRepository) GetLastDeletedProductFromReception(ctx context.Context, receptionID uuid.UUID) (domain.Product, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastDeletedProductFromReception")
+	}
+
+	var r0 domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Product, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Product); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		r0 = ret.Get(0).(domain.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastOpenReceptionByPVZ provides a mock function with given fields: ctx, pvzID
 func (_m *ReceptionRepository) GetLastOpenReceptionByPVZ(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error) {
 	ret := _m.Called(ctx, pvzID)
@@ -170,9 +228,123 @@ func (_m *ReceptionRepository) GetLastProductFromReception(ctx context.Context,
 	return r0, r1
 }
 
-// ListProductsByReceptionIDs provides a mock function with given fields: ctx, receptionIDs
-func (_m *ReceptionRepository) ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]domain.Product, error) {
-	ret := _m.Called(ctx, receptionIDs)
+// GetProductByID provides a mock function with given fields: ctx, productID
+func (_m *ReceptionRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Product, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Product, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Product); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(domain.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceptionByID provides a mock function with given fields: ctx, receptionID
+func (_m *ReceptionRepository) GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (domain.Reception, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionByID")
+	}
+
+	var r0 domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Reception, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Reception); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		r0 = ret.Get(0).(domain.Reception)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasNewerReception provides a mock function with given fields: ctx, reception
+func (_m *ReceptionRepository) HasNewerReception(ctx context.Context, reception domain.Reception) (bool, error) {
+	ret := _m.Called(ctx, reception)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasNewerReception")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reception) (bool, error)); ok {
+		return rf(ctx, reception)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reception) bool); ok {
+		r0 = rf(ctx, reception)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Reception) error); ok {
+		r1 = rf(ctx, reception)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProductsByBarcode provides a mock function with given fields: ctx, barcode, pvzIDs, limit
+func (_m *ReceptionRepository) ListProductsByBarcode(ctx context.Context, barcode string, pvzIDs []uuid.UUID, limit uint64) ([]domain.Product, error) {
+	ret := _m.Called(ctx, barcode, pvzIDs, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductsByBarcode")
+	}
+
+	var r0 []domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID, uint64) ([]domain.Product, error)); ok {
+		return rf(ctx, barcode, pvzIDs, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID, uint64) []domain.Product); ok {
+		r0 = rf(ctx, barcode, pvzIDs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []uuid.UUID, uint64) error); ok {
+		r1 = rf(ctx, barcode, pvzIDs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProductsByReceptionIDs provides a mock function with given fields: ctx, receptionIDs, includeDeleted
+func (_m *ReceptionRepository) ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID, includeDeleted bool) ([]domain.Product, error) {
+	ret := _m.Called(ctx, receptionIDs, includeDeleted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListProductsByReceptionIDs")
@@ -180,19 +352,49 @@ func (_m *ReceptionRepository) ListProductsByReceptionIDs(ctx context.Context, r
 
 	var r0 []domain.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]domain.Product, error)); ok {
-		return rf(ctx, receptionIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, bool) ([]domain.Product, error)); ok {
+		return rf(ctx, receptionIDs, includeDeleted)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []domain.Product); ok {
-		r0 = rf(ctx, receptionIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, bool) []domain.Product); ok {
+		r0 = rf(ctx, receptionIDs, includeDeleted)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
-		r1 = rf(ctx, receptionIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, bool) error); ok {
+		r1 = rf(ctx, receptionIDs, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceptionsByPVZ provides a mock function with given fields: ctx, pvzID, filter
+func (_m *ReceptionRepository) ListReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionFilter) ([]domain.Reception, error) {
+	ret := _m.Called(ctx, pvzID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceptionsByPVZ")
+	}
+
+	var r0 []domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionFilter) ([]domain.Reception, error)); ok {
+		return rf(ctx, pvzID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionFilter) []domain.Reception); ok {
+		r0 = rf(ctx, pvzID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.ReceptionFilter) error); ok {
+		r1 = rf(ctx, pvzID, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -230,6 +432,136 @@ func (_m *ReceptionRepository) ListReceptionsByPVZIDs(ctx context.Context, pvzID
 	return r0, r1
 }
 
+// ListStaleReceptions provides a mock function with given fields: ctx, filter
+func (_m *ReceptionRepository) ListStaleReceptions(ctx context.Context, filter domain.StaleReceptionFilter) ([]domain.Reception, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStaleReceptions")
+	}
+
+	var r0 []domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StaleReceptionFilter) ([]domain.Reception, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StaleReceptionFilter) []domain.Reception); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StaleReceptionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordProductDeletion provides a mock function with given fields: ctx, deletion
+func (_m *ReceptionRepository) RecordProductDeletion(ctx context.Context, deletion domain.ProductDeletion) error {
+	ret := _m.Called(ctx, deletion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordProductDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProductDeletion) error); ok {
+		r0 = rf(ctx, deletion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordReceptionAudit provides a mock function with given fields: ctx, entry
+func (_m *ReceptionRepository) RecordReceptionAudit(ctx context.Context, entry domain.ReceptionAuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordReceptionAudit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReceptionAuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreProduct provides a mock function with given fields: ctx, productID
+func (_m *ReceptionRepository) RestoreProduct(ctx context.Context, productID uuid.UUID) error {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TryLockReception provides a mock function with given fields: ctx, receptionID
+func (_m *ReceptionRepository) TryLockReception(ctx context.Context, receptionID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryLockReception")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReceptionStatus provides a mock function with given fields: ctx, receptionID, from, to, actor
+func (_m *ReceptionRepository) UpdateReceptionStatus(ctx context.Context, receptionID uuid.UUID, from domain.ReceptionStatus, to domain.ReceptionStatus, actor domain.Actor) error {
+	ret := _m.Called(ctx, receptionID, from, to, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReceptionStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.ReceptionStatus, domain.ReceptionStatus, domain.Actor) error); ok {
+		r0 = rf(ctx, receptionID, from, to, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewReceptionRepository creates a new instance of ReceptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewReceptionRepository(t interface {
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// TokenRepository is an autogenerated mock type for the TokenRepository type
+type TokenRepository struct {
+	mock.Mock
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *TokenRepository) CreateRefreshToken(ctx context.Context, token domain.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRefreshTokenByHash provides a mock function with given fields: ctx, hash
+func (_m *TokenRepository) GetRefreshTokenByHash(ctx context.Context, hash []byte) (domain.RefreshToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByHash")
+	}
+
+	var r0 domain.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (domain.RefreshToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) domain.RefreshToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(domain.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRevokedAccessTokens provides a mock function with given fields: ctx, since
+func (_m *TokenRepository) ListRevokedAccessTokens(ctx context.Context, since time.Time) ([]domain.RevokedToken, time.Time, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevokedAccessTokens")
+	}
+
+	var r0 []domain.RevokedToken
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.RevokedToken, time.Time, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.RevokedToken); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RevokedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) time.Time); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkRefreshTokenUsed provides a mock function with given fields: ctx, id
+func (_m *TokenRepository) MarkRefreshTokenUsed(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRefreshTokenUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAccessTokens provides a mock function with given fields: ctx, tokens
+func (_m *TokenRepository) RevokeAccessTokens(ctx context.Context, tokens []domain.RevokedToken) error {
+	ret := _m.Called(ctx, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAccessTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.RevokedToken) error); ok {
+		r0 = rf(ctx, tokens)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *TokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) ([]domain.RevokedToken, error) {
+	ret := _m.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 []domain.RevokedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.RevokedToken, error)); ok {
+		return rf(ctx, familyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.RevokedToken); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RevokedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeUserRefreshTokens provides a mock function with given fields: ctx, userID
+func (_m *TokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) ([]domain.RevokedToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserRefreshTokens")
+	}
+
+	var r0 []domain.RevokedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.RevokedToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.RevokedToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RevokedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTokenRepository creates a new instance of TokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TokenRepository {
+	mock := &TokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
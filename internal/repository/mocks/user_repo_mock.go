@@ -16,6 +16,42 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CreateInvite provides a mock function with given fields: ctx, invite
+func (_m *UserRepository) CreateInvite(ctx context.Context, invite domain.UserInvite) error {
+	ret := _m.Called(ctx, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserInvite) error); ok {
+		r0 = rf(ctx, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePasswordResetToken provides a mock function with given fields: ctx, token
+func (_m *UserRepository) CreatePasswordResetToken(ctx context.Context, token domain.PasswordResetToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PasswordResetToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUser provides a mock function with given fields: ctx, user
 func (_m *UserRepository) CreateUser(ctx context.Context, user domain.User) (uuid.UUID, error) {
 	ret := _m.Called(ctx, user)
@@ -46,6 +82,62 @@ func (_m *UserRepository) CreateUser(ctx context.Context, user domain.User) (uui
 	return r0, r1
 }
 
+// GetInviteByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *UserRepository) GetInviteByHash(ctx context.Context, tokenHash []byte) (domain.UserInvite, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInviteByHash")
+	}
+
+	var r0 domain.UserInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (domain.UserInvite, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) domain.UserInvite); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(domain.UserInvite)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordResetTokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *UserRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash []byte) (domain.PasswordResetToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordResetTokenByHash")
+	}
+
+	var r0 domain.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (domain.PasswordResetToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) domain.PasswordResetToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(domain.PasswordResetToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
 	ret := _m.Called(ctx, email)
@@ -74,6 +166,154 @@ func (_m *UserRepository) GetUserByEmail(ctx context.Context, email string) (dom
 	return r0, r1
 }
 
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvalidatePasswordResetTokens provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidatePasswordResetTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) ([]domain.User, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) []domain.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkInviteUsed provides a mock function with given fields: ctx, id, userID
+func (_m *UserRepository) MarkInviteUsed(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkInviteUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, id, disabled
+func (_m *UserRepository) SetUserDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	ret := _m.Called(ctx, id, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, id, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, id, passwordHash
+func (_m *UserRepository) UpdateUserPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	ret := _m.Called(ctx, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, id, role
+func (_m *UserRepository) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// assignmentColumns - колонки назначения в порядке, который ожидает scanAssignment
+var assignmentColumns = []string{"user_id", "pvz_id", "assigned_at", "assigned_by"}
+
+// scanAssignment читает одну строку, выбранную с assignmentColumns
+func scanAssignment(row rowScanner) (domain.PVZAssignment, error) {
+	var a domain.PVZAssignment
+	err := row.Scan(&a.UserID, &a.PVZID, &a.AssignedAt, &a.AssignedBy)
+	return a, err
+}
+
+// AssignmentRepo - реализация интерфейса repository.AssignmentRepository для PostgreSQL (таблица 'user_pvz').
+type AssignmentRepo struct {
+	db *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewAssignmentRepo - конструктор для AssignmentRepo.
+func NewAssignmentRepo(db *sql.DB) *AssignmentRepo {
+	return &AssignmentRepo{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// AssignPVZ - назначает пользователя на ПВЗ. При повторном назначении остается исходная запись.
+func (r *AssignmentRepo) AssignPVZ(ctx context.Context, assignment domain.PVZAssignment) error {
+	sqlQuery, args, err := r.sq.
+		Insert("user_pvz").
+		Columns("user_id", "pvz_id", "assigned_by"). // assigned_at имеет DEFAULT NOW()
+		Values(assignment.UserID, assignment.PVZID, assignment.AssignedBy).
+		Suffix("ON CONFLICT (user_id, pvz_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для назначения на ПВЗ", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для назначения на ПВЗ: %w", err)
+	}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для назначения на ПВЗ", slog.Any("user_id", assignment.UserID), slog.Any("pvz_id", assignment.PVZID), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для назначения на ПВЗ: %w", err)
+	}
+	return nil
+}
+
+// UnassignPVZ - снимает назначение пользователя на ПВЗ.
+func (r *AssignmentRepo) UnassignPVZ(ctx context.Context, userID, pvzID uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Delete("user_pvz").
+		Where(squirrel.Eq{"user_id": userID, "pvz_id": pvzID}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для снятия назначения на ПВЗ", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для снятия назначения на ПВЗ: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для снятия назначения на ПВЗ", slog.Any("user_id", userID), slog.Any("pvz_id", pvzID), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для снятия назначения на ПВЗ: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrAssignmentNotFound
+	}
+	return nil
+}
+
+// ListAssignmentsByUser - возвращает назначения пользователя, новые первыми.
+func (r *AssignmentRepo) ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]domain.PVZAssignment, error) {
+	sqlQuery, args, err := r.sq.
+		Select(assignmentColumns...).
+		From("user_pvz").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("assigned_at DESC", "pvz_id").
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для списка назначений", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для списка назначений: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для списка назначений", slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для списка назначений: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := make([]domain.PVZAssignment, 0)
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования назначения: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по назначениям: %w", err)
+	}
+	return assignments, nil
+}
+
+// IsAssigned - проверяет, назначен ли пользователь на ПВЗ.
+func (r *AssignmentRepo) IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	sqlQuery, args, err := r.sq.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("user_pvz").
+		Where(squirrel.Eq{"user_id": userID, "pvz_id": pvzID}).
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для проверки назначения", slog.Any("error", err))
+		return false, fmt.Errorf("ошибка построения SQL для проверки назначения: %w", err)
+	}
+
+	var assigned bool
+	if err := conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).Scan(&assigned); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для проверки назначения", slog.Any("user_id", userID), slog.Any("pvz_id", pvzID), slog.Any("error", err))
+		return false, fmt.Errorf("ошибка выполнения SQL для проверки назначения: %w", err)
+	}
+	return assigned, nil
+}
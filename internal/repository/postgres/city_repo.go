@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn" // Для проверки кода ошибки PostgreSQL
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// cityColumns - колонки города в порядке, который ожидает scanCity
+var cityColumns = []string{"code", "name", "timezone", "is_active", "created_at"}
+
+// scanCity читает одну строку, выбранную с cityColumns
+func scanCity(row rowScanner) (domain.City, error) {
+	var city domain.City
+	err := row.Scan(&city.Code, &city.Name, &city.Timezone, &city.IsActive, &city.CreatedAt)
+	return city, err
+}
+
+// CityRepo - реализация интерфейса repository.CityRepository для PostgreSQL (таблица 'cities').
+type CityRepo struct {
+	db *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewCityRepo - конструктор для CityRepo.
+func NewCityRepo(db *sql.DB) *CityRepo {
+	return &CityRepo{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateCity - сохраняет новый город. created_at заполняется по DEFAULT NOW().
+func (r *CityRepo) CreateCity(ctx context.Context, city domain.City) error {
+	sqlQuery, args, err := r.sq.
+		Insert("cities").
+		Columns("code", "name", "timezone", "is_active").
+		Values(city.Code, city.Name, city.Timezone, city.IsActive).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для создания города", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для создания города: %w", err)
+	}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 = unique_violation (code или name)
+			return repository.ErrCityDuplicate
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания города", slog.String("code", city.Code), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для создания города: %w", err)
+	}
+	return nil
+}
+
+// GetCityByCode - возвращает город по коду.
+func (r *CityRepo) GetCityByCode(ctx context.Context, code string) (domain.City, error) {
+	return r.getCity(ctx, squirrel.Eq{"code": code})
+}
+
+// GetCityByName - возвращает город по отображаемому имени.
+func (r *CityRepo) GetCityByName(ctx context.Context, name string) (domain.City, error) {
+	return r.getCity(ctx, squirrel.Eq{"name": name})
+}
+
+// getCity - общий SELECT одного города по условию where.
+func (r *CityRepo) getCity(ctx context.Context, where squirrel.Eq) (domain.City, error) {
+	sqlQuery, args, err := r.sq.
+		Select(cityColumns...).
+		From("cities").
+		Where(where).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения города", slog.Any("error", err))
+		return domain.City{}, fmt.Errorf("ошибка построения SQL для получения города: %w", err)
+	}
+
+	city, err := scanCity(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.City{}, repository.ErrCityNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для получения города", slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.City{}, fmt.Errorf("ошибка выполнения SQL для получения города: %w", err)
+	}
+	return city, nil
+}
+
+// ListCities - возвращает города, упорядоченные по имени.
+func (r *CityRepo) ListCities(ctx context.Context, includeInactive bool) ([]domain.City, error) {
+	queryBuilder := r.sq.
+		Select(cityColumns...).
+		From("cities").
+		OrderBy("name")
+	if !includeInactive {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"is_active": true})
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для списка городов", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для списка городов: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для списка городов", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для списка городов: %w", err)
+	}
+	defer rows.Close()
+
+	cities := make([]domain.City, 0)
+	for rows.Next() {
+		city, err := scanCity(rows)
+		if err != nil {
+			slog.WarnContext(ctx, "Ошибка сканирования строки города", slog.Any("error", err))
+			continue
+		}
+		cities = append(cities, city)
+	}
+	if err = rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по результатам списка городов", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по результатам списка городов: %w", err)
+	}
+	return cities, nil
+}
+
+// UpdateCity - сохраняет имя, таймзону и активность города.
+// Переименование каскадно обновляет pvz.city (ON UPDATE CASCADE).
+func (r *CityRepo) UpdateCity(ctx context.Context, city domain.City) error {
+	sqlQuery, args, err := r.sq.
+		Update("cities").
+		Set("name", city.Name).
+		Set("timezone", city.Timezone).
+		Set("is_active", city.IsActive).
+		Where(squirrel.Eq{"code": city.Code}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для обновления города", slog.String("code", city.Code), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для обновления города: %w", err)
+	}
+
+	return r.execAffectingCity(ctx, city.Code, sqlQuery, args, "обновления города")
+}
+
+// DeleteCity - удаляет город по коду. Город с ПВЗ удалить нельзя (FK pvz_city_fkey).
+func (r *CityRepo) DeleteCity(ctx context.Context, code string) error {
+	sqlQuery, args, err := r.sq.
+		Delete("cities").
+		Where(squirrel.Eq{"code": code}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для удаления города", slog.String("code", code), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для удаления города: %w", err)
+	}
+
+	return r.execAffectingCity(ctx, code, sqlQuery, args, "удаления города")
+}
+
+// execAffectingCity выполняет UPDATE/DELETE одного города и переводит ошибки PostgreSQL
+// в ошибки репозитория: 0 строк -> ErrCityNotFound, unique_violation -> ErrCityDuplicate,
+// foreign_key_violation -> ErrCityInUse.
+func (r *CityRepo) execAffectingCity(ctx context.Context, code, sqlQuery string, args []any, action string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique_violation
+				return repository.ErrCityDuplicate
+			case "23503": // foreign_key_violation
+				return repository.ErrCityInUse
+			}
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для "+action, slog.String("code", code), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.WarnContext(ctx, "Не удалось получить количество затронутых строк города", slog.String("code", code), slog.Any("error", err))
+		return nil // Запрос прошел, ошибку не возвращаем
+	}
+	if rowsAffected == 0 {
+		return repository.ErrCityNotFound
+	}
+	return nil
+}
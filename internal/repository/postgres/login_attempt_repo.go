@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+)
+
+// LoginAttemptRepo - реализация интерфейса repository.LoginAttemptRepository для PostgreSQL (таблица 'login_attempts').
+type LoginAttemptRepo struct {
+	db *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewLoginAttemptRepo - конструктор для LoginAttemptRepo.
+func NewLoginAttemptRepo(db *sql.DB) *LoginAttemptRepo {
+	return &LoginAttemptRepo{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// GetLoginAttempts - возвращает счетчики неудачных попыток для указанных ключей.
+func (r *LoginAttemptRepo) GetLoginAttempts(ctx context.Context, keys []domain.LoginAttemptKey) ([]domain.LoginAttempt, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	where := make(squirrel.Or, 0, len(keys))
+	for _, k := range keys {
+		where = append(where, squirrel.Eq{"scope": string(k.Scope), "key": k.Value})
+	}
+	sqlQuery, args, err := r.sq.
+		Select("scope", "key", "failures", "last_failure_at").
+		From("login_attempts").
+		Where(where).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения попыток входа", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для получения попыток входа: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для получения попыток входа", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для получения попыток входа: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []domain.LoginAttempt
+	for rows.Next() {
+		var a domain.LoginAttempt
+		if err := rows.Scan(&a.Key.Scope, &a.Key.Value, &a.Failures, &a.LastFailureAt); err != nil {
+			slog.ErrorContext(ctx, "Ошибка сканирования попытки входа", slog.Any("error", err))
+			return nil, fmt.Errorf("ошибка сканирования попытки входа: %w", err)
+		}
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по попыткам входа", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по попыткам входа: %w", err)
+	}
+	return attempts, nil
+}
+
+// RecordLoginFailure - увеличивает счетчик одним запросом (INSERT ... ON CONFLICT DO UPDATE),
+// чтобы параллельные попытки на разных экземплярах сервиса не потеряли инкременты.
+// Прежнее last_failure_at читается в том же запросе (CTE с FOR UPDATE) - RETURNING отдает только новые значения.
+func (r *LoginAttemptRepo) RecordLoginFailure(ctx context.Context, key domain.LoginAttemptKey, at time.Time, window time.Duration) (domain.LoginAttempt, error) {
+	sqlQuery, args, err := r.sq.
+		Insert("login_attempts").
+		Prefix("WITH prev AS (SELECT last_failure_at FROM login_attempts WHERE scope = ? AND key = ? FOR UPDATE)",
+			string(key.Scope), key.Value).
+		Columns("scope", "key", "failures", "last_failure_at").
+		Values(string(key.Scope), key.Value, 1, at).
+		Suffix(`ON CONFLICT (scope, key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+			RETURNING failures, last_failure_at, (SELECT last_failure_at FROM prev)`, at.Add(-window)).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для учета неудачного входа", slog.Any("error", err))
+		return domain.LoginAttempt{}, fmt.Errorf("ошибка построения SQL для учета неудачного входа: %w", err)
+	}
+
+	attempt := domain.LoginAttempt{Key: key}
+	var prev sql.NullTime
+	if err := conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).Scan(&attempt.Failures, &attempt.LastFailureAt, &prev); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для учета неудачного входа", slog.String("scope", string(key.Scope)), slog.Any("error", err))
+		return domain.LoginAttempt{}, fmt.Errorf("ошибка выполнения SQL для учета неудачного входа: %w", err)
+	}
+	if prev.Valid {
+		attempt.PrevFailureAt = prev.Time
+	}
+	return attempt, nil
+}
+
+// ReleaseLoginAttempt - снимает зарезервированную попытку (счетчик не уходит ниже нуля) и возвращает
+// last_failure_at к значению до резерва, если после резерва его не обновила другая попытка.
+func (r *LoginAttemptRepo) ReleaseLoginAttempt(ctx context.Context, reserved domain.LoginAttempt) error {
+	key := reserved.Key
+	query := r.sq.
+		Update("login_attempts").
+		Set("failures", squirrel.Expr("failures - 1")).
+		Where(squirrel.Eq{"scope": string(key.Scope), "key": key.Value}).
+		Where(squirrel.Gt{"failures": 0})
+	if !reserved.PrevFailureAt.IsZero() {
+		query = query.Set("last_failure_at", squirrel.Expr("CASE WHEN last_failure_at = ? THEN ? ELSE last_failure_at END",
+			reserved.LastFailureAt, reserved.PrevFailureAt))
+	}
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для снятия резерва попытки входа", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для снятия резерва попытки входа: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для снятия резерва попытки входа", slog.String("scope", string(key.Scope)), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для снятия резерва попытки входа: %w", err)
+	}
+	return nil
+}
+
+// ResetLoginAttempts - удаляет счетчик после успешного входа.
+func (r *LoginAttemptRepo) ResetLoginAttempts(ctx context.Context, key domain.LoginAttemptKey) error {
+	sqlQuery, args, err := r.sq.
+		Delete("login_attempts").
+		Where(squirrel.Eq{"scope": string(key.Scope), "key": key.Value}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для сброса попыток входа", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для сброса попыток входа: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для сброса попыток входа", slog.String("scope", string(key.Scope)), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для сброса попыток входа: %w", err)
+	}
+	return nil
+}
+
+// DeleteLoginAttemptsBefore - удаляет устаревшие счетчики.
+func (r *LoginAttemptRepo) DeleteLoginAttemptsBefore(ctx context.Context, before time.Time) (int64, error) {
+	sqlQuery, args, err := r.sq.
+		Delete("login_attempts").
+		Where(squirrel.Lt{"last_failure_at": before}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для очистки попыток входа", slog.Any("error", err))
+		return 0, fmt.Errorf("ошибка построения SQL для очистки попыток входа: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для очистки попыток входа", slog.Any("error", err))
+		return 0, fmt.Errorf("ошибка выполнения SQL для очистки попыток входа: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn" // Для проверки кода ошибки PostgreSQL
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// productTypeColumns - колонки типа товара в порядке, который ожидает scanProductType
+var productTypeColumns = []string{"code", "names", "is_active", "attributes_schema", "created_at"}
+
+// scanProductType читает одну строку, выбранную с productTypeColumns.
+// names и attributes_schema хранятся в JSONB.
+func scanProductType(row rowScanner) (domain.ProductTypeInfo, error) {
+	var (
+		pt        domain.ProductTypeInfo
+		namesRaw  []byte
+		schemaRaw []byte // NULL -> nil
+	)
+	if err := row.Scan(&pt.Code, &namesRaw, &pt.IsActive, &schemaRaw, &pt.CreatedAt); err != nil {
+		return domain.ProductTypeInfo{}, err
+	}
+	if err := json.Unmarshal(namesRaw, &pt.Names); err != nil {
+		return domain.ProductTypeInfo{}, fmt.Errorf("некорректный JSON в product_types.names: %w", err)
+	}
+	if schemaRaw != nil {
+		pt.AttributesSchema = &domain.AttributeSchema{}
+		if err := json.Unmarshal(schemaRaw, pt.AttributesSchema); err != nil {
+			return domain.ProductTypeInfo{}, fmt.Errorf("некорректный JSON в product_types.attributes_schema: %w", err)
+		}
+	}
+	return pt, nil
+}
+
+// productTypeJSON сериализует JSONB-поля типа товара; schema == nil -> NULL.
+func productTypeJSON(pt domain.ProductTypeInfo) (names []byte, schema []byte, err error) {
+	if names, err = json.Marshal(pt.Names); err != nil {
+		return nil, nil, fmt.Errorf("ошибка сериализации названий типа товара: %w", err)
+	}
+	if pt.AttributesSchema != nil {
+		if schema, err = json.Marshal(pt.AttributesSchema); err != nil {
+			return nil, nil, fmt.Errorf("ошибка сериализации схемы атрибутов: %w", err)
+		}
+	}
+	return names, schema, nil
+}
+
+// ProductTypeRepo - реализация интерфейса repository.ProductTypeRepository для PostgreSQL (таблица 'product_types').
+type ProductTypeRepo struct {
+	db *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewProductTypeRepo - конструктор для ProductTypeRepo.
+func NewProductTypeRepo(db *sql.DB) *ProductTypeRepo {
+	return &ProductTypeRepo{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateProductType - сохраняет новый тип товара. created_at заполняется по DEFAULT NOW().
+func (r *ProductTypeRepo) CreateProductType(ctx context.Context, pt domain.ProductTypeInfo) error {
+	names, schema, err := productTypeJSON(pt)
+	if err != nil {
+		return err
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("product_types").
+		Columns("code", "names", "is_active", "attributes_schema").
+		Values(pt.Code, names, pt.IsActive, schema).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для создания типа товара", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для создания типа товара: %w", err)
+	}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 = unique_violation
+			return repository.ErrProductTypeDuplicate
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания типа товара", slog.Any("code", pt.Code), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для создания типа товара: %w", err)
+	}
+	return nil
+}
+
+// GetProductType - возвращает тип товара по коду.
+func (r *ProductTypeRepo) GetProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error) {
+	sqlQuery, args, err := r.sq.
+		Select(productTypeColumns...).
+		From("product_types").
+		Where(squirrel.Eq{"code": code}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения типа товара", slog.Any("error", err))
+		return domain.ProductTypeInfo{}, fmt.Errorf("ошибка построения SQL для получения типа товара: %w", err)
+	}
+
+	pt, err := scanProductType(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ProductTypeInfo{}, repository.ErrProductTypeNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для получения типа товара", slog.Any("code", code), slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.ProductTypeInfo{}, fmt.Errorf("ошибка выполнения SQL для получения типа товара: %w", err)
+	}
+	return pt, nil
+}
+
+// ListProductTypes - возвращает типы товаров, упорядоченные по коду.
+func (r *ProductTypeRepo) ListProductTypes(ctx context.Context, includeInactive bool) ([]domain.ProductTypeInfo, error) {
+	queryBuilder := r.sq.
+		Select(productTypeColumns...).
+		From("product_types").
+		OrderBy("code")
+	if !includeInactive {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"is_active": true})
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для списка типов товаров", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для списка типов товаров: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для списка типов товаров", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для списка типов товаров: %w", err)
+	}
+	defer rows.Close()
+
+	types := make([]domain.ProductTypeInfo, 0)
+	for rows.Next() {
+		pt, err := scanProductType(rows)
+		if err != nil {
+			slog.WarnContext(ctx, "Ошибка сканирования строки типа товара", slog.Any("error", err))
+			continue
+		}
+		types = append(types, pt)
+	}
+	if err = rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по результатам типов товаров", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по результатам типов товаров: %w", err)
+	}
+	return types, nil
+}
+
+// UpdateProductType - сохраняет названия, активность и схему атрибутов типа товара.
+// Уже добавленные товары не перепроверяются по новой схеме.
+func (r *ProductTypeRepo) UpdateProductType(ctx context.Context, pt domain.ProductTypeInfo) error {
+	names, schema, err := productTypeJSON(pt)
+	if err != nil {
+		return err
+	}
+
+	sqlQuery, args, err := r.sq.
+		Update("product_types").
+		Set("names", names).
+		Set("is_active", pt.IsActive).
+		Set("attributes_schema", schema).
+		Where(squirrel.Eq{"code": pt.Code}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для обновления типа товара", slog.Any("code", pt.Code), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для обновления типа товара: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для обновления типа товара", slog.Any("code", pt.Code), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для обновления типа товара: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.WarnContext(ctx, "Не удалось получить количество обновленных строк типа товара", slog.Any("code", pt.Code), slog.Any("error", err))
+		return nil // Запрос прошел, ошибку не возвращаем
+	}
+	if rowsAffected == 0 {
+		return repository.ErrProductTypeNotFound
+	}
+	return nil
+}
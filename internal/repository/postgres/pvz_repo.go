@@ -10,12 +10,28 @@ import (
 
 	// Импортируем внутренний пакет с доменными моделями
 	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
 
 	// Внешние зависимости
 	"github.com/Masterminds/squirrel" // SQL билдер
 	"github.com/google/uuid"          // Для работы с UUID
 )
 
+// pvzColumns - колонки ПВЗ в порядке, который ожидает scanPVZ
+var pvzColumns = []string{"id", "registration_date", "city", "is_active", "deactivated_at", "strict_lifo"}
+
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPVZ читает одну строку, выбранную с pvzColumns
+func scanPVZ(row rowScanner) (domain.PVZ, error) {
+	var pvz domain.PVZ
+	err := row.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City, &pvz.IsActive, &pvz.DeactivatedAt, &pvz.StrictLIFO)
+	return pvz, err
+}
+
 // PVZRepo - реализация интерфейса repository.PVZRepository для PostgreSQL.
 // Содержит методы для взаимодействия с таблицей 'pvz'.
 type PVZRepo struct {
@@ -57,7 +73,7 @@ func (r *PVZRepo) CreatePVZ(ctx context.Context, pvz domain.PVZ) (uuid.UUID, err
 	}
 
 	// Выполняем SQL запрос к базе данных
-	_, err = r.db.ExecContext(ctx, sqlQuery, args...)
+	_, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		// Используем slog для ошибки выполнения запроса
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания ПВЗ",
@@ -75,16 +91,24 @@ func (r *PVZRepo) CreatePVZ(ctx context.Context, pvz domain.PVZ) (uuid.UUID, err
 
 // ListPVZs - получает список ПВЗ из базы данных с использованием keyset pagination.
 // Принимает лимит и опциональные курсоры (дата и ID последнего элемента предыдущей страницы).
+// assignedTo ограничивает выборку ПВЗ, на которые назначен пользователь (user_pvz).
 // Возвращает срез domain.PVZ для текущей страницы и ошибку.
-func (r *PVZRepo) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID) ([]domain.PVZ, error) {
+func (r *PVZRepo) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) ([]domain.PVZ, error) {
 
 	// Базовый SELECT с сортировкой
 	queryBuilder := r.sq.
-		Select("id", "registration_date", "city").
+		Select(pvzColumns...).
 		From("pvz").
 		OrderBy("registration_date DESC", "id DESC").
 		Limit(uint64(limit))
 
+	if !includeInactive {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"is_active": true})
+	}
+	if assignedTo != nil {
+		queryBuilder = queryBuilder.Where("EXISTS (SELECT 1 FROM user_pvz up WHERE up.pvz_id = pvz.id AND up.user_id = ?)", *assignedTo)
+	}
+
 	// Добавляем условие WHERE для курсора
 	if afterRegistrationDate != nil && afterID != nil {
 		queryBuilder = queryBuilder.Where(
@@ -110,7 +134,7 @@ func (r *PVZRepo) ListPVZs(ctx context.Context, limit int, afterRegistrationDate
 	slog.DebugContext(ctx, "Выполнение SQL для списка ПВЗ", slog.String("query", sqlQuery), slog.Any("args", args)) // Используем Debug
 
 	// Выполняем запрос
-	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		// Используем slog
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для списка ПВЗ", slog.String("query", sqlQuery), slog.Any("error", err))
@@ -121,8 +145,8 @@ func (r *PVZRepo) ListPVZs(ctx context.Context, limit int, afterRegistrationDate
 	// Сканируем результаты
 	pvzList := make([]domain.PVZ, 0, limit)
 	for rows.Next() {
-		var pvz domain.PVZ
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
+		pvz, err := scanPVZ(rows)
+		if err != nil {
 			// Используем slog для ошибки сканирования
 			slog.WarnContext(ctx, "Ошибка сканирования строки ПВЗ", slog.Any("error", err)) // Warn, т.к. продолжаем
 			continue
@@ -142,21 +166,25 @@ func (r *PVZRepo) ListPVZs(ctx context.Context, limit int, afterRegistrationDate
 
 // GetAllPVZs - реализует repository.PVZRepository.
 // Используется в основном для gRPC.
-func (r *PVZRepo) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
+func (r *PVZRepo) GetAllPVZs(ctx context.Context, includeInactive bool) ([]domain.PVZ, error) {
 	// Используем Warn, т.к. этот метод может быть неэффективным
 	slog.WarnContext(ctx, "Вызов неэффективного метода GetAllPVZs")
 
-	query, args, err := r.sq.
-		Select("id", "registration_date", "city").
+	queryBuilder := r.sq.
+		Select(pvzColumns...).
 		From("pvz").
-		OrderBy("registration_date DESC").
-		ToSql()
+		OrderBy("registration_date DESC")
+	if !includeInactive {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"is_active": true})
+	}
+
+	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для GetAllPVZs", slog.Any("error", err))
 		return nil, fmt.Errorf("ошибка построения SQL для GetAllPVZs: %w", err)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для GetAllPVZs", slog.String("query", query), slog.Any("error", err))
 		return nil, fmt.Errorf("ошибка выполнения SQL для GetAllPVZs: %w", err)
@@ -165,8 +193,8 @@ func (r *PVZRepo) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
 
 	pvzList := make([]domain.PVZ, 0)
 	for rows.Next() {
-		var pvz domain.PVZ
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
+		pvz, err := scanPVZ(rows)
+		if err != nil {
 			slog.WarnContext(ctx, "Ошибка сканирования строки ПВЗ в GetAllPVZs", slog.Any("error", err))
 			continue
 		}
@@ -180,6 +208,92 @@ func (r *PVZRepo) GetAllPVZs(ctx context.Context) ([]domain.PVZ, error) {
 	return pvzList, nil
 }
 
+// GetPVZByID - возвращает ПВЗ по ID (включая деактивированные).
+// Внутри транзакции берет блокировку FOR SHARE: SetPVZActive для этого ПВЗ будет ждать ее фиксации.
+func (r *PVZRepo) GetPVZByID(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	queryBuilder := r.sq.
+		Select(pvzColumns...).
+		From("pvz").
+		Where(squirrel.Eq{"id": id})
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR SHARE")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения ПВЗ", slog.Any("pvz_id", id), slog.Any("error", err))
+		return domain.PVZ{}, fmt.Errorf("ошибка построения SQL для получения ПВЗ: %w", err)
+	}
+
+	pvz, err := scanPVZ(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.DebugContext(ctx, "ПВЗ не найден", slog.Any("pvz_id", id))
+			return domain.PVZ{}, repository.ErrPVZNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для получения ПВЗ", slog.Any("pvz_id", id), slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.PVZ{}, fmt.Errorf("ошибка выполнения SQL для получения ПВЗ: %w", err)
+	}
+
+	return pvz, nil
+}
+
+// UpdatePVZ - сохраняет изменяемые поля ПВЗ (город, строгий LIFO).
+func (r *PVZRepo) UpdatePVZ(ctx context.Context, pvz domain.PVZ) error {
+	sqlQuery, args, err := r.sq.
+		Update("pvz").
+		Set("city", pvz.City).
+		Set("strict_lifo", pvz.StrictLIFO).
+		Where(squirrel.Eq{"id": pvz.ID}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для обновления ПВЗ", slog.Any("pvz_id", pvz.ID), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для обновления ПВЗ: %w", err)
+	}
+
+	return r.execAffectingPVZ(ctx, pvz.ID, sqlQuery, args, "обновления ПВЗ")
+}
+
+// SetPVZActive - мягко деактивирует ПВЗ (is_active = false, deactivated_at = NOW()) или возвращает его в работу.
+func (r *PVZRepo) SetPVZActive(ctx context.Context, id uuid.UUID, active bool) error {
+	deactivatedAt := squirrel.Expr("NOW()")
+	if active {
+		deactivatedAt = squirrel.Expr("NULL")
+	}
+
+	sqlQuery, args, err := r.sq.
+		Update("pvz").
+		Set("is_active", active).
+		Set("deactivated_at", deactivatedAt).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для смены активности ПВЗ", slog.Any("pvz_id", id), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для смены активности ПВЗ: %w", err)
+	}
+
+	return r.execAffectingPVZ(ctx, id, sqlQuery, args, "смены активности ПВЗ")
+}
+
+// execAffectingPVZ выполняет UPDATE одного ПВЗ и возвращает ErrPVZNotFound, если строка не найдена.
+func (r *PVZRepo) execAffectingPVZ(ctx context.Context, id uuid.UUID, sqlQuery string, args []any, action string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для "+action, slog.Any("pvz_id", id), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.WarnContext(ctx, "Не удалось получить количество обновленных строк ПВЗ", slog.Any("pvz_id", id), slog.Any("error", err))
+		return nil // Запрос прошел, ошибку не возвращаем
+	}
+	if rowsAffected == 0 {
+		return repository.ErrPVZNotFound
+	}
+	return nil
+}
+
 // --- УДАЛЕНЫ ЗАГЛУШКИ МЕТОДОВ ReceptionRepository ---
 // Реализация этих методов должна находиться в internal/repository/postgres/reception_repo.go
 // в структуре ReceptionRepo
@@ -3,17 +3,106 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors" // Для проверки sql.ErrNoRows
 	"fmt"
 	"log/slog" // --- ИСПОЛЬЗУЕМ SLOG ---
+	"strings"
 	"time"
 
 	"github.com/Artem0405/pvz-service/internal/domain"
 	"github.com/Artem0405/pvz-service/internal/repository" // Для использования ErrReceptionNotFound, ErrProductNotFound
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn" // Для проверки кода ошибки PostgreSQL
 )
 
+// openReceptionIndex - частичный уникальный индекс "одна приемка in_progress на ПВЗ" (миграция 000006)
+const openReceptionIndex = "uq_receptions_pvz_in_progress"
+
+// productBarcodeIndex - уникальный индекс "штрихкод уникален в пределах приемки" (миграция 000011)
+const productBarcodeIndex = "uq_products_barcode_reception"
+
+// receptionColumns - колонки приемки в порядке, который ожидает scanReception
+var receptionColumns = []string{"id", "pvz_id", "date_time", "status", "stale_flagged_at", "created_by", "closed_by"}
+
+// scanReception читает одну строку, выбранную с receptionColumns
+func scanReception(row rowScanner) (domain.Reception, error) {
+	var (
+		rcp                 domain.Reception
+		staleFlaggedAt      sql.NullTime
+		createdBy, closedBy uuid.NullUUID
+	)
+	if err := row.Scan(&rcp.ID, &rcp.PVZID, &rcp.DateTime, &rcp.Status, &staleFlaggedAt, &createdBy, &closedBy); err != nil {
+		return domain.Reception{}, err
+	}
+	if staleFlaggedAt.Valid {
+		rcp.StaleFlaggedAt = &staleFlaggedAt.Time
+	}
+	if createdBy.Valid {
+		rcp.CreatedBy = &createdBy.UUID
+	}
+	if closedBy.Valid {
+		rcp.ClosedBy = &closedBy.UUID
+	}
+	return rcp, nil
+}
+
+// productColumns - колонки товара в порядке, который ожидает scanProduct
+var productColumns = []string{"id", "reception_id", "date_time_added", "type", "barcode", "order_id", "attributes", "deleted_at", "deleted_by", "created_by"}
+
+// productNotDeleted - условие "товар не удален мягко" для запросов к products
+var productNotDeleted = squirrel.Eq{"deleted_at": nil}
+
+// scanProduct читает одну строку, выбранную с productColumns (attributes хранится в JSONB)
+func scanProduct(row rowScanner) (domain.Product, error) {
+	var (
+		p                domain.Product
+		barcode, orderID sql.NullString
+		attributesRaw    []byte
+		deletedAt        sql.NullTime
+		deletedBy        uuid.NullUUID
+		createdBy        uuid.NullUUID
+	)
+	if err := row.Scan(&p.ID, &p.ReceptionID, &p.DateTimeAdded, &p.Type, &barcode, &orderID, &attributesRaw, &deletedAt, &deletedBy, &createdBy); err != nil {
+		return domain.Product{}, err
+	}
+	p.Barcode, p.OrderID = barcode.String, orderID.String
+	if deletedAt.Valid {
+		p.DeletedAt = &deletedAt.Time
+	}
+	if deletedBy.Valid {
+		p.DeletedBy = &deletedBy.UUID
+	}
+	if createdBy.Valid {
+		p.CreatedBy = &createdBy.UUID
+	}
+	if err := json.Unmarshal(attributesRaw, &p.Attributes); err != nil {
+		return domain.Product{}, fmt.Errorf("некорректный JSON в products.attributes: %w", err)
+	}
+	if len(p.Attributes) == 0 {
+		p.Attributes = nil // '{}' по умолчанию - товар без атрибутов
+	}
+	return p, nil
+}
+
+// productAttributesJSON сериализует атрибуты товара для колонки attributes (nil -> '{}')
+func productAttributesJSON(attrs map[string]any) ([]byte, error) {
+	if attrs == nil {
+		attrs = map[string]any{}
+	}
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации атрибутов товара: %w", err)
+	}
+	return raw, nil
+}
+
+// nullString - пустая строка сохраняется как NULL (необязательные barcode/order_id)
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // ReceptionRepo - реализация ReceptionRepository для PostgreSQL
 type ReceptionRepo struct {
 	db *sql.DB
@@ -42,16 +131,22 @@ func (r *ReceptionRepo) CreateReception(ctx context.Context, reception domain.Re
 
 	sqlQuery, args, err := r.sq.
 		Insert("receptions").
-		Columns("id", "pvz_id", "status"). // date_time по умолчанию NOW() в БД
-		Values(reception.ID, reception.PVZID, reception.Status).
+		Columns("id", "pvz_id", "status", "created_by"). // date_time по умолчанию NOW() в БД
+		Values(reception.ID, reception.PVZID, reception.Status, reception.CreatedBy).
 		ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для создания приемки", slog.Any("error", err))
 		return uuid.Nil, fmt.Errorf("ошибка построения SQL для создания приемки: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, sqlQuery, args...)
+	_, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
+		// Частичный уникальный индекс не дает открыть вторую приемку in_progress для ПВЗ
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == openReceptionIndex {
+			slog.WarnContext(ctx, "Открытая приемка для ПВЗ уже существует (уникальный индекс)", slog.Any("pvz_id", reception.PVZID))
+			return uuid.Nil, repository.ErrReceptionAlreadyOpen
+		}
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания приемки", slog.String("query", sqlQuery), slog.Any("error", err))
 		return uuid.Nil, fmt.Errorf("ошибка выполнения SQL для создания приемки: %w", err)
 	}
@@ -61,26 +156,25 @@ func (r *ReceptionRepo) CreateReception(ctx context.Context, reception domain.Re
 
 // GetLastOpenReceptionByPVZ - ищет последнюю открытую приемку для ПВЗ
 func (r *ReceptionRepo) GetLastOpenReceptionByPVZ(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error) {
-	var reception domain.Reception
-
-	sqlQuery, args, err := r.sq.
-		Select("id", "pvz_id", "date_time", "status").
+	queryBuilder := r.sq.
+		Select(receptionColumns...).
 		From("receptions").
 		Where(squirrel.Eq{"pvz_id": pvzID, "status": domain.StatusInProgress}).
 		OrderBy("date_time DESC").
-		Limit(1).
-		ToSql()
+		Limit(1)
+	// Внутри транзакции блокируем строку приемки: параллельные добавление/удаление/закрытие
+	// для того же ПВЗ будут ждать фиксации и увидят уже актуальный статус.
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска открытой приемки", slog.Any("pvz_id", pvzID), slog.Any("error", err))
 		return domain.Reception{}, fmt.Errorf("ошибка построения SQL для поиска открытой приемки: %w", err)
 	}
 
-	err = r.db.QueryRowContext(ctx, sqlQuery, args...).Scan(
-		&reception.ID,
-		&reception.PVZID,
-		&reception.DateTime,
-		&reception.Status,
-	)
+	reception, err := scanReception(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// Info или Debug, так как это ожидаемый случай "не найдено"
@@ -101,18 +195,28 @@ func (r *ReceptionRepo) AddProductToReception(ctx context.Context, product domai
 		product.ID = uuid.New()
 	}
 
+	attributes, err := productAttributesJSON(product.Attributes)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	sqlQuery, args, err := r.sq.
 		Insert("products").
-		Columns("id", "reception_id", "type"). // date_time_added по умолчанию NOW() в БД
-		Values(product.ID, product.ReceptionID, product.Type).
+		Columns("id", "reception_id", "type", "barcode", "order_id", "attributes", "created_by"). // date_time_added по умолчанию NOW() в БД
+		Values(product.ID, product.ReceptionID, product.Type, nullString(product.Barcode), nullString(product.OrderID), attributes, product.CreatedBy).
 		ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для добавления товара", slog.Any("error", err))
 		return uuid.Nil, fmt.Errorf("ошибка построения SQL для добавления товара: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, sqlQuery, args...)
+	_, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == productBarcodeIndex {
+			slog.WarnContext(ctx, "Повторный штрихкод в приемке (уникальный индекс)", slog.Any("reception_id", product.ReceptionID), slog.String("barcode", product.Barcode))
+			return uuid.Nil, repository.ErrProductBarcodeDuplicate
+		}
 		// TODO: Обработать специфические ошибки БД (например, неверный reception_id)
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для добавления товара", slog.String("query", sqlQuery), slog.Any("error", err))
 		return uuid.Nil, fmt.Errorf("ошибка выполнения SQL для добавления товара: %w", err)
@@ -121,15 +225,73 @@ func (r *ReceptionRepo) AddProductToReception(ctx context.Context, product domai
 	return product.ID, nil
 }
 
+// AddProductsToReception - добавляет товары одним многострочным INSERT.
+// date_time_added у товаров пачки совпадает (NOW() транзакции); порядок добавления задает seq,
+// который выдается строкам в порядке VALUES.
+func (r *ReceptionRepo) AddProductsToReception(ctx context.Context, products []domain.Product) ([]domain.Product, error) {
+	if len(products) == 0 {
+		return []domain.Product{}, nil
+	}
+
+	insert := r.sq.
+		Insert("products").
+		Columns("id", "reception_id", "type", "barcode", "order_id", "attributes", "created_by")
+	for _, product := range products {
+		attributes, err := productAttributesJSON(product.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		insert = insert.Values(
+			product.ID,
+			product.ReceptionID,
+			product.Type,
+			nullString(product.Barcode),
+			nullString(product.OrderID),
+			attributes,
+			product.CreatedBy,
+		)
+	}
+	sqlQuery, args, err := insert.
+		Suffix("ON CONFLICT (barcode, reception_id) WHERE barcode IS NOT NULL AND deleted_at IS NULL DO NOTHING").
+		Suffix("RETURNING " + strings.Join(productColumns, ", ")).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для пакетного добавления товаров", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для пакетного добавления товаров: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для пакетного добавления товаров", slog.Int("count", len(products)), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для пакетного добавления товаров: %w", err)
+	}
+	defer rows.Close()
+
+	added := make([]domain.Product, 0, len(products))
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка сканирования добавленного товара", slog.Any("error", err))
+			return nil, fmt.Errorf("ошибка сканирования добавленного товара: %w", err)
+		}
+		added = append(added, p)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по добавленным товарам", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по добавленным товарам: %w", err)
+	}
+
+	return added, nil
+}
+
 // GetLastProductFromReception находит последний добавленный товар в приемке
 func (r *ReceptionRepo) GetLastProductFromReception(ctx context.Context, receptionID uuid.UUID) (domain.Product, error) {
-	var product domain.Product
-
 	sqlQuery, args, err := r.sq.
-		Select("id", "reception_id", "date_time_added", "type").
+		Select(productColumns...).
 		From("products").
 		Where(squirrel.Eq{"reception_id": receptionID}).
-		OrderBy("date_time_added DESC").
+		Where(productNotDeleted).
+		OrderBy("seq DESC"). // seq монотонен, в отличие от date_time_added (NOW() начала транзакции)
 		Limit(1).
 		ToSql()
 	if err != nil {
@@ -137,12 +299,7 @@ func (r *ReceptionRepo) GetLastProductFromReception(ctx context.Context, recepti
 		return domain.Product{}, fmt.Errorf("ошибка построения SQL для поиска последнего товара: %w", err)
 	}
 
-	err = r.db.QueryRowContext(ctx, sqlQuery, args...).Scan(
-		&product.ID,
-		&product.ReceptionID,
-		&product.DateTimeAdded,
-		&product.Type, // Убедитесь, что тип domain.ProductType корректно сканируется из БД
-	)
+	product, err := scanProduct(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			slog.DebugContext(ctx, "Товары не найдены в приемке", slog.Any("reception_id", receptionID))
@@ -155,18 +312,89 @@ func (r *ReceptionRepo) GetLastProductFromReception(ctx context.Context, recepti
 	return product, nil
 }
 
-// DeleteProductByID удаляет товар по ID
-func (r *ReceptionRepo) DeleteProductByID(ctx context.Context, productID uuid.UUID) error {
+// GetProductByID возвращает не удаленный товар по ID
+func (r *ReceptionRepo) GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Product, error) {
+	sqlQuery, args, err := r.sq.
+		Select(productColumns...).
+		From("products").
+		Where(squirrel.Eq{"id": productID}).
+		Where(productNotDeleted).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения товара", slog.Any("product_id", productID), slog.Any("error", err))
+		return domain.Product{}, fmt.Errorf("ошибка построения SQL для получения товара: %w", err)
+	}
+
+	product, err := scanProduct(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.DebugContext(ctx, "Товар не найден", slog.Any("product_id", productID))
+			return domain.Product{}, repository.ErrProductNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для получения товара", slog.Any("product_id", productID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.Product{}, fmt.Errorf("ошибка выполнения SQL для получения товара: %w", err)
+	}
+
+	return product, nil
+}
+
+// RecordProductDeletion сохраняет запись аудита удаления товара
+func (r *ReceptionRepo) RecordProductDeletion(ctx context.Context, deletion domain.ProductDeletion) error {
+	if deletion.ID == uuid.Nil {
+		deletion.ID = uuid.New()
+	}
+	var deletedBy any // NULL, если ID пользователя неизвестен
+	if deletion.DeletedBy.UserID != uuid.Nil {
+		deletedBy = deletion.DeletedBy.UserID
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("product_deletions").
+		Columns("id", "product_id", "reception_id", "product_type", "barcode", "product_added_at", "deleted_by", "deleted_by_role", "reason").
+		Values(
+			deletion.ID,
+			deletion.Product.ID,
+			deletion.Product.ReceptionID,
+			deletion.Product.Type,
+			nullString(deletion.Product.Barcode),
+			deletion.Product.DateTimeAdded,
+			deletedBy,
+			deletion.DeletedBy.Role,
+			deletion.Reason,
+		).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для аудита удаления товара", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для аудита удаления товара: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для аудита удаления товара", slog.Any("product_id", deletion.Product.ID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для аудита удаления товара: %w", err)
+	}
+	return nil
+}
+
+// DeleteProductByID мягко удаляет товар по ID: строка остается, заполняются deleted_at и deleted_by
+func (r *ReceptionRepo) DeleteProductByID(ctx context.Context, productID uuid.UUID, actor domain.Actor) error {
+	var deletedBy any // NULL, если ID пользователя неизвестен
+	if actor.UserID != uuid.Nil {
+		deletedBy = actor.UserID
+	}
+
 	sqlQuery, args, err := r.sq.
-		Delete("products").
+		Update("products").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("deleted_by", deletedBy).
 		Where(squirrel.Eq{"id": productID}).
+		Where(productNotDeleted).
 		ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для удаления товара", slog.Any("product_id", productID), slog.Any("error", err))
 		return fmt.Errorf("ошибка построения SQL для удаления товара: %w", err)
 	}
 
-	result, err := r.db.ExecContext(ctx, sqlQuery, args...)
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для удаления товара", slog.Any("product_id", productID), slog.String("query", sqlQuery), slog.Any("error", err))
 		return fmt.Errorf("ошибка выполнения SQL для удаления товара: %w", err)
@@ -187,37 +415,275 @@ func (r *ReceptionRepo) DeleteProductByID(ctx context.Context, productID uuid.UU
 	return nil // Успешное удаление
 }
 
-// CloseReceptionByID изменяет статус приемки на 'closed'
-func (r *ReceptionRepo) CloseReceptionByID(ctx context.Context, receptionID uuid.UUID) error {
+// GetLastDeletedProductFromReception находит последний мягко удаленный товар в приемке
+func (r *ReceptionRepo) GetLastDeletedProductFromReception(ctx context.Context, receptionID uuid.UUID) (domain.Product, error) {
+	sqlQuery, args, err := r.sq.
+		Select(productColumns...).
+		From("products").
+		Where(squirrel.Eq{"reception_id": receptionID}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		OrderBy("deleted_at DESC", "seq DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска удаленного товара", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return domain.Product{}, fmt.Errorf("ошибка построения SQL для поиска удаленного товара: %w", err)
+	}
+
+	product, err := scanProduct(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.DebugContext(ctx, "Удаленные товары не найдены в приемке", slog.Any("reception_id", receptionID))
+			return domain.Product{}, repository.ErrProductNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для поиска удаленного товара", slog.Any("reception_id", receptionID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.Product{}, fmt.Errorf("ошибка выполнения SQL для поиска удаленного товара: %w", err)
+	}
+
+	return product, nil
+}
+
+// RestoreProduct снимает отметку мягкого удаления с товара
+func (r *ReceptionRepo) RestoreProduct(ctx context.Context, productID uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Update("products").
+		Set("deleted_at", nil).
+		Set("deleted_by", nil).
+		Where(squirrel.Eq{"id": productID}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для восстановления товара", slog.Any("product_id", productID), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для восстановления товара: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == productBarcodeIndex {
+			// После удаления тот же штрихкод уже отсканировали заново
+			slog.WarnContext(ctx, "Восстановление товара нарушает уникальность штрихкода", slog.Any("product_id", productID))
+			return repository.ErrProductBarcodeDuplicate
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для восстановления товара", slog.Any("product_id", productID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для восстановления товара: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.WarnContext(ctx, "Не удалось получить количество восстановленных строк", slog.Any("product_id", productID), slog.Any("error", err))
+		return nil
+	}
+	if rowsAffected == 0 {
+		return repository.ErrProductNotFound
+	}
+	return nil
+}
+
+// UpdateReceptionStatus меняет статус приемки from -> to (compare-and-set по текущему статусу)
+func (r *ReceptionRepo) UpdateReceptionStatus(ctx context.Context, receptionID uuid.UUID, from, to domain.ReceptionStatus, actor domain.Actor) error {
+	// closed_by - кто завершил приемку (закрыл или отменил); при возврате в работу очищается
+	var closedBy *uuid.UUID
+	if to != domain.StatusInProgress {
+		closedBy = actor.KnownUserID()
+	}
 	sqlQuery, args, err := r.sq.
 		Update("receptions").
-		Set("status", domain.StatusClosed).
-		Where(squirrel.Eq{"id": receptionID, "status": domain.StatusInProgress}).
+		Set("status", to).
+		Set("closed_by", closedBy).
+		Where(squirrel.Eq{"id": receptionID, "status": from}).
 		ToSql()
 	if err != nil {
-		slog.ErrorContext(ctx, "Ошибка построения SQL для закрытия приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
-		return fmt.Errorf("ошибка построения SQL для закрытия приемки: %w", err)
+		slog.ErrorContext(ctx, "Ошибка построения SQL для смены статуса приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для смены статуса приемки: %w", err)
 	}
 
-	result, err := r.db.ExecContext(ctx, sqlQuery, args...)
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		slog.ErrorContext(ctx, "Ошибка выполнения SQL для закрытия приемки", slog.Any("reception_id", receptionID), slog.String("query", sqlQuery), slog.Any("error", err))
-		return fmt.Errorf("ошибка выполнения SQL для закрытия приемки: %w", err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == openReceptionIndex {
+			// Переоткрытие, когда у ПВЗ уже есть другая открытая приемка
+			slog.WarnContext(ctx, "Смена статуса приемки нарушает уникальность открытой приемки", slog.Any("reception_id", receptionID))
+			return repository.ErrReceptionAlreadyOpen
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для смены статуса приемки", slog.Any("reception_id", receptionID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для смены статуса приемки: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		slog.WarnContext(ctx, "Не удалось получить количество обновленных строк при закрытии приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		slog.WarnContext(ctx, "Не удалось получить количество обновленных строк при смене статуса приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
 		return nil // Запрос прошел, ошибку не возвращаем
 	}
 
 	if rowsAffected == 0 {
-		slog.WarnContext(ctx, "Попытка закрыть не найденную или уже закрытую приемку", slog.Any("reception_id", receptionID))
+		slog.WarnContext(ctx, "Приемка не найдена или ее статус уже изменен", slog.Any("reception_id", receptionID), slog.Any("from", from))
 		return repository.ErrReceptionNotFound // Используем ошибку "не найдено"
 	}
 
-	slog.InfoContext(ctx, "Приемка успешно закрыта", slog.Any("reception_id", receptionID))
-	return nil // Успешное закрытие
+	slog.InfoContext(ctx, "Статус приемки изменен", slog.Any("reception_id", receptionID), slog.Any("from", from), slog.Any("to", to))
+	return nil
+}
+
+// HasNewerReception проверяет, есть ли у ПВЗ приемка, начатая позже указанной (в любом статусе)
+func (r *ReceptionRepo) HasNewerReception(ctx context.Context, reception domain.Reception) (bool, error) {
+	sqlQuery, args, err := r.sq.
+		Select("1").
+		From("receptions").
+		Where(squirrel.Eq{"pvz_id": reception.PVZID}).
+		Where(squirrel.NotEq{"id": reception.ID}).
+		Where(squirrel.Gt{"date_time": reception.DateTime}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска более новой приемки", slog.Any("reception_id", reception.ID), slog.Any("error", err))
+		return false, fmt.Errorf("ошибка построения SQL для поиска более новой приемки: %w", err)
+	}
+
+	var exists bool
+	if err := conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).Scan(&exists); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для поиска более новой приемки", slog.Any("reception_id", reception.ID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return false, fmt.Errorf("ошибка выполнения SQL для поиска более новой приемки: %w", err)
+	}
+	return exists, nil
+}
+
+// staleReceptionLockNamespace - первый ключ pg_try_advisory_xact_lock для блокировок обработчика
+// зависших приемок, чтобы они не пересекались с другими advisory-блокировками по hashtext.
+const staleReceptionLockNamespace = 17
+
+// ListStaleReceptions возвращает открытые приемки, подходящие под filter (от старых к новым)
+func (r *ReceptionRepo) ListStaleReceptions(ctx context.Context, filter domain.StaleReceptionFilter) ([]domain.Reception, error) {
+	queryBuilder := r.sq.
+		Select(receptionColumns...).
+		From("receptions").
+		Where(squirrel.Eq{"status": domain.StatusInProgress}).
+		OrderBy("date_time ASC", "id ASC").
+		Limit(uint64(filter.Limit))
+
+	conditions := squirrel.Or{}
+	if filter.OpenedBefore != nil {
+		conditions = append(conditions, squirrel.Lt{"date_time": *filter.OpenedBefore})
+	}
+	if filter.IdleBefore != nil {
+		// Время последней активности - последний не удаленный товар, а если товаров нет - начало приемки
+		conditions = append(conditions, squirrel.Expr(
+			"COALESCE((SELECT MAX(p.date_time_added) FROM products p WHERE p.reception_id = receptions.id AND p.deleted_at IS NULL), receptions.date_time) < ?",
+			*filter.IdleBefore,
+		))
+	}
+	if len(conditions) == 0 {
+		return nil, errors.New("для поиска зависших приемок нужно задать OpenedBefore и/или IdleBefore")
+	}
+	queryBuilder = queryBuilder.Where(conditions)
+	if filter.SkipFlagged {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"stale_flagged_at": nil})
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска зависших приемок", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для поиска зависших приемок: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Выполнение SQL для поиска зависших приемок", slog.String("query", sqlQuery), slog.Any("args", args))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для поиска зависших приемок", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для поиска зависших приемок: %w", err)
+	}
+	defer rows.Close()
+
+	receptions := make([]domain.Reception, 0, filter.Limit)
+	for rows.Next() {
+		rcp, err := scanReception(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка сканирования строки приемки", slog.Any("error", err))
+			return nil, fmt.Errorf("ошибка сканирования строки приемки: %w", err)
+		}
+		receptions = append(receptions, rcp)
+	}
+	if err = rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по зависшим приемкам", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по зависшим приемкам: %w", err)
+	}
+
+	return receptions, nil
+}
+
+// TryLockReception берет advisory-блокировку приемки до конца текущей транзакции (без ожидания)
+func (r *ReceptionRepo) TryLockReception(ctx context.Context, receptionID uuid.UUID) (bool, error) {
+	tx, ok := txFromContext(ctx)
+	if !ok {
+		// Вне транзакции xact-блокировка снялась бы сразу после запроса
+		return false, errors.New("TryLockReception нужно вызывать внутри транзакции")
+	}
+
+	var locked bool
+	err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1, hashtext($2))", staleReceptionLockNamespace, receptionID.String()).Scan(&locked)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка взятия advisory-блокировки приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return false, fmt.Errorf("ошибка взятия advisory-блокировки приемки: %w", err)
+	}
+	return locked, nil
+}
+
+// FlagReceptionStale помечает открытую приемку как зависшую (stale_flagged_at = NOW())
+func (r *ReceptionRepo) FlagReceptionStale(ctx context.Context, receptionID uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Update("receptions").
+		Set("stale_flagged_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": receptionID, "status": domain.StatusInProgress}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для пометки зависшей приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для пометки зависшей приемки: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для пометки зависшей приемки", slog.Any("reception_id", receptionID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для пометки зависшей приемки: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения количества затронутых строк при пометке приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return fmt.Errorf("ошибка получения количества затронутых строк при пометке приемки: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrReceptionNotFound
+	}
+	return nil
+}
+
+// RecordReceptionAudit сохраняет запись аудита действия над приемкой
+func (r *ReceptionRepo) RecordReceptionAudit(ctx context.Context, entry domain.ReceptionAuditEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	var actorID any // NULL для системного актора
+	if entry.Actor.UserID != uuid.Nil {
+		actorID = entry.Actor.UserID
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("reception_audit").
+		Columns("id", "reception_id", "action", "actor_id", "actor_role", "reason").
+		Values(entry.ID, entry.ReceptionID, entry.Action, actorID, entry.Actor.Role, entry.Reason).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для аудита приемки", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для аудита приемки: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для аудита приемки", slog.Any("reception_id", entry.ReceptionID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для аудита приемки: %w", err)
+	}
+	return nil
 }
 
 // ListReceptionsByPVZIDs возвращает приемки для списка ПВЗ с фильтром по дате
@@ -227,7 +693,7 @@ func (r *ReceptionRepo) ListReceptionsByPVZIDs(ctx context.Context, pvzIDs []uui
 	}
 
 	queryBuilder := r.sq.
-		Select("id", "pvz_id", "date_time", "status").
+		Select(receptionColumns...).
 		From("receptions").
 		Where(squirrel.Eq{"pvz_id": pvzIDs}).
 		OrderBy("pvz_id, date_time DESC")
@@ -245,7 +711,7 @@ func (r *ReceptionRepo) ListReceptionsByPVZIDs(ctx context.Context, pvzIDs []uui
 		return nil, fmt.Errorf("ошибка построения SQL для получения списка приемок: %w", err)
 	}
 
-	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для получения списка приемок", slog.String("query", sqlQuery), slog.Any("error", err))
 		return nil, fmt.Errorf("ошибка выполнения SQL для получения списка приемок: %w", err)
@@ -254,8 +720,8 @@ func (r *ReceptionRepo) ListReceptionsByPVZIDs(ctx context.Context, pvzIDs []uui
 
 	receptions := make([]domain.Reception, 0) // Инициализируем пустой слайс
 	for rows.Next() {
-		var rcp domain.Reception
-		if err := rows.Scan(&rcp.ID, &rcp.PVZID, &rcp.DateTime, &rcp.Status); err != nil {
+		rcp, err := scanReception(rows)
+		if err != nil {
 			slog.WarnContext(ctx, "Ошибка сканирования строки приемки", slog.Any("error", err))
 			continue
 		}
@@ -269,24 +735,28 @@ func (r *ReceptionRepo) ListReceptionsByPVZIDs(ctx context.Context, pvzIDs []uui
 	return receptions, nil
 }
 
-// ListProductsByReceptionIDs возвращает товары для списка приемок
-func (r *ReceptionRepo) ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]domain.Product, error) {
+// ListProductsByReceptionIDs возвращает товары для списка приемок (мягко удаленные - только при includeDeleted)
+func (r *ReceptionRepo) ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID, includeDeleted bool) ([]domain.Product, error) {
 	if len(receptionIDs) == 0 {
 		return []domain.Product{}, nil
 	}
 
-	sqlQuery, args, err := r.sq.
-		Select("id", "reception_id", "date_time_added", "type").
+	queryBuilder := r.sq.
+		Select(productColumns...).
 		From("products").
 		Where(squirrel.Eq{"reception_id": receptionIDs}).
-		OrderBy("reception_id, date_time_added ASC").
-		ToSql()
+		OrderBy("reception_id, seq ASC")
+	if !includeDeleted {
+		queryBuilder = queryBuilder.Where(productNotDeleted)
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка построения SQL для получения списка товаров", slog.Any("error", err))
 		return nil, fmt.Errorf("ошибка построения SQL для получения списка товаров: %w", err)
 	}
 
-	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Ошибка выполнения SQL для получения списка товаров", slog.String("query", sqlQuery), slog.Any("error", err))
 		return nil, fmt.Errorf("ошибка выполнения SQL для получения списка товаров: %w", err)
@@ -295,8 +765,8 @@ func (r *ReceptionRepo) ListProductsByReceptionIDs(ctx context.Context, receptio
 
 	products := make([]domain.Product, 0) // Инициализируем пустой слайс
 	for rows.Next() {
-		var p domain.Product
-		if err := rows.Scan(&p.ID, &p.ReceptionID, &p.DateTimeAdded, &p.Type); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			slog.WarnContext(ctx, "Ошибка сканирования строки товара", slog.Any("error", err))
 			continue
 		}
@@ -310,5 +780,147 @@ func (r *ReceptionRepo) ListProductsByReceptionIDs(ctx context.Context, receptio
 	return products, nil
 }
 
+// GetReceptionByID возвращает приемку по ID (любой статус)
+func (r *ReceptionRepo) GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (domain.Reception, error) {
+	queryBuilder := r.sq.
+		Select(receptionColumns...).
+		From("receptions").
+		Where(squirrel.Eq{"id": receptionID})
+	// Внутри транзакции блокируем приемку, как и GetLastOpenReceptionByPVZ
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для получения приемки", slog.Any("reception_id", receptionID), slog.Any("error", err))
+		return domain.Reception{}, fmt.Errorf("ошибка построения SQL для получения приемки: %w", err)
+	}
+
+	reception, err := scanReception(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.DebugContext(ctx, "Приемка не найдена", slog.Any("reception_id", receptionID))
+			return domain.Reception{}, repository.ErrReceptionNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения/сканирования SQL для получения приемки", slog.Any("reception_id", receptionID), slog.String("query", sqlQuery), slog.Any("error", err))
+		return domain.Reception{}, fmt.Errorf("ошибка выполнения SQL для получения приемки: %w", err)
+	}
+
+	return reception, nil
+}
+
+// ListReceptionsByPVZ возвращает страницу истории приемок ПВЗ (keyset по date_time DESC, id DESC)
+func (r *ReceptionRepo) ListReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionFilter) ([]domain.Reception, error) {
+	queryBuilder := r.sq.
+		Select(receptionColumns...).
+		From("receptions").
+		Where(squirrel.Eq{"pvz_id": pvzID}).
+		OrderBy("date_time DESC", "id DESC").
+		Limit(uint64(filter.Limit))
+
+	if filter.Status != nil {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"status": *filter.Status})
+	}
+	if filter.StartDate != nil {
+		queryBuilder = queryBuilder.Where(squirrel.GtOrEq{"date_time": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		queryBuilder = queryBuilder.Where(squirrel.LtOrEq{"date_time": *filter.EndDate})
+	}
+
+	// Условие курсора - так же, как в PVZRepo.ListPVZs
+	if filter.AfterDateTime != nil && filter.AfterID != nil {
+		queryBuilder = queryBuilder.Where(
+			squirrel.Or{
+				squirrel.Lt{"date_time": *filter.AfterDateTime},
+				squirrel.And{
+					squirrel.Eq{"date_time": *filter.AfterDateTime},
+					squirrel.Lt{"id": *filter.AfterID},
+				},
+			},
+		)
+	} else if filter.AfterDateTime != nil || filter.AfterID != nil {
+		return nil, errors.New("для keyset pagination необходимо передавать оба параметра курсора (after_date_time и after_id) или ни одного")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для истории приемок", slog.Any("pvz_id", pvzID), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для истории приемок: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Выполнение SQL для истории приемок", slog.String("query", sqlQuery), slog.Any("args", args))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для истории приемок", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для истории приемок: %w", err)
+	}
+	defer rows.Close()
+
+	receptions := make([]domain.Reception, 0, filter.Limit)
+	for rows.Next() {
+		rcp, err := scanReception(rows)
+		if err != nil {
+			// В отличие от ListReceptionsByPVZIDs не пропускаем строку: пропуск сломал бы курсор
+			slog.ErrorContext(ctx, "Ошибка сканирования строки приемки", slog.Any("error", err))
+			return nil, fmt.Errorf("ошибка сканирования строки приемки: %w", err)
+		}
+		receptions = append(receptions, rcp)
+	}
+	if err = rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по истории приемок", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по истории приемок: %w", err)
+	}
+
+	return receptions, nil
+}
+
 // --- УДАЛЕНЫ ЗАГЛУШКИ МЕТОДОВ PVZRepository ---
 // Реализация этих методов должна находиться в internal/repository/postgres/pvz_repo.go
+
+// ListProductsByBarcode возвращает товары с указанным штрихкодом (от новых к старым), при pvzIDs != nil -
+// только в приемках этих ПВЗ
+func (r *ReceptionRepo) ListProductsByBarcode(ctx context.Context, barcode string, pvzIDs []uuid.UUID, limit uint64) ([]domain.Product, error) {
+	queryBuilder := r.sq.
+		Select(productColumns...).
+		From("products").
+		Where(squirrel.Eq{"barcode": barcode}).
+		Where(productNotDeleted).
+		OrderBy("date_time_added DESC", "id DESC").
+		Limit(limit)
+	if pvzIDs != nil {
+		// Подзапрос без PlaceholderFormat: плейсхолдеры нумерует внешний запрос
+		receptionsOfPVZs := squirrel.Select("id").From("receptions").Where(squirrel.Eq{"pvz_id": pvzIDs})
+		queryBuilder = queryBuilder.Where(squirrel.Expr("reception_id IN (?)", receptionsOfPVZs))
+	}
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска товаров по штрихкоду", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для поиска товаров по штрихкоду: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для поиска товаров по штрихкоду", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для поиска товаров по штрихкоду: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0)
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка сканирования строки товара", slog.Any("error", err))
+			return nil, fmt.Errorf("ошибка сканирования товара: %w", err)
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по товарам", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка итерации по товарам: %w", err)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// refreshTokenColumns - колонки refresh-токена в порядке, который ожидает scanRefreshToken
+var refreshTokenColumns = []string{"id", "user_id", "family_id", "token_hash", "access_jti", "access_expires_at", "expires_at", "created_at", "used_at", "revoked_at"}
+
+// scanRefreshToken читает одну строку, выбранную с refreshTokenColumns
+func scanRefreshToken(row rowScanner) (domain.RefreshToken, error) {
+	var (
+		t                 domain.RefreshToken
+		usedAt, revokedAt sql.NullTime
+	)
+	if err := row.Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.AccessJTI, &t.AccessExpiresAt, &t.ExpiresAt, &t.CreatedAt, &usedAt, &revokedAt); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return t, nil
+}
+
+// TokenRepo - реализация repository.TokenRepository для PostgreSQL
+type TokenRepo struct {
+	db *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewTokenRepo - конструктор для TokenRepo
+func NewTokenRepo(db *sql.DB) *TokenRepo {
+	return &TokenRepo{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateRefreshToken сохраняет refresh-токен
+func (r *TokenRepo) CreateRefreshToken(ctx context.Context, token domain.RefreshToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("refresh_tokens").
+		Columns("id", "user_id", "family_id", "token_hash", "access_jti", "access_expires_at", "expires_at").
+		Values(token.ID, token.UserID, token.FamilyID, token.TokenHash, token.AccessJTI, token.AccessExpiresAt, token.ExpiresAt).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для сохранения refresh-токена", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для сохранения refresh-токена: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для сохранения refresh-токена", slog.Any("user_id", token.UserID), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для сохранения refresh-токена: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash ищет refresh-токен по хешу
+func (r *TokenRepo) GetRefreshTokenByHash(ctx context.Context, hash []byte) (domain.RefreshToken, error) {
+	queryBuilder := r.sq.
+		Select(refreshTokenColumns...).
+		From("refresh_tokens").
+		Where(squirrel.Eq{"token_hash": hash})
+	// Внутри транзакции блокируем токен: два параллельных обмена одного токена не пройдут оба
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для поиска refresh-токена", slog.Any("error", err))
+		return domain.RefreshToken{}, fmt.Errorf("ошибка построения SQL для поиска refresh-токена: %w", err)
+	}
+
+	token, err := scanRefreshToken(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RefreshToken{}, repository.ErrRefreshTokenNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для поиска refresh-токена", slog.Any("error", err))
+		return domain.RefreshToken{}, fmt.Errorf("ошибка выполнения SQL для поиска refresh-токена: %w", err)
+	}
+	return token, nil
+}
+
+// MarkRefreshTokenUsed отмечает, что токен обменян на новую пару
+func (r *TokenRepo) MarkRefreshTokenUsed(ctx context.Context, id uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Update("refresh_tokens").
+		Set("used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "used_at": nil, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для отметки refresh-токена", slog.Any("token_id", id), slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для отметки refresh-токена: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для отметки refresh-токена", slog.Any("token_id", id), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для отметки refresh-токена: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк при отметке refresh-токена: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRefreshTokenNotFound
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily отзывает не отозванные токены семейства и возвращает парные access-токены
+func (r *TokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) ([]domain.RevokedToken, error) {
+	return r.revokeRefreshTokens(ctx, squirrel.Eq{"family_id": familyID, "revoked_at": nil}, "семейства refresh-токенов")
+}
+
+// RevokeUserRefreshTokens отзывает все не отозванные refresh-токены пользователя и возвращает парные access-токены
+func (r *TokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) ([]domain.RevokedToken, error) {
+	return r.revokeRefreshTokens(ctx, squirrel.Eq{"user_id": userID, "revoked_at": nil}, "refresh-токенов пользователя")
+}
+
+// revokeRefreshTokens - общая часть отзыва: UPDATE ... RETURNING access_jti, access_expires_at
+func (r *TokenRepo) revokeRefreshTokens(ctx context.Context, where squirrel.Eq, what string) ([]domain.RevokedToken, error) {
+	sqlQuery, args, err := r.sq.
+		Update("refresh_tokens").
+		Set("revoked_at", squirrel.Expr("NOW()")).
+		Where(where).
+		Suffix("RETURNING access_jti, access_expires_at").
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для отзыва "+what, slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для отзыва %s: %w", what, err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для отзыва "+what, slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для отзыва %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	return scanRevokedTokens(rows)
+}
+
+// RevokeAccessTokens добавляет access-токены в denylist
+func (r *TokenRepo) RevokeAccessTokens(ctx context.Context, tokens []domain.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	insert := r.sq.
+		Insert("revoked_access_tokens").
+		Columns("jti", "expires_at")
+	for _, t := range tokens {
+		insert = insert.Values(t.JTI, t.ExpiresAt)
+	}
+	sqlQuery, args, err := insert.Suffix("ON CONFLICT (jti) DO NOTHING").ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для отзыва access-токенов", slog.Any("error", err))
+		return fmt.Errorf("ошибка построения SQL для отзыва access-токенов: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для отзыва access-токенов", slog.Int("count", len(tokens)), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для отзыва access-токенов: %w", err)
+	}
+	return nil
+}
+
+// ListRevokedAccessTokens возвращает записи denylist, отозванные после since
+func (r *TokenRepo) ListRevokedAccessTokens(ctx context.Context, since time.Time) ([]domain.RevokedToken, time.Time, error) {
+	sqlQuery, args, err := r.sq.
+		Select("jti", "expires_at", "revoked_at").
+		From("revoked_access_tokens").
+		Where(squirrel.Gt{"revoked_at": since}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для загрузки denylist", slog.Any("error", err))
+		return nil, since, fmt.Errorf("ошибка построения SQL для загрузки denylist: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для загрузки denylist", slog.Any("error", err))
+		return nil, since, fmt.Errorf("ошибка выполнения SQL для загрузки denylist: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		tokens []domain.RevokedToken
+		latest = since
+	)
+	for rows.Next() {
+		var (
+			t         domain.RevokedToken
+			revokedAt time.Time
+		)
+		if err := rows.Scan(&t.JTI, &t.ExpiresAt, &revokedAt); err != nil {
+			slog.ErrorContext(ctx, "Ошибка сканирования записи denylist", slog.Any("error", err))
+			return nil, since, fmt.Errorf("ошибка сканирования записи denylist: %w", err)
+		}
+		tokens = append(tokens, t)
+		if revokedAt.After(latest) {
+			latest = revokedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка итерации по denylist", slog.Any("error", err))
+		return nil, since, fmt.Errorf("ошибка итерации по denylist: %w", err)
+	}
+	return tokens, latest, nil
+}
+
+// scanRevokedTokens читает строки (access_jti, access_expires_at)
+func scanRevokedTokens(rows *sql.Rows) ([]domain.RevokedToken, error) {
+	var tokens []domain.RevokedToken
+	for rows.Next() {
+		var t domain.RevokedToken
+		if err := rows.Scan(&t.JTI, &t.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования отозванного токена: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по отозванным токенам: %w", err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+)
+
+// txContextKey - ключ, под которым открытая транзакция лежит в контексте.
+type txContextKey struct{}
+
+// querier - общий набор методов *sql.DB и *sql.Tx, который используют репозитории.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn возвращает транзакцию из контекста, если она есть, иначе пул соединений.
+// Благодаря этому репозитории не знают, вызываются ли они внутри WithinTransaction.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// txFromContext достает транзакцию, положенную в контекст Transactor'ом.
+func txFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+// Transactor - реализация repository.Transactor для PostgreSQL
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor - конструктор для Transactor
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction открывает транзакцию, кладет ее в контекст и вызывает fn.
+// Если fn вернула ошибку или запаниковала - транзакция откатывается, иначе фиксируется.
+// Вложенный вызов переиспользует уже открытую транзакцию.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Не удалось начать транзакцию", slog.Any("error", err))
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			slog.ErrorContext(ctx, "Ошибка отката транзакции", slog.Any("error", rbErr))
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "Ошибка фиксации транзакции", slog.Any("error", err))
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors" // Для errors.Is
 	"fmt"
+	"log/slog"
 	"strings" // Для проверки ошибки unique_violation
 
 	"github.com/Masterminds/squirrel"
@@ -15,6 +16,24 @@ import (
 	"github.com/Artem0405/pvz-service/internal/repository" // Для кастомных ошибок
 )
 
+// userColumns - колонки пользователя в порядке, который ожидает scanUser
+var userColumns = []string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}
+
+// scanUser читает одну строку, выбранную с userColumns
+func scanUser(row rowScanner) (domain.User, error) {
+	var (
+		user       domain.User
+		disabledAt sql.NullTime
+	)
+	if err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &disabledAt); err != nil {
+		return domain.User{}, err
+	}
+	if disabledAt.Valid {
+		user.DisabledAt = &disabledAt.Time
+	}
+	return user, nil
+}
+
 type UserRepo struct {
 	db *sql.DB
 	sq squirrel.StatementBuilderType
@@ -42,7 +61,7 @@ func (r *UserRepo) CreateUser(ctx context.Context, user domain.User) (uuid.UUID,
 		return uuid.Nil, fmt.Errorf("ошибка построения SQL для создания пользователя: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, sqlQuery, args...)
+	_, err = conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		// Проверяем ошибку уникальности email (специфично для PostgreSQL)
 		var pgErr *pgconn.PgError
@@ -60,25 +79,331 @@ func (r *UserRepo) CreateUser(ctx context.Context, user domain.User) (uuid.UUID,
 
 // GetUserByEmail ищет пользователя по email
 func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
-	var user domain.User
 	sqlQuery, args, err := r.sq.
-		Select("id", "email", "password_hash", "role").
+		Select(userColumns...).
 		From("users").
 		Where(squirrel.Eq{"email": email}).
 		Limit(1).
 		ToSql()
 	if err != nil {
-		return user, fmt.Errorf("ошибка построения SQL для поиска пользователя по email: %w", err)
+		return domain.User{}, fmt.Errorf("ошибка построения SQL для поиска пользователя по email: %w", err)
 	}
 
-	row := r.db.QueryRowContext(ctx, sqlQuery, args...)
-	err = row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role)
+	user, err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return user, repository.ErrUserNotFound // Используем кастомную ошибку
+			return domain.User{}, repository.ErrUserNotFound // Используем кастомную ошибку
 		}
-		return user, fmt.Errorf("ошибка сканирования данных пользователя по email: %w", err)
+		return domain.User{}, fmt.Errorf("ошибка сканирования данных пользователя по email: %w", err)
 	}
 
 	return user, nil
 }
+
+// GetUserByID ищет пользователя по ID (внутри транзакции - с блокировкой строки)
+func (r *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	queryBuilder := r.sq.
+		Select(userColumns...).
+		From("users").
+		Where(squirrel.Eq{"id": id})
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return domain.User{}, fmt.Errorf("ошибка построения SQL для поиска пользователя по ID: %w", err)
+	}
+
+	user, err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, repository.ErrUserNotFound
+		}
+		return domain.User{}, fmt.Errorf("ошибка сканирования данных пользователя по ID: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListUsers возвращает страницу пользователей в порядке регистрации
+func (r *UserRepo) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	queryBuilder := r.sq.
+		Select(userColumns...).
+		From("users").
+		OrderBy("created_at", "id").
+		Limit(uint64(filter.Limit))
+
+	if filter.Role != nil {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"role": *filter.Role})
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			queryBuilder = queryBuilder.Where(squirrel.NotEq{"disabled_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(squirrel.Eq{"disabled_at": nil})
+		}
+	}
+
+	// Условие курсора - как в ReceptionRepo.ListReceptionsByPVZ, но по возрастанию
+	if filter.AfterCreatedAt != nil && filter.AfterID != nil {
+		queryBuilder = queryBuilder.Where(
+			squirrel.Or{
+				squirrel.Gt{"created_at": *filter.AfterCreatedAt},
+				squirrel.And{
+					squirrel.Eq{"created_at": *filter.AfterCreatedAt},
+					squirrel.Gt{"id": *filter.AfterID},
+				},
+			},
+		)
+	} else if filter.AfterCreatedAt != nil || filter.AfterID != nil {
+		return nil, errors.New("для keyset pagination необходимо передавать оба параметра курсора (after_created_at и after_id) или ни одного")
+	}
+
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка построения SQL для списка пользователей", slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка построения SQL для списка пользователей: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для списка пользователей", slog.String("query", sqlQuery), slog.Any("error", err))
+		return nil, fmt.Errorf("ошибка выполнения SQL для списка пользователей: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования пользователя: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по пользователям: %w", err)
+	}
+	return users, nil
+}
+
+// SetUserDisabled отключает или включает учетную запись
+func (r *UserRepo) SetUserDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	disabledAt := squirrel.Expr("NULL")
+	if disabled {
+		disabledAt = squirrel.Expr("NOW()")
+	}
+
+	sqlQuery, args, err := r.sq.
+		Update("users").
+		Set("disabled_at", disabledAt).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для отключения пользователя: %w", err)
+	}
+	return r.execAffectingUser(ctx, id, sqlQuery, args, "отключения пользователя")
+}
+
+// UpdateUserRole меняет роль пользователя
+func (r *UserRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	sqlQuery, args, err := r.sq.
+		Update("users").
+		Set("role", role).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для смены роли пользователя: %w", err)
+	}
+	return r.execAffectingUser(ctx, id, sqlQuery, args, "смены роли пользователя")
+}
+
+// execAffectingUser выполняет UPDATE одного пользователя и возвращает ErrUserNotFound, если строка не найдена.
+func (r *UserRepo) execAffectingUser(ctx context.Context, id uuid.UUID, sqlQuery string, args []any, action string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для "+action, slog.Any("user_id", id), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для %s: %w", action, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк для %s: %w", action, err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+// CreateInvite сохраняет приглашение на регистрацию
+func (r *UserRepo) CreateInvite(ctx context.Context, invite domain.UserInvite) error {
+	if invite.ID == uuid.Nil {
+		invite.ID = uuid.New()
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("user_invites").
+		Columns("id", "token_hash", "role", "email", "created_by", "expires_at").
+		Values(invite.ID, invite.TokenHash, invite.Role, sql.NullString{String: invite.Email, Valid: invite.Email != ""}, invite.CreatedBy, invite.ExpiresAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для создания приглашения: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания приглашения", slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для создания приглашения: %w", err)
+	}
+	return nil
+}
+
+// GetInviteByHash ищет приглашение по хешу токена (внутри транзакции - с блокировкой строки)
+func (r *UserRepo) GetInviteByHash(ctx context.Context, tokenHash []byte) (domain.UserInvite, error) {
+	queryBuilder := r.sq.
+		Select("id", "token_hash", "role", "email", "created_by", "created_at", "expires_at", "used_at", "used_by").
+		From("user_invites").
+		Where(squirrel.Eq{"token_hash": tokenHash})
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return domain.UserInvite{}, fmt.Errorf("ошибка построения SQL для поиска приглашения: %w", err)
+	}
+
+	var (
+		invite            domain.UserInvite
+		email             sql.NullString
+		usedAt            sql.NullTime
+		createdBy, usedBy uuid.NullUUID
+	)
+	err = conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).
+		Scan(&invite.ID, &invite.TokenHash, &invite.Role, &email, &createdBy, &invite.CreatedAt, &invite.ExpiresAt, &usedAt, &usedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.UserInvite{}, repository.ErrInviteNotFound
+		}
+		return domain.UserInvite{}, fmt.Errorf("ошибка сканирования приглашения: %w", err)
+	}
+	invite.Email = email.String
+	if createdBy.Valid {
+		invite.CreatedBy = &createdBy.UUID
+	}
+	if usedAt.Valid {
+		invite.UsedAt = &usedAt.Time
+	}
+	if usedBy.Valid {
+		invite.UsedBy = &usedBy.UUID
+	}
+	return invite, nil
+}
+
+// MarkInviteUsed помечает приглашение использованным, если оно еще не использовано
+func (r *UserRepo) MarkInviteUsed(ctx context.Context, id, userID uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Update("user_invites").
+		Set("used_at", squirrel.Expr("NOW()")).
+		Set("used_by", userID).
+		Where(squirrel.Eq{"id": id, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для использования приглашения: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения SQL для использования приглашения: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества обновленных строк приглашения: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrInviteNotFound
+	}
+	return nil
+}
+
+// UpdateUserPassword заменяет хеш пароля пользователя
+func (r *UserRepo) UpdateUserPassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	sqlQuery, args, err := r.sq.
+		Update("users").
+		Set("password_hash", passwordHash).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для смены пароля: %w", err)
+	}
+	return r.execAffectingUser(ctx, id, sqlQuery, args, "смены пароля")
+}
+
+// CreatePasswordResetToken сохраняет токен сброса пароля
+func (r *UserRepo) CreatePasswordResetToken(ctx context.Context, token domain.PasswordResetToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	sqlQuery, args, err := r.sq.
+		Insert("password_reset_tokens").
+		Columns("id", "user_id", "token_hash", "expires_at").
+		Values(token.ID, token.UserID, token.TokenHash, token.ExpiresAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для создания токена сброса пароля: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для создания токена сброса пароля", slog.Any("user_id", token.UserID), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для создания токена сброса пароля: %w", err)
+	}
+	return nil
+}
+
+// GetPasswordResetTokenByHash ищет токен сброса пароля по хешу (внутри транзакции - с блокировкой строки)
+func (r *UserRepo) GetPasswordResetTokenByHash(ctx context.Context, tokenHash []byte) (domain.PasswordResetToken, error) {
+	queryBuilder := r.sq.
+		Select("id", "user_id", "token_hash", "created_at", "expires_at", "used_at").
+		From("password_reset_tokens").
+		Where(squirrel.Eq{"token_hash": tokenHash})
+	if _, ok := txFromContext(ctx); ok {
+		queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+	}
+	sqlQuery, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return domain.PasswordResetToken{}, fmt.Errorf("ошибка построения SQL для поиска токена сброса пароля: %w", err)
+	}
+
+	var (
+		token  domain.PasswordResetToken
+		usedAt sql.NullTime
+	)
+	err = conn(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.CreatedAt, &token.ExpiresAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PasswordResetToken{}, repository.ErrPasswordResetTokenNotFound
+		}
+		return domain.PasswordResetToken{}, fmt.Errorf("ошибка сканирования токена сброса пароля: %w", err)
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return token, nil
+}
+
+// InvalidatePasswordResetTokens помечает использованными все неиспользованные токены сброса пользователя
+func (r *UserRepo) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error {
+	sqlQuery, args, err := r.sq.
+		Update("password_reset_tokens").
+		Set("used_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"user_id": userID, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ошибка построения SQL для аннулирования токенов сброса пароля: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, sqlQuery, args...); err != nil {
+		slog.ErrorContext(ctx, "Ошибка выполнения SQL для аннулирования токенов сброса пароля", slog.Any("user_id", userID), slog.Any("error", err))
+		return fmt.Errorf("ошибка выполнения SQL для аннулирования токенов сброса пароля: %w", err)
+	}
+	return nil
+}
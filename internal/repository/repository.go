@@ -13,10 +13,32 @@ import (
 )
 
 // --- Стандартные ошибки репозитория ---
-var ErrReceptionNotFound = sql.ErrNoRows                                      // Используем стандартную ошибку для "не найдено" для приемки
-var ErrProductNotFound = sql.ErrNoRows                                        // Используем стандартную ошибку для "не найдено" для товара
-var ErrUserNotFound = errors.New("user not found")                            // Кастомная ошибка для пользователя
-var ErrUserDuplicateEmail = errors.New("user with this email already exists") // Кастомная ошибка дубликата email
+var ErrReceptionNotFound = sql.ErrNoRows                                  // Используем стандартную ошибку для "не найдено" для приемки
+var ErrProductNotFound = sql.ErrNoRows                                    // Используем стандартную ошибку для "не найдено" для товара
+var ErrPVZNotFound = sql.ErrNoRows                                        // Используем стандартную ошибку для "не найдено" для ПВЗ
+var ErrCityNotFound = sql.ErrNoRows                                       // Используем стандартную ошибку для "не найдено" для города
+var ErrCityDuplicate = domain.ErrCityAlreadyExists                        // Дубликат кода/имени города - сразу доменная ошибка (конфликт)
+var ErrCityInUse = domain.ErrCityInUse                                    // На город ссылаются ПВЗ (нарушение FK при удалении)
+var ErrProductTypeNotFound = sql.ErrNoRows                                // Используем стандартную ошибку для "не найдено" для типа товара
+var ErrProductTypeDuplicate = domain.ErrProductTypeAlreadyExists          // Дубликат кода типа товара - сразу доменная ошибка (конфликт)
+var ErrUserNotFound = errors.New("user not found")                        // Кастомная ошибка для пользователя
+var ErrUserDuplicateEmail = domain.ErrUserEmailTaken                      // Дубликат email - сразу доменная ошибка (конфликт)
+var ErrReceptionAlreadyOpen = errors.New("open reception already exists") // Нарушение уникальности открытой приемки для ПВЗ
+var ErrProductBarcodeDuplicate = domain.ErrDuplicateBarcode               // Штрихкод уже есть в приемке - сразу доменная ошибка (конфликт)
+var ErrInviteNotFound = sql.ErrNoRows                                     // Используем стандартную ошибку для "не найдено" для приглашения
+var ErrRefreshTokenNotFound = sql.ErrNoRows                               // Используем стандартную ошибку для "не найдено" для refresh-токена
+var ErrAssignmentNotFound = sql.ErrNoRows                                 // Используем стандартную ошибку для "не найдено" для назначения на ПВЗ
+var ErrPasswordResetTokenNotFound = sql.ErrNoRows                         // Используем стандартную ошибку для "не найдено" для токена сброса пароля
+
+// Transactor выполняет несколько операций репозиториев атомарно.
+//
+//go:generate mockery --name Transactor --output ./mocks --outpkg mocks --case underscore --filename transactor_mock.go
+type Transactor interface {
+	// WithinTransaction выполняет fn в одной транзакции БД.
+	// Репозитории, вызванные с контекстом, переданным в fn, работают внутри этой транзакции.
+	// Если fn возвращает ошибку, транзакция откатывается, иначе фиксируется.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
 
 // --- Интерфейсы Репозиториев ---
 
@@ -30,18 +52,77 @@ type PVZRepository interface {
 
 	// ListPVZs возвращает срез ПВЗ для текущей "страницы", определенной лимитом и курсором.
 	// afterRegistrationDate и afterID используются для keyset pagination (должны быть оба nil или оба не nil).
+	// Деактивированные ПВЗ возвращаются только при includeInactive = true.
+	// Если задан assignedTo, возвращаются только ПВЗ, на которые назначен этот пользователь.
 	// Возвращает срез ПВЗ и ошибку.
-	ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID) ([]domain.PVZ, error)
+	ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) ([]domain.PVZ, error)
 
-	// GetAllPVZs возвращает *все* ПВЗ из хранилища.
+	// GetAllPVZs возвращает *все* ПВЗ из хранилища (деактивированные - только при includeInactive = true).
 	// ВНИМАНИЕ: Может быть неэффективно при больших объемах данных.
 	// Используется, например, для gRPC эндпоинта, где пагинация не реализована.
-	GetAllPVZs(ctx context.Context) ([]domain.PVZ, error)
+	GetAllPVZs(ctx context.Context, includeInactive bool) ([]domain.PVZ, error)
+
+	// GetPVZByID возвращает ПВЗ по ID (в том числе деактивированный).
+	// Возвращает пустую структуру и ErrPVZNotFound, если ПВЗ не найден.
+	// Внутри Transactor.WithinTransaction строка блокируется от изменения (SELECT ... FOR SHARE),
+	// чтобы ПВЗ не деактивировали, пока в нем открывается приемка.
+	GetPVZByID(ctx context.Context, id uuid.UUID) (domain.PVZ, error)
+
+	// UpdatePVZ сохраняет изменяемые поля ПВЗ (город).
+	// Возвращает ErrPVZNotFound, если ПВЗ не найден.
+	UpdatePVZ(ctx context.Context, pvz domain.PVZ) error
 
-	// GetPVZByID возвращает ПВЗ по ID.
-	// Может понадобиться для проверки существования ПВЗ перед созданием приемки.
-	// Возвращает domain.PVZ и ошибку (например, sql.ErrNoRows, если не найден).
-	// GetPVZByID(ctx context.Context, id uuid.UUID) (domain.PVZ, error) // Раскомментируйте, если будете реализовывать
+	// SetPVZActive активирует или деактивирует ПВЗ (deactivated_at выставляется/сбрасывается).
+	// Возвращает ErrPVZNotFound, если ПВЗ не найден.
+	SetPVZActive(ctx context.Context, id uuid.UUID, active bool) error
+}
+
+// CityRepository определяет методы для работы со справочником городов.
+//
+//go:generate mockery --name CityRepository --output ./mocks --outpkg mocks --case underscore --filename city_repo_mock.go
+type CityRepository interface {
+	// CreateCity сохраняет новый город.
+	// Возвращает ErrCityDuplicate, если код или имя уже заняты.
+	CreateCity(ctx context.Context, city domain.City) error
+
+	// GetCityByCode возвращает город по коду.
+	// Возвращает пустую структуру и ErrCityNotFound, если город не найден.
+	GetCityByCode(ctx context.Context, code string) (domain.City, error)
+
+	// GetCityByName возвращает город по отображаемому имени (значению pvz.city).
+	// Возвращает пустую структуру и ErrCityNotFound, если город не найден.
+	GetCityByName(ctx context.Context, name string) (domain.City, error)
+
+	// ListCities возвращает города, упорядоченные по имени (неактивные - только при includeInactive = true).
+	ListCities(ctx context.Context, includeInactive bool) ([]domain.City, error)
+
+	// UpdateCity сохраняет изменяемые поля города (имя, таймзона, активность) по его коду.
+	// Возвращает ErrCityNotFound, если город не найден, и ErrCityDuplicate при конфликте имени.
+	UpdateCity(ctx context.Context, city domain.City) error
+
+	// DeleteCity удаляет город по коду.
+	// Возвращает ErrCityNotFound, если город не найден, и ErrCityInUse, если на него ссылаются ПВЗ.
+	DeleteCity(ctx context.Context, code string) error
+}
+
+// ProductTypeRepository определяет методы для работы со справочником типов товаров.
+//
+//go:generate mockery --name ProductTypeRepository --output ./mocks --outpkg mocks --case underscore --filename product_type_repo_mock.go
+type ProductTypeRepository interface {
+	// CreateProductType сохраняет новый тип товара.
+	// Возвращает ErrProductTypeDuplicate, если код уже занят.
+	CreateProductType(ctx context.Context, productType domain.ProductTypeInfo) error
+
+	// GetProductType возвращает тип товара по коду (в том числе неактивный).
+	// Возвращает пустую структуру и ErrProductTypeNotFound, если тип не найден.
+	GetProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error)
+
+	// ListProductTypes возвращает типы товаров, упорядоченные по коду (неактивные - только при includeInactive = true).
+	ListProductTypes(ctx context.Context, includeInactive bool) ([]domain.ProductTypeInfo, error)
+
+	// UpdateProductType сохраняет названия, активность и схему атрибутов типа по его коду.
+	// Возвращает ErrProductTypeNotFound, если тип не найден.
+	UpdateProductType(ctx context.Context, productType domain.ProductTypeInfo) error
 }
 
 // ReceptionRepository определяет методы для работы с приемками и товарами в рамках приемок.
@@ -50,41 +131,102 @@ type PVZRepository interface {
 type ReceptionRepository interface {
 	// CreateReception создает новую запись о приемке.
 	// Возвращает ID созданной приемки или ошибку.
+	// Возвращает ErrReceptionAlreadyOpen, если для ПВЗ уже есть приемка 'in_progress'.
 	CreateReception(ctx context.Context, reception domain.Reception) (uuid.UUID, error)
 
 	// GetLastOpenReceptionByPVZ ищет последнюю незакрытую приемку (статус 'in_progress') для данного ПВЗ.
 	// Возвращает domain.Reception и nil, если найдена.
 	// Возвращает пустую структуру и ErrReceptionNotFound, если не найдена (или закрыта).
 	// Возвращает пустую структуру и другую ошибку при проблемах с БД.
+	// Внутри Transactor.WithinTransaction строка приемки блокируется (SELECT ... FOR UPDATE).
 	GetLastOpenReceptionByPVZ(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error)
 
 	// AddProductToReception добавляет товар к существующей приемке.
 	// Возвращает ID добавленного товара или ошибку.
+	// Возвращает ErrProductBarcodeDuplicate, если товар с таким штрихкодом уже есть в приемке.
 	AddProductToReception(ctx context.Context, product domain.Product) (uuid.UUID, error)
 
-	// GetLastProductFromReception находит последний (по времени добавления) товар в указанной приемке.
+	// AddProductsToReception добавляет несколько товаров одним INSERT (ID задает вызывающий).
+	// Товары, штрихкод которых уже есть в их приемке, пропускаются без ошибки (ON CONFLICT DO NOTHING).
+	// date_time_added растет в порядке среза, чтобы удаление по LIFO оставалось однозначным.
+	// Возвращает только действительно добавленные товары (с date_time_added из БД).
+	AddProductsToReception(ctx context.Context, products []domain.Product) ([]domain.Product, error)
+
+	// GetLastProductFromReception находит последний (по времени добавления) не удаленный товар в указанной приемке.
 	// Возвращает domain.Product и nil, если найден.
 	// Возвращает пустую структуру и ErrProductNotFound, если товаров в приемке нет.
 	// Возвращает пустую структуру и другую ошибку при проблемах с БД.
 	GetLastProductFromReception(ctx context.Context, receptionID uuid.UUID) (domain.Product, error)
 
-	// DeleteProductByID удаляет товар по его ID.
-	// Возвращает ErrProductNotFound, если товар с таким ID не найден.
+	// GetProductByID возвращает товар по ID.
+	// Возвращает пустую структуру и ErrProductNotFound, если товар не найден.
+	GetProductByID(ctx context.Context, productID uuid.UUID) (domain.Product, error)
+
+	// RecordProductDeletion сохраняет запись аудита удаления товара (кто, когда и почему).
+	RecordProductDeletion(ctx context.Context, deletion domain.ProductDeletion) error
+
+	// DeleteProductByID мягко удаляет товар по его ID (deleted_at = NOW(), deleted_by - ID пользователя из actor).
+	// Удаленный товар не виден остальным методам, кроме ListProductsByReceptionIDs с includeDeleted.
+	// Возвращает ErrProductNotFound, если товар с таким ID не найден или уже удален.
 	// Возвращает nil при успехе или другую ошибку при проблемах с БД.
-	DeleteProductByID(ctx context.Context, productID uuid.UUID) error
+	DeleteProductByID(ctx context.Context, productID uuid.UUID, actor domain.Actor) error
+
+	// GetLastDeletedProductFromReception находит последний мягко удаленный товар в приемке.
+	// Возвращает пустую структуру и ErrProductNotFound, если удаленных товаров нет.
+	GetLastDeletedProductFromReception(ctx context.Context, receptionID uuid.UUID) (domain.Product, error)
 
-	// CloseReceptionByID изменяет статус приемки на 'closed'.
-	// Обновляет только приемку со статусом 'in_progress'.
-	// Возвращает ErrReceptionNotFound, если приемка не найдена или уже закрыта.
+	// RestoreProduct снимает с товара отметку удаления.
+	// Возвращает ErrProductNotFound, если товар не найден или не удален,
+	// и ErrProductBarcodeDuplicate, если штрихкод товара уже занят в приемке.
+	RestoreProduct(ctx context.Context, productID uuid.UUID) error
+
+	// UpdateReceptionStatus меняет статус приемки с from на to.
+	// Обновляет только приемку, которая все еще в статусе from (допустимость перехода проверяет сервис
+	// по domain.ReceptionLifecycle). closed_by заполняется ID пользователя из actor при выходе из
+	// in_progress и очищается при возврате в in_progress. Возвращает ErrReceptionNotFound, если приемка
+	// не найдена или ее статус уже другой, и ErrReceptionAlreadyOpen, если у ПВЗ уже есть открытая приемка.
 	// Возвращает nil при успехе или другую ошибку при проблемах с БД.
-	CloseReceptionByID(ctx context.Context, receptionID uuid.UUID) error
+	UpdateReceptionStatus(ctx context.Context, receptionID uuid.UUID, from, to domain.ReceptionStatus, actor domain.Actor) error
+
+	// HasNewerReception сообщает, есть ли у ПВЗ приемка (в любом статусе), начатая позже reception.
+	HasNewerReception(ctx context.Context, reception domain.Reception) (bool, error)
+
+	// ListStaleReceptions возвращает открытые приемки, подходящие под filter, от старых к новым.
+	// Без блокировок: перед действием сервис перепроверяет приемку в транзакции.
+	ListStaleReceptions(ctx context.Context, filter domain.StaleReceptionFilter) ([]domain.Reception, error)
+
+	// TryLockReception без ожидания берет advisory-блокировку приемки до конца транзакции
+	// (pg_try_advisory_xact_lock). Возвращает false, если приемку уже обрабатывает другой экземпляр сервиса.
+	// Вызывается только внутри Transactor.WithinTransaction.
+	TryLockReception(ctx context.Context, receptionID uuid.UUID) (bool, error)
+
+	// FlagReceptionStale помечает открытую приемку как зависшую (stale_flagged_at = NOW()).
+	// Возвращает ErrReceptionNotFound, если приемка не найдена или уже не в статусе in_progress.
+	FlagReceptionStale(ctx context.Context, receptionID uuid.UUID) error
+
+	// RecordReceptionAudit сохраняет запись аудита действия над приемкой.
+	RecordReceptionAudit(ctx context.Context, entry domain.ReceptionAuditEntry) error
 
 	// ListReceptionsByPVZIDs возвращает все приемки для указанного списка ID ПВЗ,
 	// опционально фильтруя по диапазону дат (startDate, endDate).
 	ListReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) ([]domain.Reception, error)
 
-	// ListProductsByReceptionIDs возвращает все товары для указанного списка ID приемок.
-	ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]domain.Product, error)
+	// ListProductsByReceptionIDs возвращает товары для указанного списка ID приемок.
+	// Мягко удаленные товары возвращаются только при includeDeleted.
+	ListProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID, includeDeleted bool) ([]domain.Product, error)
+
+	// GetReceptionByID возвращает приемку по ID независимо от статуса.
+	// Внутри Transactor.WithinTransaction строка приемки блокируется (SELECT ... FOR UPDATE).
+	// Возвращает пустую структуру и ErrReceptionNotFound, если приемка не найдена.
+	GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (domain.Reception, error)
+
+	// ListReceptionsByPVZ возвращает страницу истории приемок ПВЗ (от новых к старым)
+	// с фильтрами по статусу и диапазону дат и keyset курсором (date_time, id) из filter.
+	ListReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID, filter domain.ReceptionFilter) ([]domain.Reception, error)
+
+	// ListProductsByBarcode возвращает товары с указанным штрихкодом в приемках ПВЗ из pvzIDs
+	// (nil - во всех приемках; от новых к старым, не более limit). Поиск идет по индексу uq_products_barcode_reception.
+	ListProductsByBarcode(ctx context.Context, barcode string, pvzIDs []uuid.UUID, limit uint64) ([]domain.Product, error)
 }
 
 // UserRepository определяет методы для работы с пользователями в БД.
@@ -100,4 +242,121 @@ type UserRepository interface {
 	// Возвращает пустую структуру и ErrUserNotFound, если не найден.
 	// Возвращает пустую структуру и другую ошибку при проблемах с БД.
 	GetUserByEmail(ctx context.Context, email string) (domain.User, error)
+
+	// GetUserByID ищет пользователя по ID.
+	// Возвращает пустую структуру и ErrUserNotFound, если не найден.
+	// Внутри Transactor.WithinTransaction строка блокируется (SELECT ... FOR UPDATE).
+	GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, error)
+
+	// ListUsers возвращает страницу пользователей в порядке регистрации (created_at, id).
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)
+
+	// SetUserDisabled отключает (disabled_at = NOW()) или включает (NULL) учетную запись.
+	// Возвращает ErrUserNotFound, если пользователь не найден.
+	SetUserDisabled(ctx context.Context, id uuid.UUID, disabled bool) error
+
+	// UpdateUserRole меняет роль пользователя. Возвращает ErrUserNotFound, если пользователь не найден.
+	UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error
+
+	// CreateInvite сохраняет приглашение на регистрацию.
+	CreateInvite(ctx context.Context, invite domain.UserInvite) error
+
+	// GetInviteByHash ищет приглашение по хешу токена.
+	// Внутри Transactor.WithinTransaction строка блокируется (SELECT ... FOR UPDATE).
+	// Возвращает ErrInviteNotFound, если приглашение не найдено.
+	GetInviteByHash(ctx context.Context, tokenHash []byte) (domain.UserInvite, error)
+
+	// MarkInviteUsed помечает приглашение использованным пользователем userID, если оно еще не использовано,
+	// иначе возвращает ErrInviteNotFound.
+	MarkInviteUsed(ctx context.Context, id, userID uuid.UUID) error
+
+	// UpdateUserPassword заменяет хеш пароля. Возвращает ErrUserNotFound, если пользователь не найден.
+	UpdateUserPassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+
+	// CreatePasswordResetToken сохраняет токен сброса пароля (только хеш).
+	CreatePasswordResetToken(ctx context.Context, token domain.PasswordResetToken) error
+
+	// GetPasswordResetTokenByHash ищет токен сброса пароля по хешу (в любом состоянии).
+	// Внутри Transactor.WithinTransaction строка блокируется (SELECT ... FOR UPDATE).
+	// Возвращает ErrPasswordResetTokenNotFound, если токен не найден.
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash []byte) (domain.PasswordResetToken, error)
+
+	// InvalidatePasswordResetTokens помечает использованными все еще не использованные токены сброса пользователя
+	// (после сброса или смены пароля прежние токены не должны работать).
+	InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+// TokenRepository определяет методы для работы с refresh-токенами и denylist access-токенов.
+//
+//go:generate mockery --name TokenRepository --output ./mocks --outpkg mocks --case underscore --filename token_repo_mock.go
+type TokenRepository interface {
+	// CreateRefreshToken сохраняет выданный refresh-токен (только хеш).
+	CreateRefreshToken(ctx context.Context, token domain.RefreshToken) error
+
+	// GetRefreshTokenByHash ищет refresh-токен по хешу значения (в любом состоянии).
+	// Внутри Transactor.WithinTransaction строка блокируется (SELECT ... FOR UPDATE).
+	// Возвращает пустую структуру и ErrRefreshTokenNotFound, если токен не найден.
+	GetRefreshTokenByHash(ctx context.Context, hash []byte) (domain.RefreshToken, error)
+
+	// MarkRefreshTokenUsed отмечает обмен токена на новую пару (used_at = NOW()).
+	// Обновляет только еще не использованный и не отозванный токен;
+	// иначе возвращает ErrRefreshTokenNotFound.
+	MarkRefreshTokenUsed(ctx context.Context, id uuid.UUID) error
+
+	// RevokeRefreshTokenFamily отзывает все еще не отозванные токены семейства
+	// и возвращает access-токены, выданные в паре с ними, для добавления в denylist.
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) ([]domain.RevokedToken, error)
+
+	// RevokeUserRefreshTokens отзывает все еще не отозванные токены пользователя (отключение, смена роли)
+	// и возвращает парные access-токены для добавления в denylist.
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) ([]domain.RevokedToken, error)
+
+	// RevokeAccessTokens добавляет access-токены в denylist (повторное добавление не ошибка).
+	RevokeAccessTokens(ctx context.Context, tokens []domain.RevokedToken) error
+
+	// ListRevokedAccessTokens возвращает еще не истекшие записи denylist, отозванные после since
+	// (нулевое since - все записи). Второе значение - наибольшее revoked_at среди них (для следующего вызова).
+	ListRevokedAccessTokens(ctx context.Context, since time.Time) ([]domain.RevokedToken, time.Time, error)
+}
+
+// AssignmentRepository определяет методы для работы с назначениями сотрудников на ПВЗ (user_pvz).
+//
+//go:generate mockery --name AssignmentRepository --output ./mocks --outpkg mocks --case underscore --filename assignment_repo_mock.go
+type AssignmentRepository interface {
+	// AssignPVZ назначает пользователя на ПВЗ. Повторное назначение не ошибка (сохраняется первое).
+	AssignPVZ(ctx context.Context, assignment domain.PVZAssignment) error
+
+	// UnassignPVZ снимает назначение.
+	// Возвращает ErrAssignmentNotFound, если пользователь не был назначен на ПВЗ.
+	UnassignPVZ(ctx context.Context, userID, pvzID uuid.UUID) error
+
+	// ListAssignmentsByUser возвращает назначения пользователя (от новых к старым).
+	ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]domain.PVZAssignment, error)
+
+	// IsAssigned сообщает, назначен ли пользователь на ПВЗ.
+	IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error)
+}
+
+// LoginAttemptRepository определяет методы для работы со счетчиками неудачных попыток входа (login_attempts).
+//
+//go:generate mockery --name LoginAttemptRepository --output ./mocks --outpkg mocks --case underscore --filename login_attempt_repo_mock.go
+type LoginAttemptRepository interface {
+	// GetLoginAttempts возвращает существующие счетчики из keys (для отсутствующих записей нет).
+	GetLoginAttempts(ctx context.Context, keys []domain.LoginAttemptKey) ([]domain.LoginAttempt, error)
+
+	// RecordLoginFailure атомарно увеличивает счетчик и возвращает его новое состояние
+	// (PrevFailureAt - last_failure_at до изменения). Если прошлая неудача была раньше at - window,
+	// отсчет начинается заново с 1.
+	RecordLoginFailure(ctx context.Context, key domain.LoginAttemptKey, at time.Time, window time.Duration) (domain.LoginAttempt, error)
+
+	// ReleaseLoginAttempt уменьшает счетчик на 1 (зарезервированная попытка, результат RecordLoginFailure,
+	// не оказалась неудачной) и возвращает last_failure_at к reserved.PrevFailureAt, если его с тех пор
+	// не изменила другая попытка. Отсутствие записи или нулевой счетчик не ошибка.
+	ReleaseLoginAttempt(ctx context.Context, reserved domain.LoginAttempt) error
+
+	// ResetLoginAttempts удаляет счетчик (успешный вход). Отсутствие записи не ошибка.
+	ResetLoginAttempts(ctx context.Context, key domain.LoginAttemptKey) error
+
+	// DeleteLoginAttemptsBefore удаляет счетчики с последней неудачей раньше before и возвращает их количество.
+	DeleteLoginAttemptsBefore(ctx context.Context, before time.Time) (int64, error)
 }
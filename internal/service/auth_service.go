@@ -2,31 +2,87 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/Artem0405/pvz-service/internal/config"
 	"github.com/Artem0405/pvz-service/internal/domain"
 	"github.com/Artem0405/pvz-service/internal/repository" // Убедитесь, что интерфейс UserRepository и константы ошибок здесь
 	"github.com/golang-jwt/jwt/v5"                         // Импорт пакета JWT v5
-	"golang.org/x/crypto/bcrypt"                           // Импорт пакета bcrypt
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt" // Импорт пакета bcrypt
 )
 
 // Claims определяет структуру полезной нагрузки (payload) JWT токена.
+// ID пользователя хранится в стандартном claim sub; у токенов /dummyLogin sub и email пустые,
+// а claim dummy = true - по нему такие токены отклоняются, если dummy-авторизация выключена.
 type Claims struct {
-	Role string `json:"role"`
-	// Можно добавить другие поля, например UserID
-	jwt.RegisteredClaims // Встраиваем стандартные RegisteredClaims (exp, iat, iss, etc.)
+	Role                 string `json:"role"`
+	Email                string `json:"email,omitempty"`
+	Dummy                bool   `json:"dummy,omitempty"`
+	jwt.RegisteredClaims        // Встраиваем стандартные RegisteredClaims (sub, exp, iat, iss, etc.)
 }
 
-// jwtKey хранит секретный ключ для подписи и проверки JWT токенов.
-// Инициализируется в конструкторе NewAuthService.
-var jwtKey []byte
+// Principal возвращает пользователя, от имени которого выдан токен.
+// Вызывается после ValidateToken, который отклоняет токены с некорректными sub и jti.
+func (c *Claims) Principal() domain.Principal {
+	p := domain.Principal{Email: c.Email, Role: c.Role}
+	if c.Subject != "" {
+		p.UserID, _ = uuid.Parse(c.Subject)
+	}
+	if c.ID != "" {
+		p.TokenID, _ = uuid.Parse(c.ID)
+	}
+	if c.ExpiresAt != nil {
+		p.TokenExpiresAt = c.ExpiresAt.Time
+	}
+	return p
+}
+
+// opaqueTokenBytes - длина случайной части refresh-токенов и приглашений.
+const opaqueTokenBytes = 32
+
+// errRefreshTokenReuse - внутренний сигнал RefreshTokens: предъявлен уже использованный
+// refresh-токен. Транзакция ротации откатывается, семейство отзывается отдельной транзакцией.
+var errRefreshTokenReuse = errors.New("повторное использование refresh-токена")
+
+// errDummyAuthDisabled - GenerateToken вызван при выключенном jwt.dummy_auth (маршрут /dummyLogin
+// в этом случае не регистрируется, так что это ошибка конфигурации, а не клиента).
+var errDummyAuthDisabled = errors.New("dummy-авторизация выключена (jwt.dummy_auth)")
+
+// dummyPasswordHash - bcrypt-хеш случайного пароля. Вход с незарегистрированным email сравнивает пароль
+// с ним, чтобы ответ занимал столько же времени, сколько проверка настоящего пароля.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+	if err != nil {
+		panic(fmt.Sprintf("не удалось сгенерировать фиктивный хеш пароля: %v", err))
+	}
+	return hash
+})
 
 // AuthServiceImpl реализует логику сервиса аутентификации.
 type AuthServiceImpl struct {
-	userRepo repository.UserRepository // Зависимость от репозитория пользователей
+	keys         *JWTKeySet                 // Ключи подписи и проверки токенов
+	registration config.RegistrationConfig  // Режим самостоятельной регистрации
+	userRepo     repository.UserRepository  // Зависимость от репозитория пользователей
+	tokenRepo    repository.TokenRepository // Refresh-токены и отозванные access-токены
+	denylist     *TokenDenylist             // Кеш отозванных jti для ValidateToken
+	limiter      *LoginLimiter              // Счетчики неудачных попыток входа
+	passwords    *PasswordPolicy            // Требования к новым паролям
+	notifier     Notifier                   // Доставка токенов сброса пароля
+	tx           repository.Transactor
+	tokenTTL     time.Duration // Время жизни выдаваемых access-токенов
+	refreshTTL   time.Duration // Время жизни refresh-токенов
+	resetTTL     time.Duration // Время жизни токенов сброса пароля
+	issuer       string        // Значение claim iss, проверяется при валидации
+	dummyAuth    bool          // Выдавать и принимать токены /dummyLogin (только dev/test)
 }
 
 // AuthService определяет интерфейс для сервиса аутентификации (если он нужен).
@@ -39,29 +95,71 @@ type AuthServiceImpl struct {
 // }
 
 // NewAuthService - конструктор для AuthServiceImpl.
-// Принимает секретный ключ JWT и репозиторий пользователей.
-func NewAuthService(secret string, userRepo repository.UserRepository) AuthService { // <-- Возвращаем ИНТЕРФЕЙС
-	if secret == "" {
-		panic("JWT_SECRET не может быть пустым")
+// Принимает настройки JWT (TTL, издатель) и регистрации, набор ключей подписи, репозитории пользователей
+// и токенов, кеш отозванных токенов, счетчики попыток входа, политику паролей, Notifier для токенов
+// сброса пароля и Transactor для ротации refresh-токенов, регистрации по приглашению и смены пароля.
+func NewAuthService(cfg config.JWTConfig, registration config.RegistrationConfig, reset config.PasswordResetConfig, keys *JWTKeySet,
+	userRepo repository.UserRepository, tokenRepo repository.TokenRepository, denylist *TokenDenylist, limiter *LoginLimiter,
+	passwords *PasswordPolicy, notifier Notifier, tx repository.Transactor) AuthService { // <-- Возвращаем ИНТЕРФЕЙС
+	if keys == nil {
+		panic("набор ключей JWT не задан")
+	}
+	if limiter == nil {
+		panic("счетчики попыток входа не заданы")
+	}
+	if passwords == nil {
+		panic("политика паролей не задана")
+	}
+	if notifier == nil {
+		panic("notifier для сброса пароля не задан")
 	}
-	// Инициализируем глобальный ключ (или поле структуры, если jwtKey - поле)
-	jwtKey = []byte(secret)
 	return &AuthServiceImpl{ // <-- Возвращаем указатель на СТРУКТУРУ, которая реализует интерфейс
-		userRepo: userRepo,
+		keys:         keys,
+		registration: registration,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		denylist:     denylist,
+		limiter:      limiter,
+		passwords:    passwords,
+		notifier:     notifier,
+		tx:           tx,
+		tokenTTL:     cfg.TokenTTL,
+		refreshTTL:   cfg.RefreshTokenTTL,
+		resetTTL:     reset.TokenTTL,
+		issuer:       cfg.Issuer,
+		dummyAuth:    cfg.DummyAuth,
 	}
 }
 
 // Register обрабатывает регистрацию нового пользователя.
-func (s *AuthServiceImpl) Register(ctx context.Context, email, password, role string) (domain.User, error) {
+// Без приглашения можно зарегистрироваться только сотрудником и только в режиме registration.mode = open;
+// с приглашением роль задается им (пустая role в запросе - роль из приглашения).
+func (s *AuthServiceImpl) Register(ctx context.Context, email, password, role, inviteToken string) (domain.User, error) {
 	// 1. Валидация входных данных
 	if email == "" || password == "" {
 		// Возвращаем конкретную ошибку для невалидного ввода
 		return domain.User{}, domain.ErrAuthValidation // Пример использования доменной ошибки
 	}
-	if role != domain.RoleEmployee && role != domain.RoleModerator {
-		return domain.User{}, fmt.Errorf("недопустимая роль пользователя: %s", role)
+	if role != "" && !domain.IsValidRole(role) {
+		return domain.User{}, fmt.Errorf("%w: недопустимая роль пользователя %s", domain.ErrAuthValidation, role)
+	}
+	if inviteToken == "" {
+		if s.registration.Mode == config.RegistrationInvite {
+			slog.WarnContext(ctx, "Попытка регистрации без приглашения", "email", email)
+			return domain.User{}, domain.ErrInviteRequired
+		}
+		if role == "" {
+			role = domain.RoleEmployee
+		}
+		if role != domain.RoleEmployee {
+			slog.WarnContext(ctx, "Попытка самостоятельной регистрации с ролью выше сотрудника", "email", email, "role", role)
+			return domain.User{}, domain.ErrRegistrationRoleForbidden
+		}
 	}
-	// TODO: Добавить более строгую валидацию формата email и сложности пароля.
+	if err := s.passwords.Validate(password); err != nil {
+		return domain.User{}, err
+	}
+	// TODO: Добавить более строгую валидацию формата email.
 
 	// 2. Хеширование пароля
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -71,94 +169,540 @@ func (s *AuthServiceImpl) Register(ctx context.Context, email, password, role st
 		return domain.User{}, fmt.Errorf("внутренняя ошибка сервера")
 	}
 
-	// 3. Создание пользователя в репозитории
+	// 3. Создание пользователя в репозитории (вместе с использованием приглашения - атомарно)
 	newUser := domain.User{
 		Email:        email,
 		PasswordHash: string(hashedPassword),
 		Role:         role,
 		// ID будет присвоен базой данных или сгенерирован в CreateUser
 	}
-	userID, err := s.userRepo.CreateUser(ctx, newUser)
-	if err != nil {
-		// Проверяем на конкретную ошибку дубликата
-		if errors.Is(err, repository.ErrUserDuplicateEmail) {
-			slog.WarnContext(ctx, "Попытка регистрации с существующим email", "email", email)
-			// Возвращаем специфическую ошибку, которую может обработать хендлер (например, для статуса 409)
-			return domain.User{}, repository.ErrUserDuplicateEmail
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var invite domain.UserInvite
+		if inviteToken != "" {
+			invite, err = s.lockInvite(ctx, inviteToken, email)
+			if err != nil {
+				return err
+			}
+			if role != "" && role != invite.Role {
+				return fmt.Errorf("%w: роль задается приглашением (%s)", domain.ErrAuthValidation, invite.Role)
+			}
+			newUser.Role = invite.Role
 		}
-		// Логируем любую другую ошибку репозитория
-		slog.ErrorContext(ctx, "Ошибка создания пользователя в репозитории", "email", email, "error", err)
-		// Возвращаем обернутую ошибку
-		return domain.User{}, fmt.Errorf("не удалось зарегистрировать пользователя: %w", err)
+
+		newUser.ID, err = s.userRepo.CreateUser(ctx, newUser)
+		if err != nil {
+			// Проверяем на конкретную ошибку дубликата
+			if errors.Is(err, repository.ErrUserDuplicateEmail) {
+				slog.WarnContext(ctx, "Попытка регистрации с существующим email", "email", email)
+				// repository.ErrUserDuplicateEmail - это domain.ErrUserEmailTaken, транспорт отдаст 409 / EMAIL_TAKEN
+				return repository.ErrUserDuplicateEmail
+			}
+			// Логируем любую другую ошибку репозитория
+			slog.ErrorContext(ctx, "Ошибка создания пользователя в репозитории", "email", email, "error", err)
+			// Возвращаем обернутую ошибку
+			return fmt.Errorf("не удалось зарегистрировать пользователя: %w", err)
+		}
+
+		if inviteToken != "" {
+			if err := s.userRepo.MarkInviteUsed(ctx, invite.ID, newUser.ID); err != nil {
+				if errors.Is(err, repository.ErrInviteNotFound) {
+					return domain.ErrInviteInvalid
+				}
+				return fmt.Errorf("не удалось использовать приглашение: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.User{}, err
 	}
 
 	// 4. Успешная регистрация - возвращаем данные пользователя (без хеша пароля!)
 	createdUser := domain.User{
-		ID:    userID,
+		ID:    newUser.ID,
 		Email: email,
-		Role:  role,
+		Role:  newUser.Role,
 	}
-	slog.InfoContext(ctx, "Пользователь успешно зарегистрирован", "user_id", userID, "email", email)
+	slog.InfoContext(ctx, "Пользователь успешно зарегистрирован", "user_id", newUser.ID, "email", email, "role", newUser.Role, "by_invite", inviteToken != "")
 	return createdUser, nil
 }
 
-// Login обрабатывает вход пользователя и возвращает JWT токен.
-func (s *AuthServiceImpl) Login(ctx context.Context, email, password string) (string, error) {
+// lockInvite находит и блокирует приглашение, проверяя, что оно действует для email.
+// Все причины отказа (не найдено, использовано, истекло, чужой email) - ErrInviteInvalid.
+func (s *AuthServiceImpl) lockInvite(ctx context.Context, inviteToken, email string) (domain.UserInvite, error) {
+	invite, err := s.userRepo.GetInviteByHash(ctx, hashOpaqueToken(inviteToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrInviteNotFound) {
+			return domain.UserInvite{}, domain.ErrInviteInvalid
+		}
+		return domain.UserInvite{}, fmt.Errorf("ошибка получения приглашения: %w", err)
+	}
+
+	switch {
+	case invite.UsedAt != nil:
+		slog.WarnContext(ctx, "Повторное использование приглашения", "invite_id", invite.ID, "email", email)
+		return domain.UserInvite{}, domain.ErrInviteInvalid
+	case !time.Now().Before(invite.ExpiresAt):
+		return domain.UserInvite{}, domain.ErrInviteInvalid
+	case invite.Email != "" && !strings.EqualFold(invite.Email, email):
+		slog.WarnContext(ctx, "Приглашение выдано на другой email", "invite_id", invite.ID, "email", email)
+		return domain.UserInvite{}, domain.ErrInviteInvalid
+	}
+	return invite, nil
+}
+
+// Login обрабатывает вход пользователя и возвращает пару токенов (access + refresh).
+// Каждый вход начинает новое семейство refresh-токенов. clientIP - адрес клиента для счетчика
+// неудачных попыток по IP (пусто - считается только по email).
+func (s *AuthServiceImpl) Login(ctx context.Context, email, password, clientIP string) (TokenPair, error) {
+	// 0. Задержка или блокировка после неудачных попыток - до обращения к БД пользователей и bcrypt.
+	// Попытка сразу резервируется как неудачная; если до проверки пароля дело не дошло, резерв снимается
+	attempt, err := s.limiter.Reserve(ctx, email, clientIP)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	defer attempt.Release(ctx)
+
 	// 1. Получаем пользователя из репозитория по email
 	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
 		// Если пользователь не найден, возвращаем общую ошибку (защита от перебора)
 		if errors.Is(err, repository.ErrUserNotFound) {
+			// Сравнение с фиктивным хешем выравнивает время ответа: по нему нельзя узнать, зарегистрирован ли email
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+			attempt.Fail(ctx)
 			slog.WarnContext(ctx, "Попытка входа несуществующего пользователя", "email", email)
-			return "", domain.ErrAuthInvalidCredentials
+			return TokenPair{}, domain.ErrAuthInvalidCredentials
 		}
 		// Логируем любую другую ошибку репозитория
 		slog.ErrorContext(ctx, "Ошибка получения пользователя по email при логине", "email", email, "error", err)
 		// Возвращаем обернутую ошибку
-		return "", fmt.Errorf("ошибка входа: %w", err)
+		return TokenPair{}, fmt.Errorf("ошибка входа: %w", err)
 	}
 
 	// 2. Сравниваем предоставленный пароль с хешем из БД
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
 		// Если хеши не совпадают (bcrypt.ErrMismatchedHashAndPassword) или другая ошибка bcrypt
+		attempt.Fail(ctx)
 		slog.WarnContext(ctx, "Неудачная попытка входа (неверный пароль)", "email", email)
 		// Возвращаем ту же общую ошибку (защита от перебора)
-		return "", domain.ErrAuthInvalidCredentials
+		return TokenPair{}, domain.ErrAuthInvalidCredentials
+	}
+
+	attempt.Succeed(ctx)
+
+	// Статус проверяется после пароля: без пароля нельзя узнать, что учетная запись отключена
+	if user.IsDisabled() {
+		slog.WarnContext(ctx, "Попытка входа в отключенную учетную запись", "user_id", user.ID, "email", email)
+		return TokenPair{}, domain.ErrUserDisabled
 	}
 
-	// 3. Пароль верный - генерируем JWT токен
-	tokenString, err := s.GenerateToken(user.Role) // Используем роль пользователя из БД
+	// 3. Пароль верный - выдаем access-токен с ID, email и ролью из БД и refresh-токен нового семейства
+	pair, err := s.issueTokenPair(ctx, user, uuid.New())
 	if err != nil {
-		// Ошибка генерации токена уже логируется внутри GenerateToken
-		// Оборачиваем ошибку для контекста
-		return "", fmt.Errorf("не удалось сгенерировать токен: %w", err)
+		return TokenPair{}, fmt.Errorf("не удалось сгенерировать токен: %w", err)
 	}
 
 	slog.InfoContext(ctx, "Пользователь успешно вошел в систему", "user_id", user.ID, "email", email)
-	return tokenString, nil
+	return pair, nil
+}
+
+// RefreshTokens обменивает refresh-токен на новую пару токенов того же семейства.
+// Предъявленный токен становится использованным; повторное предъявление использованного
+// токена считается кражей: отзывается все семейство вместе с выданными по нему access-токенами.
+func (s *AuthServiceImpl) RefreshTokens(ctx context.Context, refreshToken string) (TokenPair, error) {
+	if refreshToken == "" {
+		return TokenPair{}, domain.ErrRefreshTokenInvalid
+	}
+
+	var (
+		pair     TokenPair
+		familyID uuid.UUID
+	)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Строка блокируется (FOR UPDATE): параллельная ротация того же токена ждет и видит used_at
+		stored, err := s.tokenRepo.GetRefreshTokenByHash(ctx, hashOpaqueToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+				return domain.ErrRefreshTokenInvalid
+			}
+			return fmt.Errorf("ошибка получения refresh-токена: %w", err)
+		}
+		familyID = stored.FamilyID
+
+		switch {
+		case stored.RevokedAt != nil:
+			return domain.ErrRefreshTokenInvalid
+		case stored.UsedAt != nil:
+			return errRefreshTokenReuse
+		case !time.Now().Before(stored.ExpiresAt):
+			return domain.ErrRefreshTokenInvalid
+		}
+
+		// Роль и email берем из БД: они могли измениться с момента входа
+		user, err := s.userRepo.GetUserByID(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return domain.ErrRefreshTokenInvalid
+			}
+			return fmt.Errorf("ошибка получения пользователя: %w", err)
+		}
+		if user.IsDisabled() {
+			return domain.ErrUserDisabled
+		}
+
+		if err := s.tokenRepo.MarkRefreshTokenUsed(ctx, stored.ID); err != nil {
+			if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+				return errRefreshTokenReuse
+			}
+			return fmt.Errorf("ошибка пометки refresh-токена: %w", err)
+		}
+
+		pair, err = s.issueTokenPair(ctx, user, stored.FamilyID)
+		return err
+	})
+	if errors.Is(err, errRefreshTokenReuse) {
+		slog.WarnContext(ctx, "Повторное использование refresh-токена, семейство отзывается", "family_id", familyID)
+		if err := s.revokeTokens(ctx, &familyID, nil); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, domain.ErrRefreshTokenReused
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return pair, nil
+}
+
+// Logout отзывает access-токен, которым выполнен запрос (до истечения его срока).
+// Если передан refresh-токен этого же пользователя, отзывается и все его семейство.
+func (s *AuthServiceImpl) Logout(ctx context.Context, principal domain.Principal, refreshToken string) error {
+	var current *domain.RevokedToken
+	if principal.TokenID != uuid.Nil {
+		current = &domain.RevokedToken{JTI: principal.TokenID, ExpiresAt: principal.TokenExpiresAt}
+	}
+
+	var familyID *uuid.UUID
+	if refreshToken != "" {
+		stored, err := s.tokenRepo.GetRefreshTokenByHash(ctx, hashOpaqueToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+				return domain.ErrRefreshTokenInvalid
+			}
+			return fmt.Errorf("ошибка получения refresh-токена: %w", err)
+		}
+		// Чужой refresh-токен отозвать нельзя
+		if principal.UserID == uuid.Nil || stored.UserID != principal.UserID {
+			return domain.ErrRefreshTokenInvalid
+		}
+		familyID = &stored.FamilyID
+	}
+
+	if err := s.revokeTokens(ctx, familyID, current); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Выход из системы", "user_id", principal.UserID, "jti", principal.TokenID)
+	return nil
+}
+
+// ChangePassword меняет пароль после проверки текущего. Все refresh-токены пользователя и выданные
+// по ним access-токены (включая токен запроса) отзываются; взамен выдается новая пара токенов.
+func (s *AuthServiceImpl) ChangePassword(ctx context.Context, principal domain.Principal, currentPassword, newPassword string) (TokenPair, error) {
+	// У токенов /dummyLogin нет пользователя - менять нечего
+	if principal.UserID == uuid.Nil {
+		return TokenPair{}, domain.ErrPasswordChangeForbidden
+	}
+	if currentPassword == "" || newPassword == "" {
+		return TokenPair{}, domain.ErrAuthValidation
+	}
+	// Подбор текущего пароля через смену ограничивается теми же счетчиками, что и вход
+	attempt, err := s.limiter.Reserve(ctx, principal.Email, "")
+	if err != nil {
+		return TokenPair{}, err
+	}
+	defer attempt.Release(ctx)
+	if err := s.passwords.Validate(newPassword); err != nil {
+		return TokenPair{}, err
+	}
+
+	var (
+		user    domain.User
+		revoked []domain.RevokedToken
+	)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetUserByID(ctx, principal.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return domain.ErrPasswordChangeForbidden
+			}
+			return fmt.Errorf("ошибка получения пользователя: %w", err)
+		}
+		if user.IsDisabled() {
+			return domain.ErrUserDisabled
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+			return domain.ErrCurrentPasswordInvalid
+		}
+		// Токен запроса выдан вместе с refresh-токеном и отзывается вместе с ним
+		revoked, err = s.replacePassword(ctx, user.ID, newPassword)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrCurrentPasswordInvalid) {
+			attempt.Fail(ctx)
+			slog.WarnContext(ctx, "Неудачная попытка смены пароля (неверный текущий пароль)", "user_id", principal.UserID)
+		}
+		return TokenPair{}, err
+	}
+	s.denylist.Add(revoked...)
+	attempt.Succeed(ctx)
+
+	pair, err := s.issueTokenPair(ctx, user, uuid.New())
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("не удалось сгенерировать токен: %w", err)
+	}
+	slog.InfoContext(ctx, "Пароль изменен", "user_id", user.ID, "revoked_tokens", len(revoked))
+	return pair, nil
+}
+
+// RequestPasswordReset создает токен сброса пароля и передает его Notifier.
+// Для неизвестного или отключенного email ничего не делает и тоже возвращает nil;
+// ошибка доставки только логируется - по ответу нельзя узнать, зарегистрирован ли email.
+func (s *AuthServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	if email == "" {
+		return domain.ErrAuthValidation
+	}
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			slog.InfoContext(ctx, "Запрошен сброс пароля для незарегистрированного email", "email", email)
+			return nil
+		}
+		return fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user.IsDisabled() {
+		slog.WarnContext(ctx, "Запрошен сброс пароля отключенной учетной записи", "user_id", user.ID)
+		return nil
+	}
+
+	resetToken, err := newOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("ошибка генерации токена сброса: %w", err)
+	}
+	stored := domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashOpaqueToken(resetToken),
+		ExpiresAt: time.Now().Add(s.resetTTL),
+	}
+	if err := s.userRepo.CreatePasswordResetToken(ctx, stored); err != nil {
+		return fmt.Errorf("ошибка сохранения токена сброса: %w", err)
+	}
+
+	if err := s.notifier.SendPasswordReset(ctx, user.Email, resetToken, stored.ExpiresAt); err != nil {
+		slog.ErrorContext(ctx, "Не удалось отправить токен сброса пароля", "user_id", user.ID, "error", err)
+		return nil
+	}
+	slog.InfoContext(ctx, "Отправлен токен сброса пароля", "user_id", user.ID, "expires_at", stored.ExpiresAt)
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по токену сброса. Токен одноразовый: после сброса
+// он и остальные токены сброса пользователя становятся недействительны, все сессии отзываются.
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	if resetToken == "" {
+		return domain.ErrPasswordResetInvalid
+	}
+	if err := s.passwords.Validate(newPassword); err != nil {
+		return err
+	}
+
+	var (
+		user    domain.User
+		revoked []domain.RevokedToken
+	)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Строка блокируется (FOR UPDATE): параллельный сброс тем же токеном ждет и видит used_at
+		stored, err := s.userRepo.GetPasswordResetTokenByHash(ctx, hashOpaqueToken(resetToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrPasswordResetTokenNotFound) {
+				return domain.ErrPasswordResetInvalid
+			}
+			return fmt.Errorf("ошибка получения токена сброса: %w", err)
+		}
+		if stored.UsedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+			return domain.ErrPasswordResetInvalid
+		}
+
+		user, err = s.userRepo.GetUserByID(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return domain.ErrPasswordResetInvalid
+			}
+			return fmt.Errorf("ошибка получения пользователя: %w", err)
+		}
+		if user.IsDisabled() {
+			return domain.ErrUserDisabled
+		}
+		revoked, err = s.replacePassword(ctx, user.ID, newPassword)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.denylist.Add(revoked...)
+	// Владелец почты доказал, что знает пароль - блокировка входа по email больше не нужна
+	s.limiter.RecordSuccess(ctx, user.Email)
+
+	slog.InfoContext(ctx, "Пароль сброшен по токену", "user_id", user.ID, "revoked_tokens", len(revoked))
+	return nil
+}
+
+// replacePassword (внутри транзакции) сохраняет хеш нового пароля, гасит неиспользованные токены
+// сброса и отзывает все refresh-токены пользователя вместе с выданными по ним access-токенами.
+// Возвращает отозванные access-токены для локального кеша denylist.
+func (s *AuthServiceImpl) replacePassword(ctx context.Context, userID uuid.UUID, newPassword string) ([]domain.RevokedToken, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка хеширования нового пароля", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("внутренняя ошибка сервера")
+	}
+	if err := s.userRepo.UpdateUserPassword(ctx, userID, string(hashedPassword)); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, domain.ErrPasswordResetInvalid
+		}
+		return nil, fmt.Errorf("ошибка сохранения пароля: %w", err)
+	}
+	if err := s.userRepo.InvalidatePasswordResetTokens(ctx, userID); err != nil {
+		return nil, fmt.Errorf("ошибка аннулирования токенов сброса: %w", err)
+	}
+	revoked, err := s.tokenRepo.RevokeUserRefreshTokens(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отзыва refresh-токенов: %w", err)
+	}
+	if len(revoked) > 0 {
+		if err := s.tokenRepo.RevokeAccessTokens(ctx, revoked); err != nil {
+			return nil, fmt.Errorf("ошибка отзыва access-токенов: %w", err)
+		}
+	}
+	return revoked, nil
 }
 
-// GenerateToken генерирует новый JWT токен для указанной роли.
+// revokeTokens в одной транзакции отзывает семейство refresh-токенов (если задано) и
+// заносит в denylist выданные по нему access-токены и current; после фиксации
+// добавляет их в локальный кеш.
+func (s *AuthServiceImpl) revokeTokens(ctx context.Context, familyID *uuid.UUID, current *domain.RevokedToken) error {
+	var revoked []domain.RevokedToken
+	if current != nil {
+		revoked = append(revoked, *current)
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if familyID != nil {
+			tokens, err := s.tokenRepo.RevokeRefreshTokenFamily(ctx, *familyID)
+			if err != nil {
+				return fmt.Errorf("ошибка отзыва семейства refresh-токенов: %w", err)
+			}
+			revoked = append(revoked, tokens...)
+		}
+		if len(revoked) == 0 {
+			return nil
+		}
+		if err := s.tokenRepo.RevokeAccessTokens(ctx, revoked); err != nil {
+			return fmt.Errorf("ошибка отзыва access-токенов: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.denylist.Add(revoked...)
+	return nil
+}
+
+// issueTokenPair выдает access-токен пользователя и refresh-токен семейства familyID.
+// В БД сохраняется только хеш refresh-токена и jti парного access-токена.
+func (s *AuthServiceImpl) issueTokenPair(ctx context.Context, user domain.User, familyID uuid.UUID) (TokenPair, error) {
+	claims := userClaims(user)
+	accessToken, err := s.issueToken(claims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("ошибка генерации refresh-токена: %w", err)
+	}
+
+	stored := domain.RefreshToken{
+		UserID:          user.ID,
+		FamilyID:        familyID,
+		TokenHash:       hashOpaqueToken(refreshToken),
+		AccessJTI:       uuid.MustParse(claims.ID),
+		AccessExpiresAt: claims.ExpiresAt.Time,
+		ExpiresAt:       time.Now().Add(s.refreshTTL),
+	}
+	if err := s.tokenRepo.CreateRefreshToken(ctx, stored); err != nil {
+		return TokenPair{}, fmt.Errorf("ошибка сохранения refresh-токена: %w", err)
+	}
+
+	return TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  stored.AccessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// newOpaqueToken генерирует случайный непрозрачный токен (refresh-токен, приглашение).
+func newOpaqueToken() (string, error) {
+	raw := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashOpaqueToken - непрозрачные токены хранятся только в виде SHA-256.
+// Токен - 32 случайных байта, поэтому соль и медленный хеш не нужны.
+func hashOpaqueToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// GenerateToken генерирует новый JWT токен для указанной роли без пользователя (для /dummyLogin).
+// Токен помечается claim dummy; при выключенном jwt.dummy_auth возвращает ошибку.
 func (s *AuthServiceImpl) GenerateToken(role string) (string, error) {
-	// Устанавливаем срок действия токена (например, 24 часа)
-	expirationTime := time.Now().Add(24 * time.Hour)
-	// Создаем полезную нагрузку (claims)
-	claims := &Claims{
-		Role: role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			// Устанавливаем стандартные claims
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "pvz-service", // Опционально: указываем издателя
-		},
+	if !s.dummyAuth {
+		slog.Warn("Попытка выдать токен /dummyLogin при выключенной dummy-авторизации", "role", role)
+		return "", errDummyAuthDisabled
 	}
+	return s.issueToken(&Claims{Role: role, Dummy: true})
+}
 
-	// Создаем новый токен с указанием метода подписи и claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// userClaims - claims access-токена пользователя.
+func userClaims(user domain.User) *Claims {
+	claims := &Claims{Role: user.Role, Email: user.Email}
+	claims.Subject = user.ID.String()
+	return claims
+}
 
-	// Подписываем токен секретным ключом
-	tokenString, err := token.SignedString(jwtKey)
+// issueToken дополняет claims стандартными полями (jti, exp, iat, iss) и подписывает токен.
+func (s *AuthServiceImpl) issueToken(claims *Claims) (string, error) {
+	// Срок действия токена задается конфигурацией (jwt.token_ttl)
+	now := time.Now()
+	claims.ID = uuid.New().String()
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(s.tokenTTL))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.Issuer = s.issuer
+
+	// Создаем новый токен с методом подписи активного ключа; kid указывает, чем проверять
+	key := s.keys.signer()
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+
+	// Подписываем токен закрытым ключом (для HS256 - секретом)
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		slog.Error("Ошибка подписи JWT токена", "error", err)
 		// Возвращаем общую ошибку сервера
@@ -168,6 +712,11 @@ func (s *AuthServiceImpl) GenerateToken(role string) (string, error) {
 	return tokenString, nil
 }
 
+// JWKS возвращает открытые ключи для проверки токенов другими сервисами.
+func (s *AuthServiceImpl) JWKS() JSONWebKeySet {
+	return s.keys.JWKS()
+}
+
 // ValidateToken проверяет подпись и срок действия JWT токена.
 // Возвращает claims токена в случае успеха.
 func (s *AuthServiceImpl) ValidateToken(tokenString string) (*Claims, error) {
@@ -175,13 +724,18 @@ func (s *AuthServiceImpl) ValidateToken(tokenString string) (*Claims, error) {
 
 	// Парсим токен, проверяя подпись и claims
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// Валидация метода подписи: убеждаемся, что это HMAC, а не 'none' или другой
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys.verifier(kid)
+		if !ok {
+			return nil, fmt.Errorf("неизвестный ключ подписи: kid=%q", kid)
+		}
+		// Метод подписи должен совпадать с ключом: иначе возможна подмена
+		// (например, 'none' или HS256 с открытым ключом RSA в качестве секрета)
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("неожиданный метод подписи: %v", token.Header["alg"])
 		}
-		// Возвращаем секретный ключ для проверки подписи
-		return jwtKey, nil
-	})
+		return key.public, nil
+	}, jwt.WithIssuer(s.issuer))
 
 	// Обработка ошибок парсинга и валидации
 	if err != nil {
@@ -209,6 +763,34 @@ func (s *AuthServiceImpl) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, domain.ErrAuthTokenInvalid
 	}
 
+	// Токены /dummyLogin принимаются, только если dummy-авторизация включена (dev/test).
+	// Токен без sub тоже не связан с пользователем - так выглядели токены /dummyLogin до появления claim dummy
+	if (claims.Dummy || claims.Subject == "") && !s.dummyAuth {
+		slog.Warn("Ошибка валидации токена: токен /dummyLogin при выключенной dummy-авторизации", "role", claims.Role)
+		return nil, fmt.Errorf("%w: токены /dummyLogin не принимаются", domain.ErrAuthTokenInvalid)
+	}
+
+	// sub, если задан, должен быть ID пользователя
+	if claims.Subject != "" {
+		if _, err := uuid.Parse(claims.Subject); err != nil {
+			slog.Warn("Ошибка валидации токена: sub не является UUID", "sub", claims.Subject)
+			return nil, fmt.Errorf("%w: некорректный sub", domain.ErrAuthTokenInvalid)
+		}
+	}
+
+	// jti, если задан, проверяется по denylist (logout, отзыв семейства refresh-токенов)
+	if claims.ID != "" {
+		jti, err := uuid.Parse(claims.ID)
+		if err != nil {
+			slog.Warn("Ошибка валидации токена: jti не является UUID", "jti", claims.ID)
+			return nil, fmt.Errorf("%w: некорректный jti", domain.ErrAuthTokenInvalid)
+		}
+		if s.denylist.Contains(jti) {
+			slog.Debug("Ошибка валидации токена: токен отозван", "jti", jti)
+			return nil, domain.ErrAuthTokenRevoked
+		}
+	}
+
 	// Токен успешно прошел все проверки
 	return claims, nil
 }
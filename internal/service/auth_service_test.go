@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Artem0405/pvz-service/internal/config"
 	"github.com/Artem0405/pvz-service/internal/domain"
 	"github.com/Artem0405/pvz-service/internal/repository"
 	mocks "github.com/Artem0405/pvz-service/internal/repository/mocks" // Правильный импорт моков
@@ -22,34 +23,121 @@ import (
 
 const testSecret = "test-secret-key-1234567890-for-testing-purpose" // Используем константу для тестов
 
-// --- ДОБАВЛЕНО: Кастомные ошибки уровня сервиса ---
+// testPassword удовлетворяет политике паролей по умолчанию
+const testPassword = "Passw0rd-42"
+
+// testSigningKey - ключ HS256 сервиса из setupAuthServiceTest, для подписи токенов в обход сервиса
+var testSigningKey = []byte(testSecret)
+
+// Ошибки, которые должен возвращать сервис - доменные сентинелы (сравнение через errors.Is)
 var (
-	ErrAuthValidation            = errors.New("ошибка валидации данных аутентификации")
-	ErrAuthInvalidCredentials    = errors.New("неверный email или пароль")
-	ErrAuthTokenGeneration       = errors.New("ошибка генерации токена")
-	ErrAuthTokenExpired          = errors.New("токен истек")
-	ErrAuthTokenMalformed        = errors.New("некорректный формат токена")
-	ErrAuthTokenInvalidSignature = errors.New("неверная подпись токена")
-	ErrAuthTokenInvalid          = errors.New("невалидный токен")
+	ErrAuthValidation            = domain.ErrAuthValidation
+	ErrAuthInvalidCredentials    = domain.ErrAuthInvalidCredentials
+	ErrAuthTokenExpired          = domain.ErrAuthTokenExpired
+	ErrAuthTokenMalformed        = domain.ErrAuthTokenMalformed
+	ErrAuthTokenInvalidSignature = domain.ErrAuthTokenInvalidSignature
+	ErrAuthTokenInvalid          = domain.ErrAuthTokenInvalid
 )
 
+// testJWTConfig возвращает настройки JWT по умолчанию с указанным секретом.
+func testJWTConfig(secret string) config.JWTConfig {
+	cfg := config.Default().JWT
+	cfg.Secret = secret
+	cfg.DummyAuth = true // Как в dev-конфигурации: по умолчанию /dummyLogin выключен
+	return cfg
+}
+
 // Helper function to set up AuthService with a mock repository
 func setupAuthServiceTest(t *testing.T) (*AuthServiceImpl, *mocks.UserRepository) { // Возвращаем *mocks.UserRepository
+	t.Helper()
+	authService, mockUserRepo, _ := setupAuthServiceWithTokens(t)
+	return authService, mockUserRepo
+}
+
+// setupAuthServiceWithTokens дополнительно возвращает мок репозитория токенов (refresh/logout)
+func setupAuthServiceWithTokens(t *testing.T) (*AuthServiceImpl, *mocks.UserRepository, *mocks.TokenRepository) {
+	t.Helper()
+	keys, err := NewJWTKeySet(testJWTConfig(testSecret))
+	require.NoError(t, err)
+	return setupAuthServiceWithKeys(t, keys)
+}
+
+// setupAuthServiceWithKeys создает сервис с заданным набором ключей подписи
+func setupAuthServiceWithKeys(t *testing.T, keys *JWTKeySet) (*AuthServiceImpl, *mocks.UserRepository, *mocks.TokenRepository) {
 	t.Helper()
 	mockUserRepo := new(mocks.UserRepository) // Используем правильный тип мока
+	mockTokenRepo := new(mocks.TokenRepository)
 	// NewAuthService принимает UserRepository, а не UserRepoMock
-	authService := NewAuthService(testSecret, mockUserRepo).(*AuthServiceImpl) // Приводим к *AuthServiceImpl, если нужно обращаться к неэкспортируемым полям (не нужно здесь)
+	authService := NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, keys, mockUserRepo, mockTokenRepo,
+		NewTokenDenylist(mockTokenRepo), newDisabledLoginLimiter(), newTestPasswordPolicy(t), &recordingNotifier{}, newPassthroughTransactor(t)).(*AuthServiceImpl) // Приводим к *AuthServiceImpl, если нужно обращаться к неэкспортируемым полям (не нужно здесь)
 	require.NotNil(t, authService)
-	return authService, mockUserRepo
+	return authService, mockUserRepo, mockTokenRepo
+}
+
+// newDisabledLoginLimiter возвращает выключенные счетчики попыток входа (проверяются в TestAuthService_LoginProtection)
+func newDisabledLoginLimiter() *LoginLimiter {
+	return NewLoginLimiter(config.LoginProtectionConfig{}, nil)
+}
+
+// newTestPasswordPolicy - политика паролей по умолчанию (встроенный список распространенных паролей)
+func newTestPasswordPolicy(t *testing.T) *PasswordPolicy {
+	t.Helper()
+	policy, err := NewPasswordPolicy(config.Default().PasswordPolicy)
+	require.NoError(t, err)
+	return policy
+}
+
+// recordingNotifier запоминает отправленные токены сброса пароля
+type recordingNotifier struct {
+	email string
+	token string
+	err   error
+}
+
+func (n *recordingNotifier) SendPasswordReset(_ context.Context, email, token string, _ time.Time) error {
+	n.email, n.token = email, token
+	return n.err
+}
+
+// issueTestTokenPair выдает пару токенов как при входе (с записью refresh-токена) и возвращает principal access-токена
+func issueTestTokenPair(t *testing.T, authService *AuthServiceImpl, mockTokenRepo *mocks.TokenRepository, user domain.User) (TokenPair, domain.Principal) {
+	t.Helper()
+	mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt domain.RefreshToken) bool { return rt.UserID == user.ID })).Return(nil).Once()
+	pair, err := authService.issueTokenPair(context.Background(), user, uuid.New())
+	require.NoError(t, err)
+	claims, err := authService.ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	return pair, claims.Principal()
+}
+
+// setupAuthServiceWithLimiter создает сервис с включенной защитой входа и возвращает мок ее счетчиков
+func setupAuthServiceWithLimiter(t *testing.T) (*AuthServiceImpl, *mocks.UserRepository, *mocks.TokenRepository, *mocks.LoginAttemptRepository) {
+	t.Helper()
+	keys, err := NewJWTKeySet(testJWTConfig(testSecret))
+	require.NoError(t, err)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewTokenRepository(t)
+	mockAttempts := mocks.NewLoginAttemptRepository(t)
+	limiter := NewLoginLimiter(config.Default().LoginProtection, mockAttempts)
+	authService := NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, keys, mockUserRepo, mockTokenRepo,
+		NewTokenDenylist(mockTokenRepo), limiter, newTestPasswordPolicy(t), &recordingNotifier{}, newPassthroughTransactor(t)).(*AuthServiceImpl)
+	return authService, mockUserRepo, mockTokenRepo, mockAttempts
 }
 
 // --- Tests for NewAuthService ---
 func TestNewAuthService(t *testing.T) {
 	mockUserRepo := new(mocks.UserRepository) // Используем правильный тип мока
+	mockTokenRepo := new(mocks.TokenRepository)
+	denylist := NewTokenDenylist(mockTokenRepo)
+	policy := newTestPasswordPolicy(t)
+	notifier := &recordingNotifier{}
+	tx := new(mocks.Transactor)
 
 	t.Run("Success with valid secret", func(t *testing.T) {
+		keys, err := NewJWTKeySet(testJWTConfig(testSecret))
+		require.NoError(t, err)
 		assert.NotPanics(t, func() {
-			service := NewAuthService(testSecret, mockUserRepo) // Передаем мок UserRepository
+			service := NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, keys, mockUserRepo, mockTokenRepo, denylist, newDisabledLoginLimiter(), policy, notifier, tx) // Передаем мок UserRepository
 			assert.NotNil(t, service)
 			// Проверяем, что поле userRepo установлено (если нужно)
 			// Для этого может потребоваться привести тип service.(type) или сделать поле экспортируемым
@@ -57,10 +145,27 @@ func TestNewAuthService(t *testing.T) {
 		})
 	})
 
-	t.Run("Panic on empty secret", func(t *testing.T) {
-		assert.PanicsWithValue(t, "JWT_SECRET не может быть пустым", func() {
-			NewAuthService("", mockUserRepo)
-		}, "Should panic when JWT secret is empty")
+	t.Run("Error on empty secret", func(t *testing.T) {
+		// Без keys_dir нужен общий секрет HS256
+		_, err := NewJWTKeySet(testJWTConfig(""))
+		assert.EqualError(t, err, "JWT_SECRET не может быть пустым")
+	})
+
+	t.Run("Panic without keys", func(t *testing.T) {
+		assert.PanicsWithValue(t, "набор ключей JWT не задан", func() {
+			NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, nil, mockUserRepo, mockTokenRepo, denylist, newDisabledLoginLimiter(), policy, notifier, tx)
+		}, "Should panic when key set is nil")
+	})
+
+	t.Run("Panic without password policy or notifier", func(t *testing.T) {
+		keys, err := NewJWTKeySet(testJWTConfig(testSecret))
+		require.NoError(t, err)
+		assert.PanicsWithValue(t, "политика паролей не задана", func() {
+			NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, keys, mockUserRepo, mockTokenRepo, denylist, newDisabledLoginLimiter(), nil, notifier, tx)
+		})
+		assert.PanicsWithValue(t, "notifier для сброса пароля не задан", func() {
+			NewAuthService(testJWTConfig(testSecret), config.Default().Registration, config.Default().PasswordReset, keys, mockUserRepo, mockTokenRepo, denylist, newDisabledLoginLimiter(), policy, nil, tx)
+		})
 	})
 }
 
@@ -71,7 +176,7 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		authService, mockUserRepo := setupAuthServiceTest(t)
 		email := "register.success@example.com"
-		password := "password123"
+		password := testPassword
 		role := domain.RoleEmployee
 		expectedUserID := uuid.New()
 
@@ -92,7 +197,7 @@ func TestAuthService_Register(t *testing.T) {
 			return true
 		})).Return(expectedUserID, nil).Once()
 
-		createdUser, err := authService.Register(ctx, email, password, role)
+		createdUser, err := authService.Register(ctx, email, password, role, "")
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedUserID, createdUser.ID)
@@ -111,15 +216,15 @@ func TestAuthService_Register(t *testing.T) {
 		role        string
 		expectedErr error // Используем ошибки уровня сервиса
 	}{
-		{"Fail - Empty Email", "", "password123", domain.RoleEmployee, ErrAuthValidation},
+		{"Fail - Empty Email", "", testPassword, domain.RoleEmployee, ErrAuthValidation},
 		{"Fail - Empty Password", "test@example.com", "", domain.RoleEmployee, ErrAuthValidation},
-		{"Fail - Invalid Role", "test@example.com", "password123", "admin", ErrAuthValidation}, // Ошибка валидации роли тоже ErrAuthValidation
+		{"Fail - Invalid Role", "test@example.com", testPassword, "admin", ErrAuthValidation}, // Ошибка валидации роли тоже ErrAuthValidation
 	}
 
 	for _, tc := range validationTestCases {
 		t.Run(tc.name, func(t *testing.T) {
 			authService, mockUserRepo := setupAuthServiceTest(t)
-			_, err := authService.Register(ctx, tc.email, tc.password, tc.role)
+			_, err := authService.Register(ctx, tc.email, tc.password, tc.role, "")
 			require.Error(t, err)
 			assert.ErrorIs(t, err, tc.expectedErr) // Проверяем конкретную ошибку сервиса
 			mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
@@ -135,7 +240,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("domain.User")).
 			Return(uuid.Nil, repository.ErrUserDuplicateEmail).Once()
 
-		_, err := authService.Register(ctx, email, "password123", domain.RoleModerator)
+		_, err := authService.Register(ctx, email, testPassword, domain.RoleEmployee, "")
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repository.ErrUserDuplicateEmail, "Should return specific duplicate email error") // Сервис должен пробрасывать эту ошибку репозитория
@@ -150,7 +255,7 @@ func TestAuthService_Register(t *testing.T) {
 		mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("domain.User")).
 			Return(uuid.Nil, repoErr).Once()
 
-		_, err := authService.Register(ctx, "test.repo.fail@example.com", "password123", domain.RoleEmployee)
+		_, err := authService.Register(ctx, "test.repo.fail@example.com", testPassword, domain.RoleEmployee, "")
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "не удалось зарегистрировать пользователя", "Should return wrapped generic error")
@@ -158,6 +263,116 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	// --- Self-registration and invite tests ---
+	t.Run("Fail - Self-Registration As Moderator", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+
+		_, err := authService.Register(ctx, "moderator@example.com", testPassword, domain.RoleModerator, "")
+
+		assert.ErrorIs(t, err, domain.ErrRegistrationRoleForbidden)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Empty Role Defaults To Employee", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+			return user.Role == domain.RoleEmployee
+		})).Return(uuid.New(), nil).Once()
+
+		created, err := authService.Register(ctx, "default.role@example.com", testPassword, "", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleEmployee, created.Role)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Invite Mode Without Token", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		authService.registration.Mode = config.RegistrationInvite
+
+		_, err := authService.Register(ctx, "employee@example.com", testPassword, domain.RoleEmployee, "")
+
+		assert.ErrorIs(t, err, domain.ErrInviteRequired)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	inviteToken := "presented-invite-token"
+	activeInvite := func() domain.UserInvite {
+		return domain.UserInvite{
+			ID:        uuid.New(),
+			TokenHash: hashOpaqueToken(inviteToken),
+			Role:      domain.RoleModerator,
+			Email:     "invited@example.com",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Success - Moderator By Invite", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		authService.registration.Mode = config.RegistrationInvite
+		invite := activeInvite()
+		newID := uuid.New()
+
+		mockUserRepo.On("GetInviteByHash", mock.Anything, invite.TokenHash).Return(invite, nil).Once()
+		mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+			return user.Role == domain.RoleModerator && user.Email == invite.Email
+		})).Return(newID, nil).Once()
+		mockUserRepo.On("MarkInviteUsed", mock.Anything, invite.ID, newID).Return(nil).Once()
+
+		// Роль не передана - берется из приглашения; email сравнивается без учета регистра
+		created, err := authService.Register(ctx, "invited@example.com", testPassword, "", inviteToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, newID, created.ID)
+		assert.Equal(t, domain.RoleModerator, created.Role)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	inviteFailCases := []struct {
+		name   string
+		modify func(inv *domain.UserInvite)
+		email  string
+	}{
+		{"Fail - Invite Already Used", func(inv *domain.UserInvite) { now := time.Now(); inv.UsedAt = &now }, "invited@example.com"},
+		{"Fail - Invite Expired", func(inv *domain.UserInvite) { inv.ExpiresAt = time.Now().Add(-time.Minute) }, "invited@example.com"},
+		{"Fail - Invite For Another Email", func(inv *domain.UserInvite) {}, "someone.else@example.com"},
+	}
+	for _, tc := range inviteFailCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService, mockUserRepo := setupAuthServiceTest(t)
+			invite := activeInvite()
+			tc.modify(&invite)
+			mockUserRepo.On("GetInviteByHash", mock.Anything, invite.TokenHash).Return(invite, nil).Once()
+
+			_, err := authService.Register(ctx, tc.email, testPassword, "", inviteToken)
+
+			assert.ErrorIs(t, err, domain.ErrInviteInvalid)
+			mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Fail - Unknown Invite", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		mockUserRepo.On("GetInviteByHash", mock.Anything, hashOpaqueToken(inviteToken)).
+			Return(domain.UserInvite{}, repository.ErrInviteNotFound).Once()
+
+		_, err := authService.Register(ctx, "invited@example.com", testPassword, "", inviteToken)
+
+		assert.ErrorIs(t, err, domain.ErrInviteInvalid)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Role Differs From Invite", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		invite := activeInvite()
+		mockUserRepo.On("GetInviteByHash", mock.Anything, invite.TokenHash).Return(invite, nil).Once()
+
+		_, err := authService.Register(ctx, "invited@example.com", testPassword, domain.RoleEmployee, inviteToken)
+
+		assert.ErrorIs(t, err, ErrAuthValidation)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
 }
 
 // --- Tests for Login ---
@@ -177,22 +392,41 @@ func TestAuthService_Login(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		authService, mockUserRepo := setupAuthServiceTest(t)
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
 
 		// Мок репозитория возвращает пользователя
 		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(mockUser, nil).Once()
+		// Вход начинает новое семейство; в БД попадает только хеш refresh-токена
+		var stored domain.RefreshToken
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("domain.RefreshToken")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(domain.RefreshToken) }).
+			Return(nil).Once()
 
-		tokenString, err := authService.Login(ctx, email, correctPassword)
+		pair, err := authService.Login(ctx, email, correctPassword, "")
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, tokenString)
+		assert.NotEmpty(t, pair.AccessToken)
+		assert.NotEmpty(t, pair.RefreshToken)
 
 		// Проверяем валидность сгенерированного токена
-		claims, err := authService.ValidateToken(tokenString) // Используем метод самого сервиса для валидации
+		claims, err := authService.ValidateToken(pair.AccessToken) // Используем метод самого сервиса для валидации
 		require.NoError(t, err)
 		assert.Equal(t, userRole, claims.Role)
+		// Токен пользователя несет его ID (sub) и email
+		assert.Equal(t, userID.String(), claims.Subject)
+		principal := claims.Principal()
+		assert.Equal(t, userID, principal.UserID)
+		assert.Equal(t, email, principal.Email)
+		assert.Equal(t, userRole, principal.Role)
+
+		assert.Equal(t, userID, stored.UserID)
+		assert.NotEqual(t, uuid.Nil, stored.FamilyID)
+		assert.Equal(t, hashOpaqueToken(pair.RefreshToken), stored.TokenHash)
+		assert.Equal(t, principal.TokenID, stored.AccessJTI)
+		assert.WithinDuration(t, time.Now().Add(config.Default().JWT.RefreshTokenTTL), pair.RefreshExpiresAt, 10*time.Second)
 
 		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("Fail - User Not Found", func(t *testing.T) {
@@ -202,7 +436,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockUserRepo.On("GetUserByEmail", mock.Anything, email).
 			Return(domain.User{}, repository.ErrUserNotFound).Once()
 
-		_, err := authService.Login(ctx, email, correctPassword)
+		_, err := authService.Login(ctx, email, correctPassword, "")
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, ErrAuthInvalidCredentials) // Сервис должен вернуть ошибку неверных данных
@@ -217,7 +451,7 @@ func TestAuthService_Login(t *testing.T) {
 		mockUserRepo.On("GetUserByEmail", mock.Anything, email).
 			Return(domain.User{}, repoErr).Once()
 
-		_, err := authService.Login(ctx, email, correctPassword)
+		_, err := authService.Login(ctx, email, correctPassword, "")
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "ошибка входа")      // Проверяем общее сообщение
@@ -234,13 +468,112 @@ func TestAuthService_Login(t *testing.T) {
 		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(mockUser, nil).Once()
 
 		// Пытаемся войти с неверным паролем
-		_, err := authService.Login(ctx, email, "wrongPassword")
+		_, err := authService.Login(ctx, email, "wrongPassword", "")
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, ErrAuthInvalidCredentials) // Сервис должен вернуть ошибку неверных данных
 
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	t.Run("Fail - User Disabled", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		disabledAt := time.Now().Add(-time.Hour)
+		disabled := mockUser
+		disabled.DisabledAt = &disabledAt
+		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(disabled, nil).Once()
+
+		_, err := authService.Login(ctx, email, correctPassword, "")
+
+		assert.ErrorIs(t, err, domain.ErrUserDisabled)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_LoginProtection(t *testing.T) {
+	ctx := context.Background()
+	email := "Brute.Force@example.com"
+	clientIP := "203.0.113.7"
+	password := "correctPassword123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := domain.User{ID: uuid.New(), Email: email, PasswordHash: string(hashedPassword), Role: domain.RoleEmployee}
+	emailKey := domain.LoginAttemptKey{Scope: domain.LoginScopeEmail, Value: "brute.force@example.com"}
+	ipKey := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: clientIP}
+	keys := []domain.LoginAttemptKey{emailKey, ipKey}
+
+	t.Run("Fail - Locked Out Before Password Check", func(t *testing.T) {
+		authService, mockUserRepo, _, mockAttempts := setupAuthServiceWithLimiter(t)
+		mockAttempts.On("GetLoginAttempts", mock.Anything, keys).
+			Return([]domain.LoginAttempt{{Key: emailKey, Failures: 5, LastFailureAt: time.Now()}}, nil).Once()
+
+		_, err := authService.Login(ctx, email, password, clientIP)
+
+		assert.ErrorIs(t, err, domain.ErrLoginThrottled)
+		var throttled *LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		assert.InDelta(t, config.Default().LoginProtection.Lockout.Seconds(), throttled.RetryAfter.Seconds(), 5)
+		mockUserRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Wrong Password Counts By Email And IP", func(t *testing.T) {
+		authService, mockUserRepo, _, mockAttempts := setupAuthServiceWithLimiter(t)
+		mockAttempts.On("GetLoginAttempts", mock.Anything, keys).Return(nil, nil).Once()
+		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(user, nil).Once()
+		window := config.Default().LoginProtection.Window
+		// Попытка учитывается заранее, до проверки пароля; после неудачи резерв не снимается
+		mockAttempts.On("RecordLoginFailure", mock.Anything, emailKey, mock.AnythingOfType("time.Time"), window).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+		mockAttempts.On("RecordLoginFailure", mock.Anything, ipKey, mock.AnythingOfType("time.Time"), window).
+			Return(domain.LoginAttempt{Key: ipKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+
+		_, err := authService.Login(ctx, email, "wrongPassword", clientIP)
+
+		assert.ErrorIs(t, err, ErrAuthInvalidCredentials)
+	})
+
+	t.Run("Fail - Unknown Email Counts Too", func(t *testing.T) {
+		authService, mockUserRepo, _, mockAttempts := setupAuthServiceWithLimiter(t)
+		mockAttempts.On("GetLoginAttempts", mock.Anything, keys).Return(nil, nil).Once()
+		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(domain.User{}, repository.ErrUserNotFound).Once()
+		mockAttempts.On("RecordLoginFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Failures: 1, LastFailureAt: time.Now()}, nil).Twice()
+
+		_, err := authService.Login(ctx, email, password, clientIP)
+
+		assert.ErrorIs(t, err, ErrAuthInvalidCredentials)
+	})
+
+	t.Run("Success - Resets Email Counter", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo, mockAttempts := setupAuthServiceWithLimiter(t)
+		mockAttempts.On("GetLoginAttempts", mock.Anything, keys).
+			Return([]domain.LoginAttempt{{Key: emailKey, Failures: 2, LastFailureAt: time.Now().Add(-time.Minute)}}, nil).Once()
+		mockAttempts.On("RecordLoginFailure", mock.Anything, emailKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: 3, LastFailureAt: time.Now()}, nil).Once()
+		mockAttempts.On("RecordLoginFailure", mock.Anything, ipKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: ipKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(user, nil).Once()
+		mockAttempts.On("ResetLoginAttempts", mock.Anything, emailKey).Return(nil).Once()
+		mockAttempts.On("ReleaseLoginAttempt", mock.Anything, reservedAttempt(ipKey)).Return(nil).Once()
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("domain.RefreshToken")).Return(nil).Once()
+
+		_, err := authService.Login(ctx, email, password, clientIP)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Repository Error Releases Reservation", func(t *testing.T) {
+		authService, mockUserRepo, _, mockAttempts := setupAuthServiceWithLimiter(t)
+		mockAttempts.On("GetLoginAttempts", mock.Anything, keys).Return(nil, nil).Once()
+		mockAttempts.On("RecordLoginFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Failures: 1, LastFailureAt: time.Now()}, nil).Twice()
+		mockUserRepo.On("GetUserByEmail", mock.Anything, email).Return(domain.User{}, errors.New("db error")).Once()
+		mockAttempts.On("ReleaseLoginAttempt", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		_, err := authService.Login(ctx, email, password, clientIP)
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAuthInvalidCredentials)
+	})
 }
 
 // --- Tests for GenerateToken ---
@@ -255,22 +588,76 @@ func TestAuthService_GenerateToken(t *testing.T) {
 
 		// Парсим и проверяем клеймы
 		claims := &Claims{}
-		_, err = jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtKey, nil // Используем тот же ключ, что и сервис
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return testSigningKey, nil // Используем тот же ключ, что и сервис
 		})
 
 		require.NoError(t, err)
 		assert.Equal(t, role, claims.Role)
+		assert.True(t, claims.Dummy, "Токен /dummyLogin должен нести claim dummy")
+		assert.Equal(t, hmacKeyID, token.Header["kid"]) // Заголовок kid указывает ключ проверки
 		// Проверяем стандартные клеймы
-		assert.WithinDuration(t, time.Now().Add(24*time.Hour), claims.ExpiresAt.Time, 10*time.Second, "Expiration time is incorrect")
+		assert.WithinDuration(t, time.Now().Add(config.Default().JWT.TokenTTL), claims.ExpiresAt.Time, 10*time.Second, "Expiration time is incorrect")
+		_, err = uuid.Parse(claims.ID) // Каждый токен получает jti для отзыва
+		assert.NoError(t, err)
 		assert.WithinDuration(t, time.Now(), claims.IssuedAt.Time, 10*time.Second, "IssuedAt time is incorrect")
 		assert.Equal(t, "pvz-service", claims.Issuer) // Проверяем издателя
 	})
 
-	// Тест на ошибку генерации (сложно симулировать без изменения jwtKey)
+	// Тест на ошибку генерации (сложно симулировать без подмены ключа)
 	// Обычно покрывается тестами NewAuthService на пустой секрет
 }
 
+// --- Tests for dummy auth outside dev/test ---
+func TestAuthService_DummyAuthDisabled(t *testing.T) {
+	// Тот же секрет, что у setupAuthServiceTest: токен dev-экземпляра проходит проверку подписи
+	cfg := testJWTConfig(testSecret)
+	cfg.DummyAuth = false
+	keys, err := NewJWTKeySet(cfg)
+	require.NoError(t, err)
+	mockTokenRepo := new(mocks.TokenRepository)
+	prodService := NewAuthService(cfg, config.Default().Registration, config.Default().PasswordReset, keys, new(mocks.UserRepository), mockTokenRepo,
+		NewTokenDenylist(mockTokenRepo), newDisabledLoginLimiter(), newTestPasswordPolicy(t), &recordingNotifier{}, newPassthroughTransactor(t))
+	devService, _, devTokenRepo := setupAuthServiceWithTokens(t)
+
+	t.Run("GenerateToken Refused", func(t *testing.T) {
+		_, err := prodService.GenerateToken(domain.RoleModerator)
+		assert.ErrorIs(t, err, errDummyAuthDisabled)
+	})
+
+	t.Run("Dummy Token Rejected", func(t *testing.T) {
+		tokenString, err := devService.GenerateToken(domain.RoleModerator)
+		require.NoError(t, err)
+
+		_, err = prodService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, ErrAuthTokenInvalid)
+	})
+
+	t.Run("Token Without User Rejected", func(t *testing.T) {
+		// Токен без sub и без claim dummy (выданный до появления маркера)
+		claims := &Claims{Role: domain.RoleModerator}
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		claims.Issuer = "pvz-service"
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = hmacKeyID
+		tokenString, err := token.SignedString(testSigningKey)
+		require.NoError(t, err)
+
+		_, err = prodService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, ErrAuthTokenInvalid)
+	})
+
+	t.Run("User Token Accepted", func(t *testing.T) {
+		user := domain.User{ID: uuid.New(), Email: "prod@example.com", Role: domain.RoleEmployee}
+		pair, _ := issueTestTokenPair(t, devService, devTokenRepo, user)
+
+		claims, err := prodService.ValidateToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.False(t, claims.Dummy)
+		assert.Equal(t, user.ID, claims.Principal().UserID)
+	})
+}
+
 // --- Tests for ValidateToken ---
 func TestAuthService_ValidateToken(t *testing.T) {
 	authService, _ := setupAuthServiceTest(t)
@@ -299,7 +686,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 			},
 		}
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsExpired)
-		expiredTokenString, _ := token.SignedString(jwtKey) // Подписываем тем же ключом
+		expiredTokenString, _ := token.SignedString(testSigningKey) // Подписываем тем же ключом
 
 		_, err := authService.ValidateToken(expiredTokenString)
 		require.Error(t, err)
@@ -358,6 +745,64 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "неожиданный метод подписи", "Should contain specific message")
 	})
 
+	t.Run("Success - Token Without User", func(t *testing.T) {
+		// Токен /dummyLogin: sub пустой, principal без ID пользователя
+		claims, err := authService.ValidateToken(validToken)
+		require.NoError(t, err)
+		principal := claims.Principal()
+		assert.Equal(t, uuid.Nil, principal.UserID)
+		assert.Empty(t, principal.Email)
+		assert.Equal(t, validRole, principal.Role)
+		assert.NotEqual(t, uuid.Nil, principal.TokenID)
+	})
+
+	t.Run("Fail - Subject Is Not UUID", func(t *testing.T) {
+		claimsBadSub := &Claims{
+			Role: validRole,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "not-a-uuid",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
+				Issuer:    "pvz-service",
+			},
+		}
+		tokenStringBadSub, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsBadSub).SignedString(testSigningKey)
+
+		_, err := authService.ValidateToken(tokenStringBadSub)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrAuthTokenInvalid)
+	})
+
+	t.Run("Fail - JTI Is Not UUID", func(t *testing.T) {
+		claimsBadJTI := &Claims{
+			Role: validRole,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "not-a-uuid",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
+				Issuer:    "pvz-service",
+			},
+		}
+		tokenStringBadJTI, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsBadJTI).SignedString(testSigningKey)
+
+		_, err := authService.ValidateToken(tokenStringBadJTI)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrAuthTokenInvalid)
+	})
+
+	t.Run("Fail - Token Revoked", func(t *testing.T) {
+		tokenString, err := authService.GenerateToken(validRole)
+		require.NoError(t, err)
+		claims, err := authService.ValidateToken(tokenString)
+		require.NoError(t, err)
+
+		authService.denylist.Add(domain.RevokedToken{JTI: claims.Principal().TokenID, ExpiresAt: claims.ExpiresAt.Time})
+
+		_, err = authService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, domain.ErrAuthTokenRevoked)
+		// Остальные токены не затронуты
+		_, err = authService.ValidateToken(validToken)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Fail - Token Valid Flag is False (Difficult to simulate)", func(t *testing.T) {
 		// Этот случай сложно воспроизвести изолированно, так как библиотека jwt
 		// обычно возвращает более конкретные ошибки парсинга или валидации клеймов до этой проверки.
@@ -365,3 +810,348 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		t.Skip("Skipping test for !token.Valid case as it's hard to trigger reliably without other parsing errors")
 	})
 }
+
+// --- Tests for RefreshTokens ---
+func TestAuthService_RefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	user := domain.User{ID: uuid.New(), Email: "refresh@example.com", Role: domain.RoleEmployee}
+	refreshToken := "presented-refresh-token"
+	hash := hashOpaqueToken(refreshToken)
+
+	activeToken := func() domain.RefreshToken {
+		return domain.RefreshToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			FamilyID:  uuid.New(),
+			TokenHash: hash,
+			AccessJTI: uuid.New(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Success - Rotation In Same Family", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		stored := activeToken()
+		// Роль в БД изменилась с момента входа - новый токен несет актуальную
+		current := user
+		current.Role = domain.RoleModerator
+
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).Return(stored, nil).Once()
+		mockUserRepo.On("GetUserByID", mock.Anything, user.ID).Return(current, nil).Once()
+		mockTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, stored.ID).Return(nil).Once()
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt domain.RefreshToken) bool {
+			return rt.FamilyID == stored.FamilyID && rt.UserID == user.ID
+		})).Return(nil).Once()
+
+		pair, err := authService.RefreshTokens(ctx, refreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, refreshToken, pair.RefreshToken)
+
+		claims, err := authService.ValidateToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleModerator, claims.Role)
+		assert.Equal(t, user.ID.String(), claims.Subject)
+
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - User Disabled", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		stored := activeToken()
+		disabledAt := time.Now()
+		disabled := user
+		disabled.DisabledAt = &disabledAt
+
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).Return(stored, nil).Once()
+		mockUserRepo.On("GetUserByID", mock.Anything, user.ID).Return(disabled, nil).Once()
+
+		_, err := authService.RefreshTokens(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrUserDisabled)
+		mockTokenRepo.AssertNotCalled(t, "MarkRefreshTokenUsed", mock.Anything, mock.Anything)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Unknown Token", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).
+			Return(domain.RefreshToken{}, repository.ErrRefreshTokenNotFound).Once()
+
+		_, err := authService.RefreshTokens(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Expired Token", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		stored := activeToken()
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).Return(stored, nil).Once()
+
+		_, err := authService.RefreshTokens(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+		mockTokenRepo.AssertNotCalled(t, "MarkRefreshTokenUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Revoked Token", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		stored := activeToken()
+		revokedAt := time.Now().Add(-time.Minute)
+		stored.RevokedAt = &revokedAt
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).Return(stored, nil).Once()
+
+		_, err := authService.RefreshTokens(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+		mockTokenRepo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Reuse Revokes Family", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		stored := activeToken()
+		usedAt := time.Now().Add(-time.Minute)
+		stored.UsedAt = &usedAt
+
+		// Access-токен, выданный по следующему токену семейства (его получил злоумышленник или владелец)
+		pair, principal := issueTestTokenPair(t, authService, mockTokenRepo, user)
+		tokenString := pair.AccessToken
+		issued := domain.RevokedToken{JTI: principal.TokenID, ExpiresAt: principal.TokenExpiresAt}
+
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hash).Return(stored, nil).Once()
+		mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, stored.FamilyID).
+			Return([]domain.RevokedToken{issued}, nil).Once()
+		mockTokenRepo.On("RevokeAccessTokens", mock.Anything, []domain.RevokedToken{issued}).Return(nil).Once()
+
+		_, err := authService.RefreshTokens(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+		// Access-токены семейства отклоняются сразу, без ожидания синхронизации
+		_, err = authService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, domain.ErrAuthTokenRevoked)
+
+		mockTokenRepo.AssertExpectations(t)
+		mockTokenRepo.AssertNumberOfCalls(t, "CreateRefreshToken", 1) // Только при выдаче пары в начале теста
+	})
+}
+
+// --- Tests for Logout ---
+func TestAuthService_Logout(t *testing.T) {
+	ctx := context.Background()
+	user := domain.User{ID: uuid.New(), Email: "logout@example.com", Role: domain.RoleEmployee}
+
+	issuePrincipal := func(t *testing.T, authService *AuthServiceImpl, mockTokenRepo *mocks.TokenRepository) (string, domain.Principal) {
+		t.Helper()
+		pair, principal := issueTestTokenPair(t, authService, mockTokenRepo, user)
+		return pair.AccessToken, principal
+	}
+
+	t.Run("Success - Access Token Only", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		tokenString, principal := issuePrincipal(t, authService, mockTokenRepo)
+
+		current := domain.RevokedToken{JTI: principal.TokenID, ExpiresAt: principal.TokenExpiresAt}
+		mockTokenRepo.On("RevokeAccessTokens", mock.Anything, []domain.RevokedToken{current}).Return(nil).Once()
+
+		require.NoError(t, authService.Logout(ctx, principal, ""))
+
+		_, err := authService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, domain.ErrAuthTokenRevoked)
+		mockTokenRepo.AssertExpectations(t)
+		mockTokenRepo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - With Refresh Token", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		_, principal := issuePrincipal(t, authService, mockTokenRepo)
+		familyID := uuid.New()
+
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hashOpaqueToken("my-refresh")).
+			Return(domain.RefreshToken{ID: uuid.New(), UserID: user.ID, FamilyID: familyID}, nil).Once()
+		mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, familyID).Return([]domain.RevokedToken(nil), nil).Once()
+		mockTokenRepo.On("RevokeAccessTokens", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, authService.Logout(ctx, principal, "my-refresh"))
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Foreign Refresh Token", func(t *testing.T) {
+		authService, _, mockTokenRepo := setupAuthServiceWithTokens(t)
+		tokenString, principal := issuePrincipal(t, authService, mockTokenRepo)
+
+		mockTokenRepo.On("GetRefreshTokenByHash", mock.Anything, hashOpaqueToken("foreign")).
+			Return(domain.RefreshToken{ID: uuid.New(), UserID: uuid.New(), FamilyID: uuid.New()}, nil).Once()
+
+		err := authService.Logout(ctx, principal, "foreign")
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+		// Ничего не отозвано, текущий токен продолжает работать
+		mockTokenRepo.AssertNotCalled(t, "RevokeAccessTokens", mock.Anything, mock.Anything)
+		_, err = authService.ValidateToken(tokenString)
+		assert.NoError(t, err)
+	})
+}
+
+// --- Tests for password change and reset ---
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := domain.User{ID: uuid.New(), Email: "change@example.com", Role: domain.RoleEmployee, PasswordHash: string(hash)}
+	const newPassword = "N3w-Secret-Pass"
+
+	issuePrincipal := func(t *testing.T, authService *AuthServiceImpl, mockTokenRepo *mocks.TokenRepository) (string, domain.Principal) {
+		t.Helper()
+		pair, principal := issueTestTokenPair(t, authService, mockTokenRepo, user)
+		return pair.AccessToken, principal
+	}
+
+	t.Run("Success - Revokes Old Tokens", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		tokenString, principal := issuePrincipal(t, authService, mockTokenRepo)
+		oldSession := domain.RevokedToken{JTI: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		current := domain.RevokedToken{JTI: principal.TokenID, ExpiresAt: principal.TokenExpiresAt}
+
+		mockUserRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		mockUserRepo.On("UpdateUserPassword", mock.Anything, user.ID, mock.MatchedBy(func(h string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(h), []byte(newPassword)) == nil
+		})).Return(nil).Once()
+		mockUserRepo.On("InvalidatePasswordResetTokens", mock.Anything, user.ID).Return(nil).Once()
+		// Текущий access-токен возвращается парой к своему refresh-токену
+		mockTokenRepo.On("RevokeUserRefreshTokens", mock.Anything, user.ID).Return([]domain.RevokedToken{oldSession, current}, nil).Once()
+		mockTokenRepo.On("RevokeAccessTokens", mock.Anything, []domain.RevokedToken{oldSession, current}).Return(nil).Once()
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("domain.RefreshToken")).Return(nil).Once()
+
+		pair, err := authService.ChangePassword(ctx, principal, testPassword, newPassword)
+		require.NoError(t, err)
+		assert.NotEmpty(t, pair.AccessToken)
+		assert.NotEmpty(t, pair.RefreshToken)
+
+		_, err = authService.ValidateToken(tokenString)
+		assert.ErrorIs(t, err, domain.ErrAuthTokenRevoked)
+		_, err = authService.ValidateToken(pair.AccessToken)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Wrong Current Password", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		_, principal := issuePrincipal(t, authService, mockTokenRepo)
+		mockUserRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+		_, err := authService.ChangePassword(ctx, principal, "Wr0ng-Password", newPassword)
+		assert.ErrorIs(t, err, domain.ErrCurrentPasswordInvalid)
+		mockUserRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+		mockTokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - New Password Violates Policy", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		_, principal := issuePrincipal(t, authService, mockTokenRepo)
+
+		_, err := authService.ChangePassword(ctx, principal, testPassword, "short")
+		assert.ErrorIs(t, err, domain.ErrPasswordPolicy)
+		mockUserRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Dummy Token", func(t *testing.T) {
+		authService, mockUserRepo, _ := setupAuthServiceWithTokens(t)
+
+		_, err := authService.ChangePassword(ctx, domain.Principal{Role: domain.RoleEmployee}, testPassword, newPassword)
+		assert.ErrorIs(t, err, domain.ErrPasswordChangeForbidden)
+		mockUserRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	user := domain.User{ID: uuid.New(), Email: "reset@example.com", Role: domain.RoleEmployee}
+
+	t.Run("Success - Token Sent", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		notifier := authService.notifier.(*recordingNotifier)
+		mockUserRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserRepo.On("CreatePasswordResetToken", mock.Anything, mock.MatchedBy(func(tok domain.PasswordResetToken) bool {
+			return tok.UserID == user.ID && time.Until(tok.ExpiresAt) > 59*time.Minute
+		})).Return(nil).Once()
+
+		require.NoError(t, authService.RequestPasswordReset(ctx, user.Email))
+		assert.Equal(t, user.Email, notifier.email)
+		require.NotEmpty(t, notifier.token)
+		// В БД сохраняется только хеш отправленного токена
+		mockUserRepo.AssertCalled(t, "CreatePasswordResetToken", mock.Anything, mock.MatchedBy(func(tok domain.PasswordResetToken) bool {
+			return string(tok.TokenHash) == string(hashOpaqueToken(notifier.token))
+		}))
+	})
+
+	t.Run("Success - Unknown Email Is Silent", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		notifier := authService.notifier.(*recordingNotifier)
+		mockUserRepo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(domain.User{}, repository.ErrUserNotFound).Once()
+
+		require.NoError(t, authService.RequestPasswordReset(ctx, "nobody@example.com"))
+		assert.Empty(t, notifier.token)
+		mockUserRepo.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Notifier Error Is Not Returned", func(t *testing.T) {
+		authService, mockUserRepo := setupAuthServiceTest(t)
+		authService.notifier.(*recordingNotifier).err = errors.New("smtp down")
+		mockUserRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserRepo.On("CreatePasswordResetToken", mock.Anything, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, authService.RequestPasswordReset(ctx, user.Email))
+	})
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	user := domain.User{ID: uuid.New(), Email: "reset@example.com", Role: domain.RoleEmployee}
+	const resetToken = "reset-token"
+	const newPassword = "N3w-Secret-Pass"
+	validToken := domain.PasswordResetToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Success", func(t *testing.T) {
+		authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+		session := domain.RevokedToken{JTI: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		mockUserRepo.On("GetPasswordResetTokenByHash", mock.Anything, hashOpaqueToken(resetToken)).Return(validToken, nil).Once()
+		mockUserRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		mockUserRepo.On("UpdateUserPassword", mock.Anything, user.ID, mock.AnythingOfType("string")).Return(nil).Once()
+		mockUserRepo.On("InvalidatePasswordResetTokens", mock.Anything, user.ID).Return(nil).Once()
+		mockTokenRepo.On("RevokeUserRefreshTokens", mock.Anything, user.ID).Return([]domain.RevokedToken{session}, nil).Once()
+		mockTokenRepo.On("RevokeAccessTokens", mock.Anything, []domain.RevokedToken{session}).Return(nil).Once()
+
+		require.NoError(t, authService.ResetPassword(ctx, resetToken, newPassword))
+		assert.True(t, authService.denylist.Contains(session.JTI))
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	usedAt := time.Now().Add(-time.Minute)
+	invalid := []struct {
+		name   string
+		stored domain.PasswordResetToken
+		err    error
+	}{
+		{"Fail - Unknown Token", domain.PasswordResetToken{}, repository.ErrPasswordResetTokenNotFound},
+		{"Fail - Used Token", domain.PasswordResetToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil},
+		{"Fail - Expired Token", domain.PasswordResetToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(-time.Second)}, nil},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			authService, mockUserRepo, mockTokenRepo := setupAuthServiceWithTokens(t)
+			mockUserRepo.On("GetPasswordResetTokenByHash", mock.Anything, hashOpaqueToken(resetToken)).Return(tc.stored, tc.err).Once()
+
+			err := authService.ResetPassword(ctx, resetToken, newPassword)
+			assert.ErrorIs(t, err, domain.ErrPasswordResetInvalid)
+			mockUserRepo.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+			mockTokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Fail - Weak Password", func(t *testing.T) {
+		authService, mockUserRepo, _ := setupAuthServiceWithTokens(t)
+
+		err := authService.ResetPassword(ctx, resetToken, "qwerty123")
+		assert.ErrorIs(t, err, domain.ErrPasswordPolicy)
+		mockUserRepo.AssertNotCalled(t, "GetPasswordResetTokenByHash", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// cityCodePattern - допустимый код города: латиница в нижнем регистре, цифры, '-' и '_' (до 50 символов)
+var cityCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,49}$`)
+
+// maxCityNameLength - ограничение колонки cities.name
+const maxCityNameLength = 100
+
+// cityService - реализация интерфейса CityService.
+type cityService struct {
+	repo repository.CityRepository
+}
+
+// NewCityService - конструктор сервиса справочника городов.
+func NewCityService(repo repository.CityRepository) CityService {
+	return &cityService{repo: repo}
+}
+
+// CreateCity проверяет поля и добавляет город в справочник.
+func (s *cityService) CreateCity(ctx context.Context, city domain.City) (domain.City, error) {
+	city.Code = strings.TrimSpace(city.Code)
+	city.Name = strings.TrimSpace(city.Name)
+	city.Timezone = strings.TrimSpace(city.Timezone)
+	if !cityCodePattern.MatchString(city.Code) {
+		return domain.City{}, fmt.Errorf("%w: код должен состоять из латиницы в нижнем регистре, цифр, '-' или '_' (до 50 символов)", domain.ErrCityValidation)
+	}
+	if err := validateCityFields(city); err != nil {
+		return domain.City{}, err
+	}
+
+	if err := s.repo.CreateCity(ctx, city); err != nil {
+		if errors.Is(err, repository.ErrCityDuplicate) {
+			slog.WarnContext(ctx, "Попытка создать дубликат города", slog.String("code", city.Code), slog.String("name", city.Name))
+			return domain.City{}, domain.ErrCityAlreadyExists
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при создании города", slog.String("code", city.Code), slog.Any("error", err))
+		return domain.City{}, fmt.Errorf("не удалось сохранить город: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Город добавлен в справочник", slog.String("code", city.Code), slog.String("name", city.Name))
+	// Перечитываем, чтобы вернуть created_at, выставленный БД
+	return s.GetCity(ctx, city.Code)
+}
+
+// GetCity возвращает город по коду.
+func (s *cityService) GetCity(ctx context.Context, code string) (domain.City, error) {
+	city, err := s.repo.GetCityByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrCityNotFound) {
+			return domain.City{}, domain.ErrCityNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения города", slog.String("code", code), slog.Any("error", err))
+		return domain.City{}, fmt.Errorf("не удалось получить город: %w", err)
+	}
+	return city, nil
+}
+
+// ListCities возвращает города справочника.
+func (s *cityService) ListCities(ctx context.Context, includeInactive bool) ([]domain.City, error) {
+	cities, err := s.repo.ListCities(ctx, includeInactive)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения списка городов", slog.Any("error", err))
+		return nil, fmt.Errorf("не удалось получить список городов: %w", err)
+	}
+	return cities, nil
+}
+
+// UpdateCity изменяет имя, таймзону или активность города. Код города неизменяем.
+// Переименование переносится на ПВЗ города внешним ключом (ON UPDATE CASCADE),
+// деактивация запрещает открывать в городе новые ПВЗ, существующие продолжают работать.
+func (s *cityService) UpdateCity(ctx context.Context, code string, update domain.CityUpdate) (domain.City, error) {
+	city, err := s.GetCity(ctx, code)
+	if err != nil {
+		return domain.City{}, err
+	}
+
+	if update.Name != nil {
+		city.Name = strings.TrimSpace(*update.Name)
+	}
+	if update.Timezone != nil {
+		city.Timezone = strings.TrimSpace(*update.Timezone)
+	}
+	if update.IsActive != nil {
+		city.IsActive = *update.IsActive
+	}
+	if err := validateCityFields(city); err != nil {
+		return domain.City{}, err
+	}
+
+	if err := s.repo.UpdateCity(ctx, city); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCityNotFound):
+			return domain.City{}, domain.ErrCityNotFound // Удален между чтением и записью
+		case errors.Is(err, repository.ErrCityDuplicate):
+			return domain.City{}, domain.ErrCityAlreadyExists
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при обновлении города", slog.String("code", code), slog.Any("error", err))
+		return domain.City{}, fmt.Errorf("не удалось обновить город: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Город обновлен", slog.String("code", code), slog.String("name", city.Name), slog.Bool("active", city.IsActive))
+	return city, nil
+}
+
+// DeleteCity удаляет город. Город, в котором есть ПВЗ (в том числе деактивированные),
+// удалить нельзя - его следует деактивировать.
+func (s *cityService) DeleteCity(ctx context.Context, code string) error {
+	if err := s.repo.DeleteCity(ctx, code); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCityNotFound):
+			return domain.ErrCityNotFound
+		case errors.Is(err, repository.ErrCityInUse):
+			slog.WarnContext(ctx, "Попытка удалить город, в котором есть ПВЗ", slog.String("code", code))
+			return domain.ErrCityInUse
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при удалении города", slog.String("code", code), slog.Any("error", err))
+		return fmt.Errorf("не удалось удалить город: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Город удален из справочника", slog.String("code", code))
+	return nil
+}
+
+// validateCityFields проверяет имя и таймзону города.
+func validateCityFields(city domain.City) error {
+	if city.Name == "" || utf8.RuneCountInString(city.Name) > maxCityNameLength {
+		return fmt.Errorf("%w: имя обязательно и не длиннее %d символов", domain.ErrCityValidation, maxCityNameLength)
+	}
+	// time.LoadLocation принимает "" и "Local" - в справочнике нужна явная IANA зона
+	if city.Timezone == "" || city.Timezone == "Local" {
+		return fmt.Errorf("%w: таймзона обязательна (IANA, например Europe/Moscow)", domain.ErrCityValidation)
+	}
+	if _, err := time.LoadLocation(city.Timezone); err != nil {
+		return fmt.Errorf("%w: неизвестная таймзона %q", domain.ErrCityValidation, city.Timezone)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCityService_CreateCity(t *testing.T) {
+	ctx := context.Background()
+	input := domain.City{Code: "ekb", Name: " Екатеринбург ", Timezone: "Asia/Yekaterinburg", IsActive: true}
+	stored := domain.City{Code: "ekb", Name: "Екатеринбург", Timezone: "Asia/Yekaterinburg", IsActive: true, CreatedAt: time.Now()}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+
+		mockRepo.On("CreateCity", mock.Anything, mock.MatchedBy(func(c domain.City) bool {
+			return c.Code == "ekb" && c.Name == "Екатеринбург" && c.IsActive
+		})).Return(nil).Once()
+		mockRepo.On("GetCityByCode", mock.Anything, "ekb").Return(stored, nil).Once()
+
+		city, err := cityService.CreateCity(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, city)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Validation", func(t *testing.T) {
+		cases := map[string]domain.City{
+			"bad code":         {Code: "Ekb!", Name: "Екатеринбург", Timezone: "Asia/Yekaterinburg"},
+			"empty name":       {Code: "ekb", Name: "  ", Timezone: "Asia/Yekaterinburg"},
+			"empty timezone":   {Code: "ekb", Name: "Екатеринбург"},
+			"unknown timezone": {Code: "ekb", Name: "Екатеринбург", Timezone: "Europe/Nowhere"},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				mockRepo := new(mocks.CityRepository)
+				cityService := NewCityService(mockRepo)
+
+				_, err := cityService.CreateCity(ctx, c)
+
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrCityValidation)
+				mockRepo.AssertNotCalled(t, "CreateCity", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Fail - Duplicate", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+
+		mockRepo.On("CreateCity", mock.Anything, mock.Anything).Return(repository.ErrCityDuplicate).Once()
+
+		_, err := cityService.CreateCity(ctx, input)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityAlreadyExists)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCityService_UpdateCity(t *testing.T) {
+	ctx := context.Background()
+	existing := domain.City{Code: "msk", Name: "Москва", Timezone: "Europe/Moscow", IsActive: true}
+
+	t.Run("Success - Rename And Deactivate", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+		name, active := "Москва (ЦАО)", false
+
+		mockRepo.On("GetCityByCode", mock.Anything, "msk").Return(existing, nil).Once()
+		mockRepo.On("UpdateCity", mock.Anything, mock.MatchedBy(func(c domain.City) bool {
+			return c.Code == "msk" && c.Name == name && c.Timezone == "Europe/Moscow" && !c.IsActive
+		})).Return(nil).Once()
+
+		city, err := cityService.UpdateCity(ctx, "msk", domain.CityUpdate{Name: &name, IsActive: &active})
+
+		require.NoError(t, err)
+		assert.Equal(t, name, city.Name)
+		assert.False(t, city.IsActive)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+		active := false
+
+		mockRepo.On("GetCityByCode", mock.Anything, "xxx").Return(domain.City{}, repository.ErrCityNotFound).Once()
+
+		_, err := cityService.UpdateCity(ctx, "xxx", domain.CityUpdate{IsActive: &active})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityNotFound)
+		mockRepo.AssertNotCalled(t, "UpdateCity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Invalid Timezone", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+		tz := "Mars/Olympus"
+
+		mockRepo.On("GetCityByCode", mock.Anything, "msk").Return(existing, nil).Once()
+
+		_, err := cityService.UpdateCity(ctx, "msk", domain.CityUpdate{Timezone: &tz})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityValidation)
+		mockRepo.AssertNotCalled(t, "UpdateCity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Name Taken", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		cityService := NewCityService(mockRepo)
+		name := "Казань"
+
+		mockRepo.On("GetCityByCode", mock.Anything, "msk").Return(existing, nil).Once()
+		mockRepo.On("UpdateCity", mock.Anything, mock.Anything).Return(repository.ErrCityDuplicate).Once()
+
+		_, err := cityService.UpdateCity(ctx, "msk", domain.CityUpdate{Name: &name})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityAlreadyExists)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCityService_DeleteCity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		mockRepo.On("DeleteCity", mock.Anything, "ekb").Return(nil).Once()
+
+		err := NewCityService(mockRepo).DeleteCity(ctx, "ekb")
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - In Use", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		mockRepo.On("DeleteCity", mock.Anything, "msk").Return(repository.ErrCityInUse).Once()
+
+		err := NewCityService(mockRepo).DeleteCity(ctx, "msk")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityInUse)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		mockRepo.On("DeleteCity", mock.Anything, "xxx").Return(repository.ErrCityNotFound).Once()
+
+		err := NewCityService(mockRepo).DeleteCity(ctx, "xxx")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrCityNotFound)
+	})
+
+	t.Run("Fail - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.CityRepository)
+		repoError := errors.New("db down")
+		mockRepo.On("DeleteCity", mock.Anything, "ekb").Return(repoError).Once()
+
+		err := NewCityService(mockRepo).DeleteCity(ctx, "ekb")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+	})
+}
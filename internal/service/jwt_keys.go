@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// hmacKeyID - kid ключа HS256, построенного из jwt.secret.
+const hmacKeyID = "hs256"
+
+// minRSAKeyBits - ключи RSA короче не принимаются.
+const minRSAKeyBits = 2048
+
+// signingKey - ключ JWT с идентификатором kid.
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private interface{} // nil - ключ только для проверки (выведен из подписи при ротации)
+	public  interface{} // Для HS256 - тот же секрет
+}
+
+// JWTKeySet - набор ключей JWT. Новые токены подписываются активным ключом, проверяются -
+// ключом, указанным в заголовке kid. Активный ключ - закрытый ключ с наибольшим kid,
+// поэтому файлы удобно называть по дате выпуска (2026-10-17.pem).
+// Reload атомарно заменяет набор: так ключи ротируются без перезапуска.
+type JWTKeySet struct {
+	dir string // Пусто для HS256 из jwt.secret
+
+	mu     sync.RWMutex
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+// NewJWTKeySet строит набор ключей по настройкам: PEM-файлы из jwt.keys_dir
+// или, если каталог не задан, HS256 с jwt.secret.
+func NewJWTKeySet(cfg config.JWTConfig) (*JWTKeySet, error) {
+	if cfg.KeysDir == "" {
+		if cfg.Secret == "" {
+			return nil, errors.New("JWT_SECRET не может быть пустым")
+		}
+		secret := []byte(cfg.Secret)
+		key := &signingKey{id: hmacKeyID, method: jwt.SigningMethodHS256, private: secret, public: secret}
+		return &JWTKeySet{active: key, keys: map[string]*signingKey{key.id: key}}, nil
+	}
+
+	ks := &JWTKeySet{dir: cfg.KeysDir}
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Reload перечитывает каталог ключей. При ошибке текущий набор остается в силе.
+func (ks *JWTKeySet) Reload() error {
+	if ks.dir == "" {
+		return nil
+	}
+	keys, err := loadPEMKeys(ks.dir)
+	if err != nil {
+		return err
+	}
+
+	var active *signingKey
+	for _, k := range keys {
+		if k.private != nil && (active == nil || k.id > active.id) {
+			active = k
+		}
+	}
+	if active == nil {
+		return fmt.Errorf("в каталоге ключей JWT %s нет закрытого ключа для подписи", ks.dir)
+	}
+
+	ks.mu.Lock()
+	changed := ks.active == nil || ks.active.id != active.id
+	ks.keys = keys
+	ks.active = active
+	ks.mu.Unlock()
+
+	if changed {
+		slog.Info("Активный ключ подписи JWT", "kid", active.id, "alg", active.method.Alg(), "keys", len(keys))
+	}
+	return nil
+}
+
+// Run перечитывает каталог ключей раз в interval, пока не отменен ctx.
+func (ks *JWTKeySet) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.Reload(); err != nil {
+				slog.ErrorContext(ctx, "Не удалось перечитать ключи JWT, используется прежний набор", "dir", ks.dir, "error", err)
+			}
+		}
+	}
+}
+
+// signer возвращает ключ для подписи новых токенов.
+func (ks *JWTKeySet) signer() *signingKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.active
+}
+
+// verifier возвращает ключ проверки по kid. Токены без kid (выпущенные до появления
+// ключей с kid) принимаются, только если подпись - HS256 общим секретом.
+func (ks *JWTKeySet) verifier(kid string) (*signingKey, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if kid == "" {
+		if ks.active.method == jwt.SigningMethodHS256 {
+			return ks.active, true
+		}
+		return nil, false
+	}
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// JWKS возвращает открытые ключи набора (RFC 7517). Для HS256 набор пуст:
+// общий секрет не публикуется.
+func (ks *JWTKeySet) JWKS() JSONWebKeySet {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(ks.keys))}
+	for _, k := range ks.keys {
+		jwk := JSONWebKey{Kid: k.id, Use: "sig", Alg: k.method.Alg()}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// loadPEMKeys читает все <kid>.pem из каталога.
+func loadPEMKeys(dir string) (map[string]*signingKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения каталога ключей JWT: %w", err)
+	}
+
+	keys := make(map[string]*signingKey)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".pem" {
+			continue
+		}
+		kid := strings.TrimSuffix(e.Name(), ".pem")
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения ключа JWT %s: %w", e.Name(), err)
+		}
+		key, err := parsePEMKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("ключ JWT %s: %w", e.Name(), err)
+		}
+		keys[kid] = key
+	}
+	return keys, nil
+}
+
+// parsePEMKey разбирает закрытый (PKCS#8, PKCS#1) или открытый (PKIX) ключ RSA/Ed25519.
+func parsePEMKey(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("файл не содержит PEM-блока")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип PEM-блока %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("ключ RSA короче %d бит", minRSAKeyBits)
+		}
+		return &signingKey{id: kid, method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("ключ RSA короче %d бит", minRSAKeyBits)
+		}
+		return &signingKey{id: kid, method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{id: kid, method: jwt.SigningMethodEdDSA, private: k, public: k.Public().(ed25519.PublicKey)}, nil
+	case ed25519.PublicKey:
+		return &signingKey{id: kid, method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип ключа %T (ожидается RSA или Ed25519)", parsed)
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM сохраняет ключ в <dir>/<kid>.pem: закрытые - PKCS#8, открытые - PKIX.
+func writePEM(t *testing.T, dir, kid string, key interface{}) {
+	t.Helper()
+	var block *pem.Block
+	switch key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600))
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return priv
+}
+
+// loadTestKeySet загружает набор ключей из каталога
+func loadTestKeySet(t *testing.T, dir string) *JWTKeySet {
+	t.Helper()
+	cfg := testJWTConfig("")
+	cfg.KeysDir = dir
+	keys, err := NewJWTKeySet(cfg)
+	require.NoError(t, err)
+	return keys
+}
+
+func TestJWTKeySet_AsymmetricSigning(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		key  interface{}
+		alg  string
+	}{
+		{name: "RS256", key: rsaKey, alg: "RS256"},
+		{name: "EdDSA", key: newEd25519Key(t), alg: "EdDSA"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePEM(t, dir, "2026-01-01", tc.key)
+			authService, _, _ := setupAuthServiceWithKeys(t, loadTestKeySet(t, dir))
+
+			tokenString, err := authService.GenerateToken(domain.RoleEmployee)
+			require.NoError(t, err)
+
+			claims, err := authService.ValidateToken(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, domain.RoleEmployee, claims.Role)
+
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.alg, token.Method.Alg())
+			assert.Equal(t, "2026-01-01", token.Header["kid"])
+		})
+	}
+}
+
+func TestJWTKeySet_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := newEd25519Key(t)
+	writePEM(t, dir, "2026-01-01", oldKey)
+	keys := loadTestKeySet(t, dir)
+	authService, _, _ := setupAuthServiceWithKeys(t, keys)
+
+	oldToken, err := authService.GenerateToken(domain.RoleModerator)
+	require.NoError(t, err)
+
+	// Новый ключ с большим kid становится активным после Reload, без пересоздания сервиса
+	writePEM(t, dir, "2026-02-01", newEd25519Key(t))
+	require.NoError(t, keys.Reload())
+
+	newToken, err := authService.GenerateToken(domain.RoleModerator)
+	require.NoError(t, err)
+	token, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02-01", token.Header["kid"])
+
+	// Старый ключ выведен из подписи: остался только открытый - выданные им токены действуют
+	writePEM(t, dir, "2026-01-01", oldKey.Public())
+	require.NoError(t, keys.Reload())
+	_, err = authService.ValidateToken(oldToken)
+	assert.NoError(t, err)
+
+	jwks := authService.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2026-01-01", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.NotEmpty(t, jwks.Keys[0].X)
+
+	// Файл удален - токены старого ключа больше не принимаются
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-01-01.pem")))
+	require.NoError(t, keys.Reload())
+	_, err = authService.ValidateToken(oldToken)
+	assert.ErrorIs(t, err, ErrAuthTokenInvalid)
+	_, err = authService.ValidateToken(newToken)
+	assert.NoError(t, err)
+}
+
+func TestJWTKeySet_ReloadErrorKeepsKeys(t *testing.T) {
+	dir := t.TempDir()
+	writePEM(t, dir, "2026-01-01", newEd25519Key(t))
+	keys := loadTestKeySet(t, dir)
+	authService, _, _ := setupAuthServiceWithKeys(t, keys)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("not a key"), 0o600))
+	assert.Error(t, keys.Reload())
+
+	tokenString, err := authService.GenerateToken(domain.RoleEmployee)
+	require.NoError(t, err)
+	_, err = authService.ValidateToken(tokenString)
+	assert.NoError(t, err)
+}
+
+func TestJWTKeySet_LoadErrors(t *testing.T) {
+	t.Run("Only Public Keys", func(t *testing.T) {
+		dir := t.TempDir()
+		writePEM(t, dir, "2026-01-01", newEd25519Key(t).Public())
+		cfg := testJWTConfig("")
+		cfg.KeysDir = dir
+		_, err := NewJWTKeySet(cfg)
+		assert.ErrorContains(t, err, "нет закрытого ключа")
+	})
+
+	t.Run("Short RSA Key", func(t *testing.T) {
+		dir := t.TempDir()
+		weak, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		writePEM(t, dir, "weak", weak)
+		cfg := testJWTConfig("")
+		cfg.KeysDir = dir
+		_, err = NewJWTKeySet(cfg)
+		assert.ErrorContains(t, err, "короче")
+	})
+}
+
+func TestJWTKeySet_Isolation(t *testing.T) {
+	// Два сервиса в одном процессе с разными ключами не принимают токены друг друга
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writePEM(t, dirA, "shared-kid", newEd25519Key(t))
+	writePEM(t, dirB, "shared-kid", newEd25519Key(t))
+	serviceA, _, _ := setupAuthServiceWithKeys(t, loadTestKeySet(t, dirA))
+	serviceB, _, _ := setupAuthServiceWithKeys(t, loadTestKeySet(t, dirB))
+	serviceHS, _ := setupAuthServiceTest(t)
+
+	tokenA, err := serviceA.GenerateToken(domain.RoleEmployee)
+	require.NoError(t, err)
+	_, err = serviceA.ValidateToken(tokenA)
+	assert.NoError(t, err)
+	_, err = serviceB.ValidateToken(tokenA)
+	assert.Error(t, err)
+	_, err = serviceHS.ValidateToken(tokenA)
+	assert.Error(t, err)
+
+	tokenHS, err := serviceHS.GenerateToken(domain.RoleEmployee)
+	require.NoError(t, err)
+	_, err = serviceA.ValidateToken(tokenHS)
+	assert.Error(t, err)
+}
+
+func TestJWTKeySet_AlgorithmConfusion(t *testing.T) {
+	// Токен HS256, подписанный открытым ключом как секретом, с kid ключа EdDSA
+	dir := t.TempDir()
+	key := newEd25519Key(t)
+	writePEM(t, dir, "2026-01-01", key)
+	authService, _, _ := setupAuthServiceWithKeys(t, loadTestKeySet(t, dir))
+
+	claims := &Claims{Role: domain.RoleModerator, RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		Issuer:    "pvz-service",
+	}}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header["kid"] = "2026-01-01"
+	forgedString, err := forged.SignedString([]byte(key.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	_, err = authService.ValidateToken(forgedString)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "неожиданный метод подписи")
+
+	// Без kid при асимметричных ключах токен не принимается
+	delete(forged.Header, "kid")
+	forgedString, err = forged.SignedString([]byte(key.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+	_, err = authService.ValidateToken(forgedString)
+	assert.Error(t, err)
+}
+
+func TestJWTKeySet_JWKS(t *testing.T) {
+	t.Run("RSA", func(t *testing.T) {
+		dir := t.TempDir()
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		writePEM(t, dir, "rsa-1", rsaKey)
+
+		jwks := loadTestKeySet(t, dir).JWKS()
+		require.Len(t, jwks.Keys, 1)
+		k := jwks.Keys[0]
+		assert.Equal(t, JSONWebKey{Kty: "RSA", Kid: "rsa-1", Use: "sig", Alg: "RS256", N: k.N, E: "AQAB"}, k)
+		assert.NotEmpty(t, k.N)
+	})
+
+	t.Run("HS256 Is Not Published", func(t *testing.T) {
+		keys, err := NewJWTKeySet(testJWTConfig(testSecret))
+		require.NoError(t, err)
+		assert.Empty(t, keys.JWKS().Keys)
+	})
+}
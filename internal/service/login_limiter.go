@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	mmetrics "github.com/Artem0405/pvz-service/internal/metrics"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// maxBackoffShift ограничивает показатель степени задержки, чтобы сдвиг не переполнил time.Duration
+const maxBackoffShift = 20
+
+// LoginThrottledError - вход временно запрещен. errors.Is(err, domain.ErrLoginThrottled) == true;
+// RetryAfter подсказывает клиенту, когда повторить (заголовок Retry-After).
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return domain.ErrLoginThrottled.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return domain.ErrLoginThrottled
+}
+
+// LoginLimiter считает неудачные попытки входа по email и по IP и решает, можно ли пробовать снова.
+// Счетчики хранятся в login_attempts (общие для всех экземпляров сервиса); заблокированные ключи
+// дополнительно запоминаются в памяти, чтобы поток запросов при переборе отсекался без обращения к БД.
+type LoginLimiter struct {
+	cfg  config.LoginProtectionConfig
+	repo repository.LoginAttemptRepository
+	now  func() time.Time // Подменяется в тестах
+
+	mu      sync.Mutex
+	blocked map[domain.LoginAttemptKey]time.Time // Ключ -> до какого момента вход запрещен
+}
+
+// NewLoginLimiter - конструктор. При cfg.Enabled = false все проверки пропускаются.
+func NewLoginLimiter(cfg config.LoginProtectionConfig, repo repository.LoginAttemptRepository) *LoginLimiter {
+	return &LoginLimiter{
+		cfg:     cfg,
+		repo:    repo,
+		now:     time.Now,
+		blocked: make(map[domain.LoginAttemptKey]time.Time),
+	}
+}
+
+// LoginReservation - попытка входа, заранее учтенная как неудачная (см. LoginLimiter.Reserve).
+// После проверки пароля вызывается Fail или Succeed; Release (через defer) снимает резерв,
+// если попытка прервалась раньше (ошибка БД, отклоненный новый пароль).
+type LoginReservation struct {
+	limiter  *LoginLimiter // nil - защита входа выключена
+	attempts []domain.LoginAttempt
+	done     bool
+}
+
+// Reserve возвращает *LoginThrottledError, если вход для email или IP сейчас запрещен, и иначе сразу
+// учитывает попытку как неудачную - до проверки пароля. Счетчик увеличивается атомарно, поэтому каждый
+// из параллельных запросов получает свой номер попытки и больше email_max_failures (ip_max_failures)
+// попыток за окно не пройдет. Задержки между попытками для одновременных запросов не гарантируются:
+// они проверяются по состоянию счетчиков до резерва.
+func (l *LoginLimiter) Reserve(ctx context.Context, email, ip string) (*LoginReservation, error) {
+	if !l.cfg.Enabled {
+		return &LoginReservation{}, nil
+	}
+	keys := loginAttemptKeys(email, ip)
+	now := l.now()
+
+	// Быстрый путь: ключ уже известен как заблокированный
+	l.mu.Lock()
+	for _, k := range keys {
+		if until, ok := l.blocked[k]; ok && now.Before(until) {
+			l.mu.Unlock()
+			return nil, l.throttled(ctx, k, until.Sub(now))
+		}
+	}
+	l.mu.Unlock()
+
+	attempts, err := l.repo.GetLoginAttempts(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки попыток входа: %w", err)
+	}
+	for _, a := range attempts {
+		if until := l.blockedUntil(a); now.Before(until) {
+			l.remember(a.Key, until)
+			return nil, l.throttled(ctx, a.Key, until.Sub(now))
+		}
+	}
+
+	r := &LoginReservation{limiter: l}
+	for _, k := range keys {
+		a, err := l.repo.RecordLoginFailure(ctx, k, now, l.cfg.Window)
+		if err != nil {
+			r.Release(ctx)
+			return nil, fmt.Errorf("ошибка учета попытки входа: %w", err)
+		}
+		r.attempts = append(r.attempts, a)
+		if a.Failures > l.maxFailures(k.Scope) {
+			// Предел исчерпали параллельные попытки, прошедшие проверку выше одновременно с этой
+			r.Release(ctx)
+			until := a.LastFailureAt.Add(l.cfg.Lockout)
+			l.remember(k, until)
+			return nil, l.throttled(ctx, k, until.Sub(now))
+		}
+	}
+	return r, nil
+}
+
+// Fail подтверждает неудачную попытку: счетчики уже увеличены в Reserve, здесь запоминаются
+// наступившие задержки и блокировки.
+func (r *LoginReservation) Fail(ctx context.Context) {
+	if r.limiter == nil || r.done {
+		return
+	}
+	r.done = true
+	l := r.limiter
+	now := l.now()
+	for _, a := range r.attempts {
+		until := l.blockedUntil(a)
+		if now.Before(until) {
+			l.remember(a.Key, until)
+		}
+		if a.Failures == l.maxFailures(a.Key.Scope) {
+			slog.WarnContext(ctx, "Вход заблокирован после серии неудачных попыток",
+				"scope", a.Key.Scope, "key", a.Key.Value, "failures", a.Failures, "locked_until", until)
+			mmetrics.LoginLockoutsTotal.WithLabelValues(string(a.Key.Scope)).Inc()
+		}
+	}
+}
+
+// Succeed - пароль верный: счетчик email сбрасывается, резерв по IP снимается.
+func (r *LoginReservation) Succeed(ctx context.Context) {
+	if r.limiter == nil || r.done {
+		return
+	}
+	r.done = true
+	for _, a := range r.attempts {
+		if a.Key.Scope == domain.LoginScopeEmail {
+			r.limiter.reset(ctx, a.Key)
+		} else {
+			r.limiter.release(ctx, a)
+		}
+	}
+}
+
+// Release снимает резерв, если попытка не завершилась ни Fail, ни Succeed.
+func (r *LoginReservation) Release(ctx context.Context) {
+	if r.limiter == nil || r.done {
+		return
+	}
+	r.done = true
+	for _, a := range r.attempts {
+		r.limiter.release(ctx, a)
+	}
+}
+
+// RecordSuccess сбрасывает счетчик email (например, после сброса пароля по почте).
+// Счетчик IP не сбрасывается: иначе один известный пароль позволял бы продолжать перебор с того же адреса.
+func (l *LoginLimiter) RecordSuccess(ctx context.Context, email string) {
+	if !l.cfg.Enabled {
+		return
+	}
+	l.reset(ctx, domain.LoginAttemptKey{Scope: domain.LoginScopeEmail, Value: normalizeLoginEmail(email)})
+}
+
+func (l *LoginLimiter) reset(ctx context.Context, k domain.LoginAttemptKey) {
+	if err := l.repo.ResetLoginAttempts(ctx, k); err != nil {
+		slog.ErrorContext(ctx, "Не удалось сбросить счетчик неудачных попыток входа", "error", err)
+	}
+	l.mu.Lock()
+	delete(l.blocked, k)
+	l.mu.Unlock()
+}
+
+// release уменьшает счетчик на зарезервированную попытку и возвращает время последней неудачи к
+// значению до резерва: иначе каждый успешный вход с адреса NAT продлевал бы окно, и чужие опечатки
+// копились бы до блокировки всего офиса. Запрос мог быть уже отменен клиентом, а резерв все равно
+// нужно снять - поэтому контекст без отмены.
+func (l *LoginLimiter) release(ctx context.Context, a domain.LoginAttempt) {
+	if err := l.repo.ReleaseLoginAttempt(context.WithoutCancel(ctx), a); err != nil {
+		slog.ErrorContext(ctx, "Не удалось снять резерв попытки входа", "scope", a.Key.Scope, "error", err)
+	}
+}
+
+// Cleanup удаляет счетчики, которые уже ни на что не влияют, из БД и истекшие блокировки из памяти.
+func (l *LoginLimiter) Cleanup(ctx context.Context) error {
+	now := l.now()
+	l.mu.Lock()
+	for k, until := range l.blocked {
+		if !now.Before(until) {
+			delete(l.blocked, k)
+		}
+	}
+	l.mu.Unlock()
+
+	deleted, err := l.repo.DeleteLoginAttemptsBefore(ctx, now.Add(-max(l.cfg.Window, l.cfg.Lockout)))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		slog.DebugContext(ctx, "Удалены устаревшие счетчики попыток входа", "count", deleted)
+	}
+	return nil
+}
+
+// Run вызывает Cleanup раз в login_protection.window, пока не отменен ctx.
+func (l *LoginLimiter) Run(ctx context.Context) {
+	if !l.cfg.Enabled {
+		return
+	}
+	ticker := time.NewTicker(l.cfg.Window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Cleanup(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Не удалось очистить счетчики попыток входа", "error", err)
+			}
+		}
+	}
+}
+
+// blockedUntil вычисляет, до какого момента после последней неудачи вход запрещен.
+// По email задержка растет экспоненциально до блокировки; по IP задержек нет, только блокировка:
+// за одним адресом (NAT офиса) могут работать несколько сотрудников.
+func (l *LoginLimiter) blockedUntil(a domain.LoginAttempt) time.Time {
+	if a.Failures <= 0 {
+		return time.Time{}
+	}
+	if a.Failures >= l.maxFailures(a.Key.Scope) {
+		return a.LastFailureAt.Add(l.cfg.Lockout)
+	}
+	if a.Key.Scope != domain.LoginScopeEmail {
+		return time.Time{}
+	}
+	delay := l.cfg.BackoffBase << min(a.Failures-1, maxBackoffShift)
+	return a.LastFailureAt.Add(min(delay, l.cfg.Lockout))
+}
+
+func (l *LoginLimiter) maxFailures(scope domain.LoginAttemptScope) int {
+	if scope == domain.LoginScopeIP {
+		return l.cfg.IPMaxFailures
+	}
+	return l.cfg.EmailMaxFailures
+}
+
+func (l *LoginLimiter) remember(k domain.LoginAttemptKey, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.blocked[k] = until
+}
+
+func (l *LoginLimiter) throttled(ctx context.Context, k domain.LoginAttemptKey, retryAfter time.Duration) error {
+	slog.WarnContext(ctx, "Попытка входа отклонена до истечения задержки", "scope", k.Scope, "key", k.Value, "retry_after", retryAfter)
+	mmetrics.LoginThrottledTotal.WithLabelValues(string(k.Scope)).Inc()
+	return &LoginThrottledError{RetryAfter: retryAfter}
+}
+
+// loginAttemptKeys возвращает счетчики попытки: email всегда, IP - если известен
+func loginAttemptKeys(email, ip string) []domain.LoginAttemptKey {
+	keys := []domain.LoginAttemptKey{{Scope: domain.LoginScopeEmail, Value: normalizeLoginEmail(email)}}
+	if ip != "" {
+		keys = append(keys, domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: ip})
+	}
+	return keys
+}
+
+// normalizeLoginEmail приводит email к виду ключа счетчика, чтобы перебор не обходил его сменой регистра
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
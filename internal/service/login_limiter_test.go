@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	mocks "github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testLoginProtection - настройки защиты входа для тестов: 3 неудачи по email, 10 по IP
+func testLoginProtection() config.LoginProtectionConfig {
+	return config.LoginProtectionConfig{
+		Enabled:          true,
+		EmailMaxFailures: 3,
+		IPMaxFailures:    10,
+		BackoffBase:      time.Second,
+		Lockout:          15 * time.Minute,
+		Window:           15 * time.Minute,
+	}
+}
+
+// reservedAttempt сопоставляет резерв, переданный в ReleaseLoginAttempt, по ключу счетчика
+func reservedAttempt(k domain.LoginAttemptKey) interface{} {
+	return mock.MatchedBy(func(a domain.LoginAttempt) bool { return a.Key == k })
+}
+
+// memoryLoginAttempts - login_attempts в памяти с той же семантикой, что у postgres.LoginAttemptRepo,
+// для проверки последовательностей входов во времени
+type memoryLoginAttempts struct {
+	rows map[domain.LoginAttemptKey]domain.LoginAttempt
+}
+
+func (m *memoryLoginAttempts) GetLoginAttempts(_ context.Context, keys []domain.LoginAttemptKey) ([]domain.LoginAttempt, error) {
+	var attempts []domain.LoginAttempt
+	for _, k := range keys {
+		if a, ok := m.rows[k]; ok {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts, nil
+}
+
+func (m *memoryLoginAttempts) RecordLoginFailure(_ context.Context, key domain.LoginAttemptKey, at time.Time, window time.Duration) (domain.LoginAttempt, error) {
+	a, ok := m.rows[key]
+	prev := a.LastFailureAt
+	if !ok || a.LastFailureAt.Before(at.Add(-window)) {
+		a = domain.LoginAttempt{Key: key}
+	}
+	a.Failures++
+	a.LastFailureAt = at
+	m.rows[key] = a
+	a.PrevFailureAt = prev
+	return a, nil
+}
+
+func (m *memoryLoginAttempts) ReleaseLoginAttempt(_ context.Context, reserved domain.LoginAttempt) error {
+	a, ok := m.rows[reserved.Key]
+	if !ok || a.Failures <= 0 {
+		return nil
+	}
+	a.Failures--
+	if !reserved.PrevFailureAt.IsZero() && a.LastFailureAt.Equal(reserved.LastFailureAt) {
+		a.LastFailureAt = reserved.PrevFailureAt
+	}
+	m.rows[reserved.Key] = a
+	return nil
+}
+
+func (m *memoryLoginAttempts) ResetLoginAttempts(_ context.Context, key domain.LoginAttemptKey) error {
+	delete(m.rows, key)
+	return nil
+}
+
+func (m *memoryLoginAttempts) DeleteLoginAttemptsBefore(_ context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for k, a := range m.rows {
+		if a.LastFailureAt.Before(before) {
+			delete(m.rows, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestLoginLimiter_BlockedUntil(t *testing.T) {
+	limiter := NewLoginLimiter(testLoginProtection(), nil)
+	last := time.Now()
+	email := domain.LoginAttemptKey{Scope: domain.LoginScopeEmail, Value: "a@example.com"}
+	ip := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: "203.0.113.7"}
+
+	// По email задержка удваивается с каждой неудачей, на пороге - блокировка
+	assert.Equal(t, last.Add(time.Second), limiter.blockedUntil(domain.LoginAttempt{Key: email, Failures: 1, LastFailureAt: last}))
+	assert.Equal(t, last.Add(2*time.Second), limiter.blockedUntil(domain.LoginAttempt{Key: email, Failures: 2, LastFailureAt: last}))
+	assert.Equal(t, last.Add(15*time.Minute), limiter.blockedUntil(domain.LoginAttempt{Key: email, Failures: 3, LastFailureAt: last}))
+
+	// По IP задержек до порога нет
+	assert.True(t, limiter.blockedUntil(domain.LoginAttempt{Key: ip, Failures: 9, LastFailureAt: last}).IsZero())
+	assert.Equal(t, last.Add(15*time.Minute), limiter.blockedUntil(domain.LoginAttempt{Key: ip, Failures: 10, LastFailureAt: last}))
+
+	// Задержка не превышает блокировку даже при большом BackoffBase
+	cfg := testLoginProtection()
+	cfg.EmailMaxFailures = 100
+	cfg.BackoffBase = time.Hour
+	assert.Equal(t, last.Add(15*time.Minute), NewLoginLimiter(cfg, nil).blockedUntil(domain.LoginAttempt{Key: email, Failures: 50, LastFailureAt: last}))
+}
+
+func TestLoginLimiter_Reserve(t *testing.T) {
+	ctx := context.Background()
+	emailKey := domain.LoginAttemptKey{Scope: domain.LoginScopeEmail, Value: "user@example.com"}
+	ipKey := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: "203.0.113.7"}
+	window := testLoginProtection().Window
+
+	t.Run("Allowed Without Counters", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		repo.On("GetLoginAttempts", mock.Anything, []domain.LoginAttemptKey{emailKey, ipKey}).Return(nil, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, emailKey, mock.AnythingOfType("time.Time"), window).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, ipKey, mock.AnythingOfType("time.Time"), window).
+			Return(domain.LoginAttempt{Key: ipKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+
+		_, err := limiter.Reserve(ctx, " User@Example.com", "203.0.113.7")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Blocked Key Is Served From Memory", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		repo.On("GetLoginAttempts", mock.Anything, mock.Anything).
+			Return([]domain.LoginAttempt{{Key: ipKey, Failures: 10, LastFailureAt: time.Now()}}, nil).Once()
+
+		_, err := limiter.Reserve(ctx, "other@example.com", "203.0.113.7")
+		assert.ErrorIs(t, err, domain.ErrLoginThrottled)
+
+		// Повторная проверка не обращается к БД (Once)
+		_, err = limiter.Reserve(ctx, "third@example.com", "203.0.113.7")
+		assert.ErrorIs(t, err, domain.ErrLoginThrottled)
+	})
+
+	t.Run("Expired Backoff Allows Attempt", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		repo.On("GetLoginAttempts", mock.Anything, mock.Anything).
+			Return([]domain.LoginAttempt{{Key: emailKey, Failures: 2, LastFailureAt: time.Now().Add(-time.Minute)}}, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, emailKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: 3, LastFailureAt: time.Now()}, nil).Once()
+
+		_, err := limiter.Reserve(ctx, "user@example.com", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Parallel Attempts Over Limit Are Rejected", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		// Проверка по счетчикам прошла, но параллельные запросы уже заняли все 3 попытки
+		repo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, emailKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: 4, LastFailureAt: time.Now()}, nil).Once()
+		repo.On("ReleaseLoginAttempt", mock.Anything, reservedAttempt(emailKey)).Return(nil).Once()
+
+		_, err := limiter.Reserve(ctx, "user@example.com", "")
+		var throttled *LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		assert.Greater(t, throttled.RetryAfter, 14*time.Minute)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		repoErr := errors.New("db error")
+		repo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return(nil, repoErr).Once()
+
+		_, err := limiter.Reserve(ctx, "user@example.com", "")
+		assert.ErrorIs(t, err, repoErr)
+		assert.NotErrorIs(t, err, domain.ErrLoginThrottled)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		cfg := testLoginProtection()
+		cfg.Enabled = false
+		limiter := NewLoginLimiter(cfg, repo)
+
+		attempt, err := limiter.Reserve(ctx, "user@example.com", "203.0.113.7")
+		require.NoError(t, err)
+		attempt.Fail(ctx)
+		attempt.Succeed(ctx)
+		attempt.Release(ctx)
+		limiter.RecordSuccess(ctx, "user@example.com")
+	})
+}
+
+func TestLoginReservation(t *testing.T) {
+	ctx := context.Background()
+	emailKey := domain.LoginAttemptKey{Scope: domain.LoginScopeEmail, Value: "user@example.com"}
+	ipKey := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: "203.0.113.7"}
+
+	reserve := func(t *testing.T, repo *mocks.LoginAttemptRepository, limiter *LoginLimiter, emailFailures int) *LoginReservation {
+		t.Helper()
+		repo.On("GetLoginAttempts", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, emailKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: emailKey, Failures: emailFailures, LastFailureAt: time.Now()}, nil).Once()
+		repo.On("RecordLoginFailure", mock.Anything, ipKey, mock.Anything, mock.Anything).
+			Return(domain.LoginAttempt{Key: ipKey, Failures: 1, LastFailureAt: time.Now()}, nil).Once()
+		attempt, err := limiter.Reserve(ctx, "user@example.com", "203.0.113.7")
+		require.NoError(t, err)
+		return attempt
+	}
+
+	t.Run("Fail Locks Out On Threshold", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		attempt := reserve(t, repo, limiter, 3)
+
+		attempt.Fail(ctx)
+		attempt.Release(ctx) // После Fail резерв не снимается
+
+		// Блокировка запомнена в памяти: Reserve отклоняет без обращения к БД
+		_, err := limiter.Reserve(ctx, "user@example.com", "")
+		var throttled *LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		assert.Greater(t, throttled.RetryAfter, 14*time.Minute)
+		repo.AssertNotCalled(t, "ReleaseLoginAttempt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Succeed Clears Email And Releases IP", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		attempt := reserve(t, repo, limiter, 1)
+		repo.On("ResetLoginAttempts", mock.Anything, emailKey).Return(nil).Once()
+		repo.On("ReleaseLoginAttempt", mock.Anything, reservedAttempt(ipKey)).Return(nil).Once()
+
+		attempt.Succeed(ctx)
+		attempt.Release(ctx)
+	})
+
+	t.Run("Release Without Result", func(t *testing.T) {
+		repo := mocks.NewLoginAttemptRepository(t)
+		limiter := NewLoginLimiter(testLoginProtection(), repo)
+		attempt := reserve(t, repo, limiter, 1)
+		repo.On("ReleaseLoginAttempt", mock.Anything, reservedAttempt(emailKey)).Return(nil).Once()
+		repo.On("ReleaseLoginAttempt", mock.Anything, reservedAttempt(ipKey)).Return(nil).Once()
+
+		attempt.Release(ctx)
+		attempt.Release(ctx) // Повторный вызов ничего не делает (Once)
+	})
+}
+
+// Успешные входы с общего адреса (NAT офиса) не продлевают окно счетчика IP:
+// неудачи других сотрудников истекают через window после последней из них.
+func TestLoginLimiter_SuccessDoesNotExtendIPWindow(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryLoginAttempts{rows: make(map[domain.LoginAttemptKey]domain.LoginAttempt)}
+	limiter := NewLoginLimiter(testLoginProtection(), repo)
+	ipKey := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: "203.0.113.7"}
+	const officeIP = "203.0.113.7"
+
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	// Три опечатки разных сотрудников
+	for i := 0; i < 3; i++ {
+		attempt, err := limiter.Reserve(ctx, fmt.Sprintf("typo%d@example.com", i), officeIP)
+		require.NoError(t, err)
+		attempt.Fail(ctx)
+		now = now.Add(time.Second)
+	}
+	lastFailure := repo.rows[ipKey].LastFailureAt
+	require.Equal(t, 3, repo.rows[ipKey].Failures)
+
+	// Успешные входы каждые 5 минут - чаще, чем длится окно
+	for i := 0; i < 5; i++ {
+		now = now.Add(5 * time.Minute)
+		attempt, err := limiter.Reserve(ctx, fmt.Sprintf("staff%d@example.com", i), officeIP)
+		require.NoError(t, err)
+		attempt.Succeed(ctx)
+
+		ip := repo.rows[ipKey]
+		assert.Equal(t, lastFailure, ip.LastFailureAt, "успешный вход не должен сдвигать время последней неудачи")
+		if now.Sub(lastFailure) <= testLoginProtection().Window {
+			assert.Equal(t, 3, ip.Failures, "внутри окна неудачи сохраняются")
+		} else {
+			assert.Zero(t, ip.Failures, "после окна от последней неудачи счетчик IP истекает")
+		}
+	}
+}
+
+func TestLoginLimiter_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewLoginAttemptRepository(t)
+	limiter := NewLoginLimiter(testLoginProtection(), repo)
+	expired := domain.LoginAttemptKey{Scope: domain.LoginScopeIP, Value: "203.0.113.7"}
+	limiter.remember(expired, time.Now().Add(-time.Second))
+
+	repo.On("DeleteLoginAttemptsBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 15*time.Minute
+	})).Return(int64(2), nil).Once()
+
+	require.NoError(t, limiter.Cleanup(ctx))
+	assert.NotContains(t, limiter.blocked, expired)
+}
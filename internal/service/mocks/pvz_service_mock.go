@@ -0,0 +1,228 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	service "github.com/Artem0405/pvz-service/internal/service"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PVZService is an autogenerated mock type for the PVZService type
+type PVZService struct {
+	mock.Mock
+}
+
+// CreatePVZ provides a mock function with given fields: ctx, input
+func (_m *PVZService) CreatePVZ(ctx context.Context, input domain.PVZ) (domain.PVZ, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePVZ")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZ) (domain.PVZ, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PVZ) domain.PVZ); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PVZ) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivatePVZ provides a mock function with given fields: ctx, id
+func (_m *PVZService) DeactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivatePVZ")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPVZ provides a mock function with given fields: ctx, id
+func (_m *PVZService) GetPVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZ")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPVZList provides a mock function with given fields: ctx, startDate, endDate, limit, afterRegistrationDate, afterID, includeInactive, assignedTo
+func (_m *PVZService) GetPVZList(ctx context.Context, startDate *time.Time, endDate *time.Time, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (service.GetPVZListResult, error) {
+	ret := _m.Called(ctx, startDate, endDate, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZList")
+	}
+
+	var r0 service.GetPVZListResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) (service.GetPVZListResult, error)); ok {
+		return rf(ctx, startDate, endDate, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) service.GetPVZListResult); ok {
+		r0 = rf(ctx, startDate, endDate, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	} else {
+		r0 = ret.Get(0).(service.GetPVZListResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) error); ok {
+		r1 = rf(ctx, startDate, endDate, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPVZs provides a mock function with given fields: ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo
+func (_m *PVZService) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (service.GetPVZListResult, error) {
+	ret := _m.Called(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPVZs")
+	}
+
+	var r0 service.GetPVZListResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) (service.GetPVZListResult, error)); ok {
+		return rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) service.GetPVZListResult); ok {
+		r0 = rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	} else {
+		r0 = ret.Get(0).(service.GetPVZListResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time, *uuid.UUID, bool, *uuid.UUID) error); ok {
+		r1 = rf(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReactivatePVZ provides a mock function with given fields: ctx, id
+func (_m *PVZService) ReactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactivatePVZ")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePVZ provides a mock function with given fields: ctx, id, update
+func (_m *PVZService) UpdatePVZ(ctx context.Context, id uuid.UUID, update domain.PVZUpdate) (domain.PVZ, error) {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePVZ")
+	}
+
+	var r0 domain.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.PVZUpdate) (domain.PVZ, error)); ok {
+		return rf(ctx, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.PVZUpdate) domain.PVZ); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Get(0).(domain.PVZ)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.PVZUpdate) error); ok {
+		r1 = rf(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPVZService creates a new instance of PVZService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPVZService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PVZService {
+	mock := &PVZService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,406 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/Artem0405/pvz-service/internal/domain"
+	service "github.com/Artem0405/pvz-service/internal/service"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReceptionService is an autogenerated mock type for the ReceptionService type
+type ReceptionService struct {
+	mock.Mock
+}
+
+// AddProduct provides a mock function with given fields: ctx, pvzID, actor, input
+func (_m *ReceptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, input domain.ProductInput) (domain.Product, error) {
+	ret := _m.Called(ctx, pvzID, actor, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProduct")
+	}
+
+	var r0 domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, domain.ProductInput) (domain.Product, error)); ok {
+		return rf(ctx, pvzID, actor, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, domain.ProductInput) domain.Product); ok {
+		r0 = rf(ctx, pvzID, actor, input)
+	} else {
+		r0 = ret.Get(0).(domain.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor, domain.ProductInput) error); ok {
+		r1 = rf(ctx, pvzID, actor, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddProductsBatch provides a mock function with given fields: ctx, pvzID, actor, inputs, allOrNothing
+func (_m *ReceptionService) AddProductsBatch(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, inputs []domain.ProductInput, allOrNothing bool) (service.ProductBatchResult, error) {
+	ret := _m.Called(ctx, pvzID, actor, inputs, allOrNothing)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProductsBatch")
+	}
+
+	var r0 service.ProductBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, []domain.ProductInput, bool) (service.ProductBatchResult, error)); ok {
+		return rf(ctx, pvzID, actor, inputs, allOrNothing)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, []domain.ProductInput, bool) service.ProductBatchResult); ok {
+		r0 = rf(ctx, pvzID, actor, inputs, allOrNothing)
+	} else {
+		r0 = ret.Get(0).(service.ProductBatchResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor, []domain.ProductInput, bool) error); ok {
+		r1 = rf(ctx, pvzID, actor, inputs, allOrNothing)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelReception provides a mock function with given fields: ctx, receptionID, actor
+func (_m *ReceptionService) CancelReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	ret := _m.Called(ctx, receptionID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReception")
+	}
+
+	var r0 domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) (domain.Reception, error)); ok {
+		return rf(ctx, receptionID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) domain.Reception); ok {
+		r0 = rf(ctx, receptionID, actor)
+	} else {
+		r0 = ret.Get(0).(domain.Reception)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r1 = rf(ctx, receptionID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseLastReception provides a mock function with given fields: ctx, pvzID, actor
+func (_m *ReceptionService) CloseLastReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	ret := _m.Called(ctx, pvzID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseLastReception")
+	}
+
+	var r0 domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) (domain.Reception, error)); ok {
+		return rf(ctx, pvzID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) domain.Reception); ok {
+		r0 = rf(ctx, pvzID, actor)
+	} else {
+		r0 = ret.Get(0).(domain.Reception)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r1 = rf(ctx, pvzID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLastProduct provides a mock function with given fields: ctx, pvzID, actor
+func (_m *ReceptionService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) error {
+	ret := _m.Called(ctx, pvzID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r0 = rf(ctx, pvzID, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteProduct provides a mock function with given fields: ctx, receptionID, productID, actor, reason
+func (_m *ReceptionService) DeleteProduct(ctx context.Context, receptionID uuid.UUID, productID uuid.UUID, actor domain.Actor, reason string) error {
+	ret := _m.Called(ctx, receptionID, productID, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.Actor, string) error); ok {
+		r0 = rf(ctx, receptionID, productID, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindProductsByBarcode provides a mock function with given fields: ctx, barcode, actor
+func (_m *ReceptionService) FindProductsByBarcode(ctx context.Context, barcode string, actor domain.Actor) ([]domain.Product, error) {
+	ret := _m.Called(ctx, barcode, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProductsByBarcode")
+	}
+
+	var r0 []domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Actor) ([]domain.Product, error)); ok {
+		return rf(ctx, barcode, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Actor) []domain.Product); ok {
+		r0 = rf(ctx, barcode, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Actor) error); ok {
+		r1 = rf(ctx, barcode, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrentReception provides a mock function with given fields: ctx, pvzID, actor, includeDeleted
+func (_m *ReceptionService) GetCurrentReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, includeDeleted bool) (service.ReceptionDetails, error) {
+	ret := _m.Called(ctx, pvzID, actor, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentReception")
+	}
+
+	var r0 service.ReceptionDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, bool) (service.ReceptionDetails, error)); ok {
+		return rf(ctx, pvzID, actor, includeDeleted)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, bool) service.ReceptionDetails); ok {
+		r0 = rf(ctx, pvzID, actor, includeDeleted)
+	} else {
+		r0 = ret.Get(0).(service.ReceptionDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor, bool) error); ok {
+		r1 = rf(ctx, pvzID, actor, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReception provides a mock function with given fields: ctx, receptionID, actor, includeDeleted
+func (_m *ReceptionService) GetReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor, includeDeleted bool) (service.ReceptionDetails, error) {
+	ret := _m.Called(ctx, receptionID, actor, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReception")
+	}
+
+	var r0 service.ReceptionDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, bool) (service.ReceptionDetails, error)); ok {
+		return rf(ctx, receptionID, actor, includeDeleted)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, bool) service.ReceptionDetails); ok {
+		r0 = rf(ctx, receptionID, actor, includeDeleted)
+	} else {
+		r0 = ret.Get(0).(service.ReceptionDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor, bool) error); ok {
+		r1 = rf(ctx, receptionID, actor, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InitiateReception provides a mock function with given fields: ctx, pvzID, actor
+func (_m *ReceptionService) InitiateReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	ret := _m.Called(ctx, pvzID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitiateReception")
+	}
+
+	var r0 domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) (domain.Reception, error)); ok {
+		return rf(ctx, pvzID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) domain.Reception); ok {
+		r0 = rf(ctx, pvzID, actor)
+	} else {
+		r0 = ret.Get(0).(domain.Reception)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r1 = rf(ctx, pvzID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceptions provides a mock function with given fields: ctx, pvzID, actor, filter
+func (_m *ReceptionService) ListReceptions(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, filter domain.ReceptionFilter) (service.ListReceptionsResult, error) {
+	ret := _m.Called(ctx, pvzID, actor, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceptions")
+	}
+
+	var r0 service.ListReceptionsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, domain.ReceptionFilter) (service.ListReceptionsResult, error)); ok {
+		return rf(ctx, pvzID, actor, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor, domain.ReceptionFilter) service.ListReceptionsResult); ok {
+		r0 = rf(ctx, pvzID, actor, filter)
+	} else {
+		r0 = ret.Get(0).(service.ListReceptionsResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor, domain.ReceptionFilter) error); ok {
+		r1 = rf(ctx, pvzID, actor, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessStaleReceptions provides a mock function with given fields: ctx, policy, now
+func (_m *ReceptionService) ProcessStaleReceptions(ctx context.Context, policy service.StaleReceptionPolicy, now time.Time) (service.StaleSweepResult, error) {
+	ret := _m.Called(ctx, policy, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessStaleReceptions")
+	}
+
+	var r0 service.StaleSweepResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.StaleReceptionPolicy, time.Time) (service.StaleSweepResult, error)); ok {
+		return rf(ctx, policy, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.StaleReceptionPolicy, time.Time) service.StaleSweepResult); ok {
+		r0 = rf(ctx, policy, now)
+	} else {
+		r0 = ret.Get(0).(service.StaleSweepResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.StaleReceptionPolicy, time.Time) error); ok {
+		r1 = rf(ctx, policy, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReopenReception provides a mock function with given fields: ctx, receptionID, actor
+func (_m *ReceptionService) ReopenReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	ret := _m.Called(ctx, receptionID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenReception")
+	}
+
+	var r0 domain.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) (domain.Reception, error)); ok {
+		return rf(ctx, receptionID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) domain.Reception); ok {
+		r0 = rf(ctx, receptionID, actor)
+	} else {
+		r0 = ret.Get(0).(domain.Reception)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r1 = rf(ctx, receptionID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndoLastDeletion provides a mock function with given fields: ctx, pvzID, actor
+func (_m *ReceptionService) UndoLastDeletion(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Product, error) {
+	ret := _m.Called(ctx, pvzID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoLastDeletion")
+	}
+
+	var r0 domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) (domain.Product, error)); ok {
+		return rf(ctx, pvzID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Actor) domain.Product); ok {
+		r0 = rf(ctx, pvzID, actor)
+	} else {
+		r0 = ret.Get(0).(domain.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.Actor) error); ok {
+		r1 = rf(ctx, pvzID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReceptionService creates a new instance of ReceptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceptionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceptionService {
+	mock := &ReceptionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+)
+
+// commonPasswords - встроенный список распространенных паролей
+//
+//go:embed common_passwords.txt
+var commonPasswords []byte
+
+// PasswordPolicy проверяет пароль при регистрации, смене и сбросе.
+type PasswordPolicy struct {
+	cfg      config.PasswordPolicyConfig
+	denylist map[string]struct{} // Запрещенные пароли в нижнем регистре
+}
+
+// NewPasswordPolicy - конструктор. Загружает встроенный список распространенных паролей
+// и, если задан, файл cfg.DenylistFile (по паролю в строке, строки с # - комментарии).
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig) (*PasswordPolicy, error) {
+	p := &PasswordPolicy{cfg: cfg, denylist: make(map[string]struct{})}
+	if err := p.loadDenylist(bytes.NewReader(commonPasswords)); err != nil {
+		return nil, fmt.Errorf("ошибка чтения встроенного списка паролей: %w", err)
+	}
+	if cfg.DenylistFile != "" {
+		f, err := os.Open(cfg.DenylistFile)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось открыть список запрещенных паролей %s: %w", cfg.DenylistFile, err)
+		}
+		defer f.Close()
+		if err := p.loadDenylist(f); err != nil {
+			return nil, fmt.Errorf("ошибка чтения списка запрещенных паролей %s: %w", cfg.DenylistFile, err)
+		}
+	}
+	return p, nil
+}
+
+func (p *PasswordPolicy) loadDenylist(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.denylist[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Validate возвращает ErrPasswordPolicy с перечнем нарушенных требований или nil.
+func (p *PasswordPolicy) Validate(password string) error {
+	var problems []string
+	if utf8.RuneCountInString(password) < p.cfg.MinLength {
+		problems = append(problems, fmt.Sprintf("не короче %d символов", p.cfg.MinLength))
+	}
+	if len(password) > p.cfg.MaxLength {
+		problems = append(problems, fmt.Sprintf("не длиннее %d байт", p.cfg.MaxLength))
+	}
+
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			special = true
+		}
+	}
+	if p.cfg.RequireUpper && !upper {
+		problems = append(problems, "заглавная буква")
+	}
+	if p.cfg.RequireLower && !lower {
+		problems = append(problems, "строчная буква")
+	}
+	if p.cfg.RequireDigit && !digit {
+		problems = append(problems, "цифра")
+	}
+	if p.cfg.RequireSpecial && !special {
+		problems = append(problems, "спецсимвол")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: требуется %s", domain.ErrPasswordPolicy, strings.Join(problems, ", "))
+	}
+
+	if _, denied := p.denylist[strings.ToLower(password)]; denied {
+		return fmt.Errorf("%w: пароль слишком распространен", domain.ErrPasswordPolicy)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy, err := NewPasswordPolicy(config.Default().PasswordPolicy)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{"Valid", "Passw0rd-42", true},
+		{"Valid Cyrillic", "Пароль2024", true},
+		{"Too Short", "Pa1", false},
+		{"Too Long", "Aa1" + strings.Repeat("x", 70), false},
+		{"No Upper", "passw0rd-42", false},
+		{"No Lower", "PASSW0RD-42", false},
+		{"No Digit", "Password-xx", false},
+		{"Common Password", "Password123", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Validate(tc.password)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrPasswordPolicy)
+			}
+		})
+	}
+
+	t.Run("Require Special", func(t *testing.T) {
+		cfg := config.Default().PasswordPolicy
+		cfg.RequireSpecial = true
+		strict, err := NewPasswordPolicy(cfg)
+		require.NoError(t, err)
+		assert.ErrorIs(t, strict.Validate("Passw0rd42"), domain.ErrPasswordPolicy)
+		assert.NoError(t, strict.Validate("Passw0rd-42"))
+	})
+}
+
+func TestNewPasswordPolicy_DenylistFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# локальный список\nPvz-Sklad-2024\n"), 0o600))
+
+	cfg := config.Default().PasswordPolicy
+	cfg.DenylistFile = path
+	policy, err := NewPasswordPolicy(cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, policy.Validate("pvz-sklad-2024"), domain.ErrPasswordPolicy)
+
+	cfg.DenylistFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = NewPasswordPolicy(cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+var (
+	// productTypeCodePattern - код типа: строчные буквы (в том числе кириллица), цифры, '-' и '_' (до 50 символов)
+	productTypeCodePattern = regexp.MustCompile(`^[\p{Ll}\p{N}_-]{1,50}$`)
+	// localePattern - ключ названия: язык (ru) или язык с регионом (en-US)
+	localePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+)
+
+// productTypeService - реализация интерфейса ProductTypeService.
+type productTypeService struct {
+	repo repository.ProductTypeRepository
+}
+
+// NewProductTypeService - конструктор сервиса справочника типов товаров.
+func NewProductTypeService(repo repository.ProductTypeRepository) ProductTypeService {
+	return &productTypeService{repo: repo}
+}
+
+// CreateProductType проверяет поля и добавляет тип товара в справочник.
+func (s *productTypeService) CreateProductType(ctx context.Context, pt domain.ProductTypeInfo) (domain.ProductTypeInfo, error) {
+	pt.Code = domain.ProductType(strings.TrimSpace(string(pt.Code)))
+	if !productTypeCodePattern.MatchString(string(pt.Code)) {
+		return domain.ProductTypeInfo{}, fmt.Errorf("%w: код должен состоять из строчных букв, цифр, '-' или '_' (до 50 символов)", domain.ErrProductTypeValidation)
+	}
+	if err := validateProductTypeFields(&pt); err != nil {
+		return domain.ProductTypeInfo{}, err
+	}
+
+	if err := s.repo.CreateProductType(ctx, pt); err != nil {
+		if errors.Is(err, repository.ErrProductTypeDuplicate) {
+			slog.WarnContext(ctx, "Попытка создать дубликат типа товара", slog.String("code", string(pt.Code)))
+			return domain.ProductTypeInfo{}, domain.ErrProductTypeAlreadyExists
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при создании типа товара", slog.String("code", string(pt.Code)), slog.Any("error", err))
+		return domain.ProductTypeInfo{}, fmt.Errorf("не удалось сохранить тип товара: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Тип товара добавлен в справочник", slog.String("code", string(pt.Code)))
+	// Перечитываем, чтобы вернуть created_at, выставленный БД
+	return s.GetProductType(ctx, pt.Code)
+}
+
+// GetProductType возвращает тип товара по коду.
+func (s *productTypeService) GetProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error) {
+	pt, err := s.repo.GetProductType(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductTypeNotFound) {
+			return domain.ProductTypeInfo{}, domain.ErrProductTypeNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения типа товара", slog.String("code", string(code)), slog.Any("error", err))
+		return domain.ProductTypeInfo{}, fmt.Errorf("не удалось получить тип товара: %w", err)
+	}
+	return pt, nil
+}
+
+// ListProductTypes возвращает типы товаров справочника.
+func (s *productTypeService) ListProductTypes(ctx context.Context, includeInactive bool) ([]domain.ProductTypeInfo, error) {
+	types, err := s.repo.ListProductTypes(ctx, includeInactive)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения списка типов товаров", slog.Any("error", err))
+		return nil, fmt.Errorf("не удалось получить список типов товаров: %w", err)
+	}
+	return types, nil
+}
+
+// UpdateProductType заменяет названия, активность и схему атрибутов типа (код неизменяем).
+// Новая схема применяется к товарам, добавленным после изменения; уже принятые товары не перепроверяются.
+func (s *productTypeService) UpdateProductType(ctx context.Context, pt domain.ProductTypeInfo) (domain.ProductTypeInfo, error) {
+	current, err := s.GetProductType(ctx, pt.Code)
+	if err != nil {
+		return domain.ProductTypeInfo{}, err
+	}
+	if err := validateProductTypeFields(&pt); err != nil {
+		return domain.ProductTypeInfo{}, err
+	}
+
+	if err := s.repo.UpdateProductType(ctx, pt); err != nil {
+		if errors.Is(err, repository.ErrProductTypeNotFound) {
+			return domain.ProductTypeInfo{}, domain.ErrProductTypeNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при обновлении типа товара", slog.String("code", string(pt.Code)), slog.Any("error", err))
+		return domain.ProductTypeInfo{}, fmt.Errorf("не удалось обновить тип товара: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Тип товара обновлен", slog.String("code", string(pt.Code)), slog.Bool("active", pt.IsActive), slog.Bool("has_schema", pt.AttributesSchema != nil))
+	pt.CreatedAt = current.CreatedAt
+	return pt, nil
+}
+
+// validateProductTypeFields проверяет названия и схему атрибутов и нормализует названия (TrimSpace).
+func validateProductTypeFields(pt *domain.ProductTypeInfo) error {
+	names := make(map[string]string, len(pt.Names))
+	for locale, name := range pt.Names {
+		name = strings.TrimSpace(name)
+		if !localePattern.MatchString(locale) || name == "" {
+			return fmt.Errorf("%w: некорректное название для локали %q", domain.ErrProductTypeValidation, locale)
+		}
+		names[locale] = name
+	}
+	if _, ok := names[domain.DefaultLocale]; !ok {
+		return fmt.Errorf("%w: требуется название на локали %q", domain.ErrProductTypeValidation, domain.DefaultLocale)
+	}
+	pt.Names = names
+
+	if pt.AttributesSchema != nil {
+		if err := pt.AttributesSchema.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
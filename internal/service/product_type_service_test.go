@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductTypeService_CreateProductType(t *testing.T) {
+	ctx := context.Background()
+	fragileSchema := &domain.AttributeSchema{
+		Type: "object",
+		Properties: map[string]domain.AttributeProperty{
+			"fragile": {Type: domain.AttributeTypeBoolean},
+		},
+	}
+	input := domain.ProductTypeInfo{
+		Code:             "посуда",
+		Names:            map[string]string{"ru": " Посуда ", "en": "Dishes"},
+		IsActive:         true,
+		AttributesSchema: fragileSchema,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.ProductTypeRepository)
+		typeService := NewProductTypeService(mockRepo)
+		stored := input
+		stored.Names = map[string]string{"ru": "Посуда", "en": "Dishes"}
+		stored.CreatedAt = time.Now()
+
+		mockRepo.On("CreateProductType", mock.Anything, mock.MatchedBy(func(pt domain.ProductTypeInfo) bool {
+			return pt.Code == "посуда" && pt.Names["ru"] == "Посуда" && pt.AttributesSchema == fragileSchema
+		})).Return(nil).Once()
+		mockRepo.On("GetProductType", mock.Anything, domain.ProductType("посуда")).Return(stored, nil).Once()
+
+		pt, err := typeService.CreateProductType(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, pt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Validation", func(t *testing.T) {
+		cases := map[string]domain.ProductTypeInfo{
+			"bad code":     {Code: "Посуда!", Names: map[string]string{"ru": "Посуда"}},
+			"no ru name":   {Code: "посуда", Names: map[string]string{"en": "Dishes"}},
+			"bad locale":   {Code: "посуда", Names: map[string]string{"ru": "Посуда", "english": "Dishes"}},
+			"empty schema": {Code: "посуда", Names: map[string]string{"ru": "Посуда"}, AttributesSchema: &domain.AttributeSchema{}},
+			"unknown attribute type": {Code: "посуда", Names: map[string]string{"ru": "Посуда"}, AttributesSchema: &domain.AttributeSchema{
+				Properties: map[string]domain.AttributeProperty{"weight": {Type: "float"}},
+			}},
+			"required not in properties": {Code: "посуда", Names: map[string]string{"ru": "Посуда"}, AttributesSchema: &domain.AttributeSchema{
+				Properties: map[string]domain.AttributeProperty{"weight": {Type: domain.AttributeTypeNumber}},
+				Required:   []string{"size"},
+			}},
+		}
+		for name, pt := range cases {
+			t.Run(name, func(t *testing.T) {
+				mockRepo := new(mocks.ProductTypeRepository)
+				typeService := NewProductTypeService(mockRepo)
+
+				_, err := typeService.CreateProductType(ctx, pt)
+
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrProductTypeValidation)
+				mockRepo.AssertNotCalled(t, "CreateProductType", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Fail - Duplicate", func(t *testing.T) {
+		mockRepo := new(mocks.ProductTypeRepository)
+		typeService := NewProductTypeService(mockRepo)
+
+		mockRepo.On("CreateProductType", mock.Anything, mock.Anything).Return(repository.ErrProductTypeDuplicate).Once()
+
+		_, err := typeService.CreateProductType(ctx, input)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrProductTypeAlreadyExists)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductTypeService_UpdateProductType(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Hour)
+	existing := domain.ProductTypeInfo{Code: domain.TypeShoes, Names: map[string]string{"ru": "Обувь"}, IsActive: true, CreatedAt: createdAt}
+
+	t.Run("Success - Deactivate", func(t *testing.T) {
+		mockRepo := new(mocks.ProductTypeRepository)
+		typeService := NewProductTypeService(mockRepo)
+		update := domain.ProductTypeInfo{Code: domain.TypeShoes, Names: map[string]string{"ru": "Обувь"}, IsActive: false}
+
+		mockRepo.On("GetProductType", mock.Anything, domain.TypeShoes).Return(existing, nil).Once()
+		mockRepo.On("UpdateProductType", mock.Anything, mock.MatchedBy(func(pt domain.ProductTypeInfo) bool {
+			return pt.Code == domain.TypeShoes && !pt.IsActive
+		})).Return(nil).Once()
+
+		pt, err := typeService.UpdateProductType(ctx, update)
+
+		require.NoError(t, err)
+		assert.False(t, pt.IsActive)
+		assert.Equal(t, createdAt, pt.CreatedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.ProductTypeRepository)
+		typeService := NewProductTypeService(mockRepo)
+
+		mockRepo.On("GetProductType", mock.Anything, domain.ProductType("нет")).Return(domain.ProductTypeInfo{}, repository.ErrProductTypeNotFound).Once()
+
+		_, err := typeService.UpdateProductType(ctx, domain.ProductTypeInfo{Code: "нет", Names: map[string]string{"ru": "Нет"}})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrProductTypeNotFound)
+		mockRepo.AssertNotCalled(t, "UpdateProductType", mock.Anything, mock.Anything)
+	})
+}
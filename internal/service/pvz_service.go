@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/Artem0405/pvz-service/internal/domain"
@@ -18,22 +19,50 @@ import (
 type pvzService struct {
 	pvzRepo       repository.PVZRepository
 	receptionRepo repository.ReceptionRepository
+	cityRepo      repository.CityRepository // Справочник городов, в которых разрешено открывать ПВЗ
 }
 
 // --- ИСПРАВЛЕНО: NewPVZService - конструктор ---
 // Возвращаемый тип - ИНТЕРФЕЙС PVZService
-func NewPVZService(pvzRepo repository.PVZRepository, receptionRepo repository.ReceptionRepository) PVZService {
+func NewPVZService(pvzRepo repository.PVZRepository, receptionRepo repository.ReceptionRepository, cityRepo repository.CityRepository) PVZService {
 	return &pvzService{ // Возвращаем указатель на структуру, реализующую интерфейс
 		pvzRepo:       pvzRepo,
 		receptionRepo: receptionRepo,
+		cityRepo:      cityRepo,
 	}
 }
 
-// CreatePVZ (код без изменений)
+// checkCity проверяет, что город есть в справочнике и активен.
+// Иначе возвращает ErrPVZInvalidCity со списком активных городов.
+func (s *pvzService) checkCity(ctx context.Context, name string) error {
+	city, err := s.cityRepo.GetCityByName(ctx, name)
+	if err == nil && city.IsActive {
+		return nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrCityNotFound) {
+		slog.ErrorContext(ctx, "Ошибка получения города из справочника", slog.String("город", name), slog.Any("error", err))
+		return fmt.Errorf("не удалось проверить город: %w", err)
+	}
+
+	activeCities, err := s.cityRepo.ListCities(ctx, false)
+	if err != nil {
+		slog.WarnContext(ctx, "Не удалось получить список активных городов для ответа", slog.Any("error", err))
+		return domain.ErrPVZInvalidCity
+	}
+	names := make([]string, 0, len(activeCities))
+	for _, c := range activeCities {
+		names = append(names, c.Name)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrPVZInvalidCity, strings.Join(names, ", "))
+}
+
+// CreatePVZ создает ПВЗ, если город есть в справочнике и активен.
 func (s *pvzService) CreatePVZ(ctx context.Context, input domain.PVZ) (domain.PVZ, error) {
-	if input.City != "Москва" && input.City != "Санкт-Петербург" && input.City != "Казань" {
-		slog.WarnContext(ctx, "Попытка создания ПВЗ с недопустимым городом", slog.String("город", input.City))
-		return domain.PVZ{}, errors.New("создание ПВЗ возможно только в городах: Москва, Санкт-Петербург, Казань")
+	if err := s.checkCity(ctx, input.City); err != nil {
+		if errors.Is(err, domain.ErrPVZInvalidCity) {
+			slog.WarnContext(ctx, "Попытка создания ПВЗ с недопустимым городом", slog.String("город", input.City))
+		}
+		return domain.PVZ{}, err
 	}
 
 	pvzToCreate := domain.PVZ{City: input.City}
@@ -49,6 +78,7 @@ func (s *pvzService) CreatePVZ(ctx context.Context, input domain.PVZ) (domain.PV
 		ID:               newID,
 		City:             input.City,
 		RegistrationDate: time.Now(),
+		IsActive:         true,
 	}
 	slog.InfoContext(ctx, "ПВЗ успешно создан", slog.String("pvz_id", newID.String()), slog.String("город", input.City))
 	return createdPVZ, nil
@@ -57,30 +87,13 @@ func (s *pvzService) CreatePVZ(ctx context.Context, input domain.PVZ) (domain.PV
 // --- ИСПРАВЛЕНО: GetPVZList - реализация метода ---
 // Сигнатура соответствует интерфейсу service.PVZService
 // Возвращаемый тип - GetPVZListResult (определенный выше или в domain)
-func (s *pvzService) GetPVZList(ctx context.Context, startDate, endDate *time.Time, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID) (GetPVZListResult, error) {
-	// Инициализируем структуру результата
-	result := GetPVZListResult{ // Используем тип GetPVZListResult
-		Receptions: make(map[uuid.UUID][]domain.Reception),
-		Products:   make(map[uuid.UUID][]domain.Product),
-		PVZs:       []domain.PVZ{},
-	}
-
-	// 1. Получаем ПВЗ
-	pvzList, err := s.pvzRepo.ListPVZs(ctx, limit, afterRegistrationDate, afterID) // Вызов репозитория соответствует интерфейсу
+func (s *pvzService) GetPVZList(ctx context.Context, startDate, endDate *time.Time, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (GetPVZListResult, error) {
+	// 1-2. Получаем страницу ПВЗ и курсор следующей страницы
+	result, err := s.ListPVZs(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
 	if err != nil {
-		slog.ErrorContext(ctx, "Ошибка получения списка ПВЗ из репозитория", "error", err)
-		return result, fmt.Errorf("не удалось получить список ПВЗ: %w", err)
-	}
-	result.PVZs = pvzList
-
-	// 2. Определяем курсор для следующей страницы
-	if len(pvzList) == limit {
-		lastPVZ := pvzList[len(pvzList)-1]
-		nextDate := lastPVZ.RegistrationDate         // Копируем значение
-		nextID := lastPVZ.ID                         // Копируем значение
-		result.NextAfterRegistrationDate = &nextDate // Присваиваем указатель полю структуры result
-		result.NextAfterID = &nextID                 // Присваиваем указатель полю структуры result
+		return result, err
 	}
+	pvzList := result.PVZs
 
 	// 3. Если ПВЗ нет, выходим
 	if len(pvzList) == 0 {
@@ -115,7 +128,7 @@ func (s *pvzService) GetPVZList(ctx context.Context, startDate, endDate *time.Ti
 
 	// 7. Получаем Товары
 	if len(receptionIDs) > 0 {
-		products, err := s.receptionRepo.ListProductsByReceptionIDs(ctx, receptionIDs)
+		products, err := s.receptionRepo.ListProductsByReceptionIDs(ctx, receptionIDs, false)
 		if err != nil {
 			slog.ErrorContext(ctx, "Ошибка получения товаров для приемок", "reception_ids", receptionIDs, "error", err)
 			return result, fmt.Errorf("не удалось получить товары: %w", err)
@@ -136,6 +149,110 @@ func (s *pvzService) GetPVZList(ctx context.Context, startDate, endDate *time.Ti
 	return result, nil
 }
 
+// ListPVZs возвращает страницу ПВЗ без приемок и товаров. Курсор следующей страницы есть,
+// только если страница заполнена полностью.
+func (s *pvzService) ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (GetPVZListResult, error) {
+	result := GetPVZListResult{
+		Receptions: make(map[uuid.UUID][]domain.Reception),
+		Products:   make(map[uuid.UUID][]domain.Product),
+		PVZs:       []domain.PVZ{},
+	}
+
+	pvzList, err := s.pvzRepo.ListPVZs(ctx, limit, afterRegistrationDate, afterID, includeInactive, assignedTo)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения списка ПВЗ из репозитория", "error", err)
+		return result, fmt.Errorf("не удалось получить список ПВЗ: %w", err)
+	}
+	result.PVZs = pvzList
+
+	if len(pvzList) == limit && limit > 0 {
+		lastPVZ := pvzList[len(pvzList)-1]
+		nextDate, nextID := lastPVZ.RegistrationDate, lastPVZ.ID
+		result.NextAfterRegistrationDate = &nextDate
+		result.NextAfterID = &nextID
+	}
+	return result, nil
+}
+
+// GetPVZ возвращает ПВЗ по ID.
+func (s *pvzService) GetPVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	pvz, err := s.pvzRepo.GetPVZByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPVZNotFound) {
+			return domain.PVZ{}, domain.ErrPVZNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения ПВЗ", slog.String("pvz_id", id.String()), slog.Any("error", err))
+		return domain.PVZ{}, fmt.Errorf("не удалось получить ПВЗ: %w", err)
+	}
+	return pvz, nil
+}
+
+// UpdatePVZ изменяет поля ПВЗ. Новый город проверяется так же, как при создании.
+func (s *pvzService) UpdatePVZ(ctx context.Context, id uuid.UUID, update domain.PVZUpdate) (domain.PVZ, error) {
+	pvz, err := s.GetPVZ(ctx, id)
+	if err != nil {
+		return domain.PVZ{}, err
+	}
+
+	if update.City != nil && *update.City != pvz.City {
+		if err := s.checkCity(ctx, *update.City); err != nil {
+			if errors.Is(err, domain.ErrPVZInvalidCity) {
+				slog.WarnContext(ctx, "Попытка сменить город ПВЗ на недопустимый", slog.String("pvz_id", id.String()), slog.String("город", *update.City))
+			}
+			return domain.PVZ{}, err
+		}
+		pvz.City = *update.City
+	}
+	if update.StrictLIFO != nil {
+		pvz.StrictLIFO = *update.StrictLIFO
+	}
+
+	if err := s.pvzRepo.UpdatePVZ(ctx, pvz); err != nil {
+		if errors.Is(err, repository.ErrPVZNotFound) {
+			return domain.PVZ{}, domain.ErrPVZNotFound // Удален между чтением и записью
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при обновлении ПВЗ", slog.String("pvz_id", id.String()), slog.Any("error", err))
+		return domain.PVZ{}, fmt.Errorf("не удалось обновить ПВЗ: %w", err)
+	}
+
+	slog.InfoContext(ctx, "ПВЗ обновлен", slog.String("pvz_id", id.String()), slog.String("город", pvz.City), slog.Bool("strict_lifo", pvz.StrictLIFO))
+	return pvz, nil
+}
+
+// DeactivatePVZ мягко деактивирует ПВЗ. Открытую приемку можно будет закрыть как обычно,
+// новые приемки receptionService.InitiateReception не откроет. Повторный вызов не меняет состояние.
+func (s *pvzService) DeactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	return s.setActive(ctx, id, false)
+}
+
+// ReactivatePVZ возвращает ПВЗ в работу. Повторный вызов не меняет состояние.
+func (s *pvzService) ReactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error) {
+	return s.setActive(ctx, id, true)
+}
+
+// setActive - общая часть DeactivatePVZ / ReactivatePVZ.
+func (s *pvzService) setActive(ctx context.Context, id uuid.UUID, active bool) (domain.PVZ, error) {
+	pvz, err := s.GetPVZ(ctx, id)
+	if err != nil {
+		return domain.PVZ{}, err
+	}
+	if pvz.IsActive == active {
+		return pvz, nil
+	}
+
+	if err := s.pvzRepo.SetPVZActive(ctx, id, active); err != nil {
+		if errors.Is(err, repository.ErrPVZNotFound) {
+			return domain.PVZ{}, domain.ErrPVZNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка репозитория при смене активности ПВЗ", slog.String("pvz_id", id.String()), slog.Bool("active", active), slog.Any("error", err))
+		return domain.PVZ{}, fmt.Errorf("не удалось изменить активность ПВЗ: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Изменена активность ПВЗ", slog.String("pvz_id", id.String()), slog.Bool("active", active))
+	// Перечитываем, чтобы вернуть deactivated_at, выставленный БД
+	return s.GetPVZ(ctx, id)
+}
+
 // --- УДАЛИТЕ ЭТОТ БЛОК (СТРОКИ ~155 И ДАЛЕЕ), ОН ДУБЛИРУЕТ КОНСТРУКТОР ---
 /*
 func NewPVZService(pvzRepo repository.PVZRepository, receptionRepo repository.ReceptionRepository) *pvzService {
@@ -7,7 +7,7 @@ import (
 	"time" // Нужен для тестов с датами
 
 	"github.com/Artem0405/pvz-service/internal/domain"
-	// Нужен для кастомных ошибок репозитория
+	"github.com/Artem0405/pvz-service/internal/repository" // Нужен для ошибок репозитория
 	// --- ИСПРАВЛЕНО: Импорт моков ---
 	"github.com/Artem0405/pvz-service/internal/repository/mocks"
 	"github.com/google/uuid"
@@ -16,6 +16,29 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testCities - справочник городов, как после миграции 000009 (+ один неактивный город).
+var testCities = []domain.City{
+	{Code: "msk", Name: "Москва", Timezone: "Europe/Moscow", IsActive: true},
+	{Code: "spb", Name: "Санкт-Петербург", Timezone: "Europe/Moscow", IsActive: true},
+	{Code: "kzn", Name: "Казань", Timezone: "Europe/Moscow", IsActive: true},
+	{Code: "nsk", Name: "Новосибирск", Timezone: "Asia/Novosibirsk", IsActive: false},
+}
+
+// newTestCityRepo возвращает мок справочника городов, отвечающий по testCities.
+func newTestCityRepo() *mocks.CityRepository {
+	cityRepo := new(mocks.CityRepository)
+	cityRepo.On("GetCityByName", mock.Anything, mock.Anything).Return(func(_ context.Context, name string) (domain.City, error) {
+		for _, c := range testCities {
+			if c.Name == name {
+				return c, nil
+			}
+		}
+		return domain.City{}, repository.ErrCityNotFound
+	}).Maybe()
+	cityRepo.On("ListCities", mock.Anything, false).Return(testCities[:3], nil).Maybe()
+	return cityRepo
+}
+
 // TestPVZService_CreatePVZ остается без изменений
 func TestPVZService_CreatePVZ(t *testing.T) {
 	ctx := context.Background()
@@ -24,7 +47,7 @@ func TestPVZService_CreatePVZ(t *testing.T) {
 		// Используем правильные типы моков
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		inputPVZ := domain.PVZ{City: "Москва"}
 		expectedID := uuid.New()
@@ -44,21 +67,51 @@ func TestPVZService_CreatePVZ(t *testing.T) {
 	t.Run("Invalid City", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		inputPVZ := domain.PVZ{City: "Рязань"}
 		_, err := pvzService.CreatePVZ(ctx, inputPVZ)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "создание ПВЗ возможно только в городах")
+		assert.Contains(t, err.Error(), "Москва, Санкт-Петербург, Казань")
 		mockPVZRepo.AssertNotCalled(t, "CreatePVZ", mock.Anything, mock.Anything)
 		mockReceptionRepo.AssertExpectations(t)
 	})
 
+	t.Run("Inactive City", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		_, err := pvzService.CreatePVZ(ctx, domain.PVZ{City: "Новосибирск"})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZInvalidCity)
+		assert.NotContains(t, err.Error(), "Новосибирск")
+		mockPVZRepo.AssertNotCalled(t, "CreatePVZ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("City Repository Error", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		cityRepo := new(mocks.CityRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), cityRepo)
+		repoError := errors.New("cities table unavailable")
+
+		cityRepo.On("GetCityByName", mock.Anything, "Москва").Return(domain.City{}, repoError).Once()
+
+		_, err := pvzService.CreatePVZ(ctx, domain.PVZ{City: "Москва"})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+		assert.NotErrorIs(t, err, domain.ErrPVZInvalidCity)
+		mockPVZRepo.AssertNotCalled(t, "CreatePVZ", mock.Anything, mock.Anything)
+		cityRepo.AssertExpectations(t)
+	})
+
 	t.Run("Repository Error on Create", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		inputPVZ := domain.PVZ{City: "Казань"}
 		repoError := errors.New("database connection lost")
@@ -102,7 +155,7 @@ func TestPVZService_GetPVZList(t *testing.T) {
 	t.Run("Success - Basic List No Filters First Page", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 10
 		var startDate, endDate *time.Time
@@ -112,17 +165,19 @@ func TestPVZService_GetPVZList(t *testing.T) {
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs ---
 		mockPVZRepo.On(
 			"ListPVZs",
-			mock.Anything, // ctx
-			limit,         // limit (int)
-			cursorDate,    // afterRegistrationDate (*time.Time)
-			cursorID,      // afterID (*uuid.UUID)
+			mock.Anything,     // ctx
+			limit,             // limit (int)
+			cursorDate,        // afterRegistrationDate (*time.Time)
+			cursorID,          // afterID (*uuid.UUID)
+			false,             // includeInactive
+			(*uuid.UUID)(nil), // assignedTo
 		).Return(mockPVZs, nil).Once() // Возвращает []domain.PVZ, error
 
 		mockReceptionRepo.On("ListReceptionsByPVZIDs", mock.Anything, []uuid.UUID{pvzID1, pvzID2}, startDate, endDate).Return(mockReceptions, nil).Once()
-		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{receptionID1, receptionID2}).Return(mockProducts, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{receptionID1, receptionID2}, false).Return(mockProducts, nil).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList ---
-		result, err := pvzService.GetPVZList(ctx, startDate, endDate, limit, cursorDate, cursorID)
+		result, err := pvzService.GetPVZList(ctx, startDate, endDate, limit, cursorDate, cursorID, false, nil)
 
 		// Assert
 		assert.NoError(t, err)
@@ -142,7 +197,7 @@ func TestPVZService_GetPVZList(t *testing.T) {
 	t.Run("Success - Keyset Pagination Second Page", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 1
 		cursorDate := mockPVZs[1].RegistrationDate // Курсор на второй (последний в mockPVZs)
@@ -152,12 +207,12 @@ func TestPVZService_GetPVZList(t *testing.T) {
 		expectedReceptionIDsForProducts := []uuid.UUID{receptionID1}
 
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs с курсором ---
-		mockPVZRepo.On("ListPVZs", mock.Anything, limit, &cursorDate, &cursorID).Return(expectedPVZsPage2, nil).Once()
+		mockPVZRepo.On("ListPVZs", mock.Anything, limit, &cursorDate, &cursorID, false, (*uuid.UUID)(nil)).Return(expectedPVZsPage2, nil).Once()
 		mockReceptionRepo.On("ListReceptionsByPVZIDs", mock.Anything, []uuid.UUID{pvzID1}, (*time.Time)(nil), (*time.Time)(nil)).Return([]domain.Reception{mockReceptions[0]}, nil).Once()
-		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, expectedReceptionIDsForProducts).Return([]domain.Product{mockProducts[0]}, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, expectedReceptionIDsForProducts, false).Return([]domain.Product{mockProducts[0]}, nil).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList с курсором ---
-		result, err := pvzService.GetPVZList(ctx, nil, nil, limit, &cursorDate, &cursorID)
+		result, err := pvzService.GetPVZList(ctx, nil, nil, limit, &cursorDate, &cursorID, false, nil)
 
 		// Assert
 		assert.NoError(t, err)
@@ -176,45 +231,45 @@ func TestPVZService_GetPVZList(t *testing.T) {
 	t.Run("Success - No PVZs Found", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 10
 		var cursorDate *time.Time = nil
 		var cursorID *uuid.UUID = nil
 
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs ---
-		mockPVZRepo.On("ListPVZs", mock.Anything, limit, cursorDate, cursorID).Return([]domain.PVZ{}, nil).Once()
+		mockPVZRepo.On("ListPVZs", mock.Anything, limit, cursorDate, cursorID, false, (*uuid.UUID)(nil)).Return([]domain.PVZ{}, nil).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList ---
-		result, err := pvzService.GetPVZList(ctx, nil, nil, limit, cursorDate, cursorID)
+		result, err := pvzService.GetPVZList(ctx, nil, nil, limit, cursorDate, cursorID, false, nil)
 
 		// Assert
 		assert.NoError(t, err)
 		assert.Empty(t, result.PVZs)
 		// --- УДАЛЕНО: Проверка TotalPVZs ---
-		assert.Nil(t, result.Receptions)
-		assert.Nil(t, result.Products)
+		assert.Empty(t, result.Receptions)
+		assert.Empty(t, result.Products)
 		assert.Nil(t, result.NextAfterID)
 		assert.Nil(t, result.NextAfterRegistrationDate)
 		mockPVZRepo.AssertExpectations(t)
 		mockReceptionRepo.AssertNotCalled(t, "ListReceptionsByPVZIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
-		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	// --- Тест 4: Ошибка от PVZ репозитория ---
 	t.Run("Fail - PVZ Repo Error", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 10
 		repoError := errors.New("pvz repo failed")
 
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs ---
-		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil)).Return(nil, repoError).Once()
+		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).Return(nil, repoError).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList ---
-		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil)
+		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil, false, nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -222,24 +277,24 @@ func TestPVZService_GetPVZList(t *testing.T) {
 		assert.Contains(t, err.Error(), "не удалось получить список ПВЗ")
 		mockPVZRepo.AssertExpectations(t)
 		mockReceptionRepo.AssertNotCalled(t, "ListReceptionsByPVZIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
-		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	// --- Тест 5: Ошибка от Reception репозитория (при получении приемок) ---
 	t.Run("Fail - Reception Repo Error on Receptions", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 10
 		repoError := errors.New("reception repo failed on list")
 
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs ---
-		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil)).Return(mockPVZs, nil).Once()
+		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).Return(mockPVZs, nil).Once()
 		mockReceptionRepo.On("ListReceptionsByPVZIDs", mock.Anything, []uuid.UUID{pvzID1, pvzID2}, (*time.Time)(nil), (*time.Time)(nil)).Return(nil, repoError).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList ---
-		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil)
+		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil, false, nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -247,25 +302,25 @@ func TestPVZService_GetPVZList(t *testing.T) {
 		assert.Contains(t, err.Error(), "не удалось получить приемки")
 		mockPVZRepo.AssertExpectations(t)
 		mockReceptionRepo.AssertExpectations(t)
-		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	// --- Тест 6: Ошибка от Reception репозитория (при получении товаров) ---
 	t.Run("Fail - Reception Repo Error on Products", func(t *testing.T) {
 		mockPVZRepo := new(mocks.PVZRepository)             // ИСПРАВЛЕНО
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo)
+		pvzService := NewPVZService(mockPVZRepo, mockReceptionRepo, newTestCityRepo())
 
 		limit := 10
 		repoError := errors.New("reception repo failed on products")
 
 		// --- ИСПРАВЛЕНО: Настройка мока ListPVZs ---
-		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil)).Return(mockPVZs, nil).Once()
+		mockPVZRepo.On("ListPVZs", mock.Anything, limit, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).Return(mockPVZs, nil).Once()
 		mockReceptionRepo.On("ListReceptionsByPVZIDs", mock.Anything, []uuid.UUID{pvzID1, pvzID2}, (*time.Time)(nil), (*time.Time)(nil)).Return(mockReceptions, nil).Once()
-		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{receptionID1, receptionID2}).Return(nil, repoError).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{receptionID1, receptionID2}, false).Return(nil, repoError).Once()
 
 		// --- ИСПРАВЛЕНО: Вызов GetPVZList ---
-		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil)
+		_, err := pvzService.GetPVZList(ctx, nil, nil, limit, nil, nil, false, nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -275,3 +330,206 @@ func TestPVZService_GetPVZList(t *testing.T) {
 		mockReceptionRepo.AssertExpectations(t)
 	})
 }
+
+func TestPVZService_ListPVZs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	pvzs := []domain.PVZ{
+		{ID: uuid.New(), City: "Москва", RegistrationDate: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), City: "Казань", RegistrationDate: now.Add(-1 * time.Hour)},
+	}
+
+	t.Run("Full Page Has Cursor", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		// Приемки не читаются: ReceptionRepository без ожиданий
+		pvzService := NewPVZService(mockPVZRepo, mocks.NewReceptionRepository(t), newTestCityRepo())
+		mockPVZRepo.On("ListPVZs", mock.Anything, 2, (*time.Time)(nil), (*uuid.UUID)(nil), true, (*uuid.UUID)(nil)).Return(pvzs, nil).Once()
+
+		result, err := pvzService.ListPVZs(ctx, 2, nil, nil, true, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, pvzs, result.PVZs)
+		assert.Empty(t, result.Receptions)
+		require.NotNil(t, result.NextAfterID)
+		assert.Equal(t, pvzs[1].ID, *result.NextAfterID)
+		assert.Equal(t, pvzs[1].RegistrationDate, *result.NextAfterRegistrationDate)
+	})
+
+	t.Run("Partial Page Has No Cursor", func(t *testing.T) {
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		pvzService := NewPVZService(mockPVZRepo, mocks.NewReceptionRepository(t), newTestCityRepo())
+		mockPVZRepo.On("ListPVZs", mock.Anything, 3, (*time.Time)(nil), (*uuid.UUID)(nil), false, (*uuid.UUID)(nil)).Return(pvzs, nil).Once()
+
+		result, err := pvzService.ListPVZs(ctx, 3, nil, nil, false, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, result.PVZs, 2)
+		assert.Nil(t, result.NextAfterID)
+		assert.Nil(t, result.NextAfterRegistrationDate)
+	})
+}
+
+func TestPVZService_GetPVZ(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+		expected := domain.PVZ{ID: pvzID, City: "Москва", IsActive: true, RegistrationDate: time.Now()}
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(expected, nil).Once()
+
+		pvz, err := pvzService.GetPVZ(ctx, pvzID)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, pvz)
+		mockPVZRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(domain.PVZ{}, repository.ErrPVZNotFound).Once()
+
+		_, err := pvzService.GetPVZ(ctx, pvzID)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZNotFound)
+		mockPVZRepo.AssertExpectations(t)
+	})
+}
+
+func TestPVZService_UpdatePVZ(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	existing := domain.PVZ{ID: pvzID, City: "Москва", IsActive: true}
+
+	t.Run("Success - Change City", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+		city := "Казань"
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(existing, nil).Once()
+		mockPVZRepo.On("UpdatePVZ", mock.Anything, mock.MatchedBy(func(p domain.PVZ) bool {
+			return p.ID == pvzID && p.City == city
+		})).Return(nil).Once()
+
+		pvz, err := pvzService.UpdatePVZ(ctx, pvzID, domain.PVZUpdate{City: &city})
+
+		require.NoError(t, err)
+		assert.Equal(t, city, pvz.City)
+		mockPVZRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Enable Strict LIFO", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+		strict := true
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(existing, nil).Once()
+		mockPVZRepo.On("UpdatePVZ", mock.Anything, mock.MatchedBy(func(p domain.PVZ) bool {
+			return p.ID == pvzID && p.City == existing.City && p.StrictLIFO
+		})).Return(nil).Once()
+
+		pvz, err := pvzService.UpdatePVZ(ctx, pvzID, domain.PVZUpdate{StrictLIFO: &strict})
+
+		require.NoError(t, err)
+		assert.True(t, pvz.StrictLIFO)
+		mockPVZRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Invalid City", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+		city := "Рязань"
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(existing, nil).Once()
+
+		_, err := pvzService.UpdatePVZ(ctx, pvzID, domain.PVZUpdate{City: &city})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZInvalidCity)
+		mockPVZRepo.AssertNotCalled(t, "UpdatePVZ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+		city := "Казань"
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(domain.PVZ{}, repository.ErrPVZNotFound).Once()
+
+		_, err := pvzService.UpdatePVZ(ctx, pvzID, domain.PVZUpdate{City: &city})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZNotFound)
+		mockPVZRepo.AssertNotCalled(t, "UpdatePVZ", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPVZService_DeactivateReactivatePVZ(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	deactivatedAt := time.Now()
+	active := domain.PVZ{ID: pvzID, City: "Москва", IsActive: true}
+	inactive := domain.PVZ{ID: pvzID, City: "Москва", IsActive: false, DeactivatedAt: &deactivatedAt}
+
+	t.Run("Success - Deactivate", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(active, nil).Once()
+		mockPVZRepo.On("SetPVZActive", mock.Anything, pvzID, false).Return(nil).Once()
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(inactive, nil).Once()
+
+		pvz, err := pvzService.DeactivatePVZ(ctx, pvzID)
+
+		require.NoError(t, err)
+		assert.False(t, pvz.IsActive)
+		assert.NotNil(t, pvz.DeactivatedAt)
+		mockPVZRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Deactivate Already Inactive Is No-op", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(inactive, nil).Once()
+
+		pvz, err := pvzService.DeactivatePVZ(ctx, pvzID)
+
+		require.NoError(t, err)
+		assert.Equal(t, inactive, pvz)
+		mockPVZRepo.AssertNotCalled(t, "SetPVZActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Reactivate", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(inactive, nil).Once()
+		mockPVZRepo.On("SetPVZActive", mock.Anything, pvzID, true).Return(nil).Once()
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(active, nil).Once()
+
+		pvz, err := pvzService.ReactivatePVZ(ctx, pvzID)
+
+		require.NoError(t, err)
+		assert.True(t, pvz.IsActive)
+		assert.Nil(t, pvz.DeactivatedAt)
+		mockPVZRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockPVZRepo := new(mocks.PVZRepository)
+		pvzService := NewPVZService(mockPVZRepo, new(mocks.ReceptionRepository), newTestCityRepo())
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, pvzID).Return(domain.PVZ{}, repository.ErrPVZNotFound).Once()
+
+		_, err := pvzService.DeactivatePVZ(ctx, pvzID)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZNotFound)
+	})
+}
@@ -5,40 +5,133 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	// import "log" // <-- Удалить
 	// <-- Добавить
 
 	"github.com/Artem0405/pvz-service/internal/domain"
+	mmetrics "github.com/Artem0405/pvz-service/internal/metrics"
 	"github.com/Artem0405/pvz-service/internal/repository"
 	"github.com/google/uuid"
 )
 
+var (
+	// barcodePattern - штрихкод/SKU: печатные ASCII символы без пробелов (EAN, Code128, внутренние SKU), до 64 символов
+	barcodePattern = regexp.MustCompile(`^[\x21-\x7E]{1,64}$`)
+	// orderIDPattern - номер внешнего заказа: любые печатные символы без пробелов, до 100 символов
+	orderIDPattern = regexp.MustCompile(`^[^\p{C}\s]{1,100}$`)
+)
+
+// productsByBarcodeLimit - сколько товаров с одним штрихкодом отдает FindProductsByBarcode
+const productsByBarcodeLimit = 100
+
+// maxDeletionReasonLength - ограничение длины причины удаления товара
+const maxDeletionReasonLength = 500
+
+// lifoDeletionReason - причина в аудите для DeleteLastProduct, где клиент ее не передает
+const lifoDeletionReason = "удаление последнего товара (LIFO)"
+
+// errBatchRolledBack - пачка allOrNothing отклонена: транзакция откатывается,
+// но AddProductsBatch возвращает клиенту результаты по товарам, а не ошибку.
+var errBatchRolledBack = errors.New("пачка товаров отклонена целиком")
+
 // receptionService - реализация ReceptionService
 type receptionService struct {
-	repo repository.ReceptionRepository // Зависимость от репозитория приемок
-	// Возможно, понадобится PVZ репозиторий для проверки существования PVZ ID
-	// pvzRepo repository.PVZRepository
+	repo     repository.ReceptionRepository   // Зависимость от репозитория приемок
+	pvzRepo  repository.PVZRepository         // Проверка существования и активности ПВЗ перед открытием приемки
+	typeRepo repository.ProductTypeRepository // Справочник типов товаров: проверка типа и атрибутов в AddProduct
+	access   repository.AssignmentRepository  // Назначения сотрудников на ПВЗ (user_pvz)
+	tx       repository.Transactor            // Операции с приемкой выполняются в одной транзакции
 }
 
 // NewReceptionService - конструктор
-func NewReceptionService(repo repository.ReceptionRepository) *receptionService {
+func NewReceptionService(repo repository.ReceptionRepository, pvzRepo repository.PVZRepository, typeRepo repository.ProductTypeRepository, access repository.AssignmentRepository, tx repository.Transactor) *receptionService {
 	return &receptionService{
-		repo: repo,
+		repo:     repo,
+		pvzRepo:  pvzRepo,
+		typeRepo: typeRepo,
+		access:   access,
+		tx:       tx,
+	}
+}
+
+// hasAllPVZAccess - модераторы и сам сервис (domain.SystemActor) работают с приемками любых ПВЗ.
+func hasAllPVZAccess(actor domain.Actor) bool {
+	return actor.Role == domain.RoleModerator || actor.Role == domain.RoleSystem
+}
+
+// checkPVZAccess проверяет, что actor может работать с приемками ПВЗ: модераторы и сервис - любых,
+// остальные - только назначенных им (user_pvz). Токен без пользователя (сотрудник из /dummyLogin)
+// назначений не имеет, поэтому доступа к ПВЗ не дает.
+func (s *receptionService) checkPVZAccess(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) error {
+	if hasAllPVZAccess(actor) {
+		return nil
+	}
+	if actor.UserID == uuid.Nil {
+		slog.WarnContext(ctx, "Токен без пользователя не дает доступа к приемкам ПВЗ", "pvz_id", pvzID, "role", actor.Role)
+		return domain.ErrPVZNotAssigned
+	}
+	assigned, err := s.access.IsAssigned(ctx, actor.UserID, pvzID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка проверки назначения на ПВЗ", "pvz_id", pvzID, "user_id", actor.UserID, "error", err)
+		return fmt.Errorf("ошибка проверки назначения на ПВЗ: %w", err)
+	}
+	if !assigned {
+		slog.WarnContext(ctx, "Сотрудник обращается к приемкам чужого ПВЗ", "pvz_id", pvzID, "user_id", actor.UserID)
+		return domain.ErrPVZNotAssigned
 	}
+	return nil
+}
+
+// InitiateReception - начинает новую приемку.
+// Проверка и создание идут в одной транзакции; если параллельный запрос успел
+// открыть приемку раньше, уникальный индекс в БД вернет ErrReceptionAlreadyOpen.
+func (s *receptionService) InitiateReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return domain.Reception{}, err
+	}
+	var createdReception domain.Reception
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdReception, err = s.initiateReception(ctx, pvzID, actor)
+		return err
+	})
+	if err != nil {
+		return domain.Reception{}, err
+	}
+	return createdReception, nil
 }
 
-// InitiateReception - начинает новую приемку
-func (s *receptionService) InitiateReception(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error) {
+// initiateReception - тело InitiateReception, выполняется внутри транзакции
+func (s *receptionService) initiateReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	// ПВЗ должен существовать и быть активным. Внутри транзакции строка ПВЗ заблокирована
+	// от изменения, поэтому параллельная деактивация дождется создания приемки.
+	pvz, err := s.pvzRepo.GetPVZByID(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPVZNotFound) {
+			slog.WarnContext(ctx, "Попытка начать приемку в несуществующем ПВЗ", "pvz_id", pvzID)
+			return domain.Reception{}, domain.ErrPVZNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка при проверке ПВЗ", "pvz_id", pvzID, "error", err)
+		return domain.Reception{}, fmt.Errorf("ошибка проверки ПВЗ: %w", err)
+	}
+	if !pvz.IsActive {
+		slog.WarnContext(ctx, "Попытка начать приемку в деактивированном ПВЗ", "pvz_id", pvzID)
+		return domain.Reception{}, domain.ErrPVZInactive
+	}
+
 	// Проверяем, нет ли уже открытой приемки для этого ПВЗ
-	_, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
+	_, err = s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
 
 	// Обрабатываем результат проверки
 	if err == nil {
 		// Ошибки нет => Найдена открытая приемка! Нельзя начать новую.
 		slog.WarnContext(ctx, "Попытка начать новую приемку при наличии открытой", "pvz_id", pvzID)
-		return domain.Reception{}, errors.New("предыдущая приемка для этого ПВЗ еще не закрыта")
+		return domain.Reception{}, domain.ErrReceptionAlreadyOpen
 	}
 
 	// Если ошибка - это НЕ "не найдено", значит, произошла другая проблема при проверке
@@ -49,38 +142,70 @@ func (s *receptionService) InitiateReception(ctx context.Context, pvzID uuid.UUI
 
 	// Если мы здесь, значит err == repository.ErrReceptionNotFound - можно создавать новую
 
-	// TODO: Опционально - проверить, существует ли сам pvzID, если добавить pvzRepo
-
 	// Создаем новую запись о приемке
 	newReception := domain.Reception{
-		PVZID:  pvzID,
-		Status: domain.StatusInProgress, // Устанавливается по умолчанию
+		PVZID:     pvzID,
+		Status:    domain.StatusInProgress, // Устанавливается по умолчанию
+		CreatedBy: actor.KnownUserID(),
 		// DateTime установится в БД по умолчанию
 	}
 
 	createdID, err := s.repo.CreateReception(ctx, newReception)
 	if err != nil {
+		// Параллельный запрос успел открыть приемку между проверкой и вставкой
+		if errors.Is(err, repository.ErrReceptionAlreadyOpen) {
+			slog.WarnContext(ctx, "Параллельная попытка начать приемку отклонена уникальным индексом", "pvz_id", pvzID)
+			return domain.Reception{}, domain.ErrReceptionAlreadyOpen
+		}
 		slog.ErrorContext(ctx, "Ошибка репозитория при создании приемки", "pvz_id", pvzID, "error", err)
 		return domain.Reception{}, fmt.Errorf("не удалось создать приемку: %w", err)
 	}
 
 	// Формируем ответ API (ID и PVZID уже есть, добавим примерное время)
 	createdReception := domain.Reception{
-		ID:       createdID,
-		PVZID:    pvzID,
-		Status:   domain.StatusInProgress,
-		DateTime: time.Now(), // Примерное время для ответа
+		ID:        createdID,
+		PVZID:     pvzID,
+		Status:    domain.StatusInProgress,
+		DateTime:  time.Now(), // Примерное время для ответа
+		CreatedBy: newReception.CreatedBy,
 	}
-	slog.InfoContext(ctx, "Приемка успешно создана", "reception_id", createdID, "pvz_id", pvzID)
+	slog.InfoContext(ctx, "Приемка успешно создана", "reception_id", createdID, "pvz_id", pvzID, "user_id", actor.UserID)
 	return createdReception, nil
 }
 
-// AddProduct - добавляет товар в последнюю открытую приемку для указанного ПВЗ
-func (s *receptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType) (domain.Product, error) {
-	// 1. Проверяем валидность типа товара (хотя хендлер тоже должен проверять)
-	if productType != domain.TypeElectronics && productType != domain.TypeClothes && productType != domain.TypeShoes {
-		slog.WarnContext(ctx, "Попытка добавить товар недопустимого типа", "pvz_id", pvzID, "type", productType)
-		return domain.Product{}, errors.New("недопустимый тип товара")
+// AddProduct - добавляет товар в последнюю открытую приемку для указанного ПВЗ.
+// Приемка блокируется (FOR UPDATE) до конца транзакции, поэтому товар не попадет в закрываемую приемку.
+func (s *receptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, input domain.ProductInput) (domain.Product, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return domain.Product{}, err
+	}
+	var result domain.Product
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.addProduct(ctx, pvzID, actor, input)
+		return err
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+	mmetrics.ProductsAddedTotal.Inc()
+	return result, nil
+}
+
+// addProduct - тело AddProduct, выполняется внутри транзакции
+func (s *receptionService) addProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, input domain.ProductInput) (domain.Product, error) {
+	productType := input.Type
+
+	// 1. Проверяем штрихкод, номер заказа, тип товара и его атрибуты по справочнику
+	input.Barcode, input.OrderID = strings.TrimSpace(input.Barcode), strings.TrimSpace(input.OrderID)
+	if err := validateProductIdentity(input); err != nil {
+		return domain.Product{}, err
+	}
+	if err := s.checkProductInput(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrInvalidProductType) || errors.Is(err, domain.ErrInvalidProductAttributes) {
+			slog.WarnContext(ctx, "Попытка добавить товар с недопустимым типом или атрибутами", "pvz_id", pvzID, "type", productType, "error", err)
+		}
+		return domain.Product{}, err
 	}
 
 	// 2. Находим последнюю открытую приемку для этого ПВЗ
@@ -88,7 +213,7 @@ func (s *receptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, prod
 	if err != nil {
 		if errors.Is(err, repository.ErrReceptionNotFound) {
 			slog.WarnContext(ctx, "Попытка добавить товар без открытой приемки", "pvz_id", pvzID)
-			return domain.Product{}, errors.New("нет открытой приемки для данного ПВЗ, чтобы добавить товар")
+			return domain.Product{}, fmt.Errorf("%w, чтобы добавить товар", domain.ErrNoOpenReception)
 		}
 		// Другая ошибка при поиске приемки
 		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки", "pvz_id", pvzID, "error", err)
@@ -100,12 +225,20 @@ func (s *receptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, prod
 	productToCreate := domain.Product{
 		ReceptionID: openReception.ID, // Связываем с найденной приемкой
 		Type:        productType,
+		Barcode:     input.Barcode,
+		OrderID:     input.OrderID,
+		Attributes:  input.Attributes,
+		CreatedBy:   actor.KnownUserID(),
 		// ID и DateTimeAdded будут сгенерированы БД/репозиторием
 	}
 
 	// 4. Вызываем репозиторий для сохранения товара
 	newProductID, err := s.repo.AddProductToReception(ctx, productToCreate)
 	if err != nil {
+		if errors.Is(err, repository.ErrProductBarcodeDuplicate) {
+			slog.WarnContext(ctx, "Повторное сканирование штрихкода в приемке", "reception_id", openReception.ID, "barcode", input.Barcode)
+			return domain.Product{}, fmt.Errorf("%w: %q", domain.ErrDuplicateBarcode, input.Barcode)
+		}
 		slog.ErrorContext(ctx, "Ошибка добавления товара в репозиторий", "reception_id", openReception.ID, "type", productType, "error", err)
 		return domain.Product{}, fmt.Errorf("не удалось добавить товар в приемку: %w", err)
 	}
@@ -116,21 +249,73 @@ func (s *receptionService) AddProduct(ctx context.Context, pvzID uuid.UUID, prod
 		ID:            newProductID,     // Используем ID, полученный от репозитория
 		ReceptionID:   openReception.ID, // ID найденной открытой приемки
 		Type:          productType,      // Тип, который передали на вход
+		Barcode:       input.Barcode,
+		OrderID:       input.OrderID,
+		Attributes:    input.Attributes, // Атрибуты, прошедшие проверку по схеме типа
 		DateTimeAdded: time.Now(),       // Примерное время для ответа API (БД ставит точное)
+		CreatedBy:     productToCreate.CreatedBy,
 	}
 
 	return addedProduct, nil // Возвращаем созданный товар и nil ошибку
 	// --- Конец исправления ---
 }
 
-// DeleteLastProduct - удаляет последний добавленный товар из открытой приемки
-func (s *receptionService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
+// validateProductIdentity проверяет формат необязательных штрихкода и номера заказа.
+func validateProductIdentity(input domain.ProductInput) error {
+	if input.Barcode != "" && !barcodePattern.MatchString(input.Barcode) {
+		return fmt.Errorf("%w: штрихкод должен состоять из печатных ASCII символов без пробелов (до 64)", domain.ErrInvalidProductIdentity)
+	}
+	if input.OrderID != "" && !orderIDPattern.MatchString(input.OrderID) {
+		return fmt.Errorf("%w: номер заказа не должен содержать пробелов и служебных символов (до 100)", domain.ErrInvalidProductIdentity)
+	}
+	return nil
+}
+
+// checkProductInput проверяет, что тип товара есть в справочнике и активен,
+// а атрибуты соответствуют его схеме.
+func (s *receptionService) checkProductInput(ctx context.Context, input domain.ProductInput) error {
+	productType, err := s.activeProductType(ctx, input.Type)
+	if err != nil {
+		return err
+	}
+	return productType.AttributesSchema.ValidateAttributes(input.Attributes)
+}
+
+// activeProductType возвращает тип товара из справочника.
+// Отсутствующий или отключенный тип - ErrInvalidProductType.
+func (s *receptionService) activeProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error) {
+	productType, err := s.typeRepo.GetProductType(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductTypeNotFound) {
+			return domain.ProductTypeInfo{}, fmt.Errorf("%w: %q нет в справочнике", domain.ErrInvalidProductType, code)
+		}
+		slog.ErrorContext(ctx, "Ошибка получения типа товара из справочника", "type", code, "error", err)
+		return domain.ProductTypeInfo{}, fmt.Errorf("не удалось проверить тип товара: %w", err)
+	}
+	if !productType.IsActive {
+		return domain.ProductTypeInfo{}, fmt.Errorf("%w: тип %q отключен", domain.ErrInvalidProductType, code)
+	}
+	return productType, nil
+}
+
+// DeleteLastProduct - мягко удаляет последний добавленный товар из открытой приемки
+func (s *receptionService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) error {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.deleteLastProduct(ctx, pvzID, actor)
+	})
+}
+
+// deleteLastProduct - тело DeleteLastProduct, выполняется внутри транзакции
+func (s *receptionService) deleteLastProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) error {
 	// 1. Находим последнюю открытую приемку
 	openReception, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
 	if err != nil {
 		if errors.Is(err, repository.ErrReceptionNotFound) {
 			slog.WarnContext(ctx, "Попытка удалить товар без открытой приемки", "pvz_id", pvzID)
-			return errors.New("нет открытой приемки для данного ПВЗ, чтобы удалить товар")
+			return fmt.Errorf("%w, чтобы удалить товар", domain.ErrNoOpenReception)
 		}
 		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки при удалении товара", "pvz_id", pvzID, "error", err)
 		return fmt.Errorf("ошибка поиска открытой приемки: %w", err)
@@ -141,60 +326,583 @@ func (s *receptionService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUI
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
 			slog.WarnContext(ctx, "Попытка удалить товар из пустой приемки", "reception_id", openReception.ID)
-			return errors.New("в текущей открытой приемке нет товаров для удаления")
+			return domain.ErrReceptionEmpty
 		}
 		slog.ErrorContext(ctx, "Ошибка поиска последнего товара в приемке", "reception_id", openReception.ID, "error", err)
 		return fmt.Errorf("ошибка поиска последнего товара: %w", err)
 	}
 
 	// 3. Удаляем найденный товар по его ID
-	err = s.repo.DeleteProductByID(ctx, lastProduct.ID)
+	err = s.repo.DeleteProductByID(ctx, lastProduct.ID, actor)
 	if err != nil {
 		// Обрабатываем случай, если товар уже был удален (хотя мы его только что нашли)
 		if errors.Is(err, repository.ErrProductNotFound) { // Репозиторий должен вернуть эту ошибку, если RowsAffected=0
 			slog.ErrorContext(ctx, "Ошибка удаления товара: товар не найден (возможно, удален параллельно)", "product_id", lastProduct.ID, "error", err)
-			return errors.New("не удалось удалить товар, так как он не найден") // Ошибка для клиента
+			return fmt.Errorf("не удалось удалить товар: %w", domain.ErrProductNotFound) // Ошибка для клиента
 		}
 		// Другая ошибка репозитория
 		slog.ErrorContext(ctx, "Ошибка удаления товара из репозитория", "product_id", lastProduct.ID, "error", err)
 		return fmt.Errorf("не удалось удалить товар: %w", err)
 	}
 
+	// 4. Аудит: у LIFO-удаления нет причины от клиента, пишем фиксированную
+	deletion := domain.ProductDeletion{Product: lastProduct, DeletedBy: actor, Reason: lifoDeletionReason}
+	if err := s.repo.RecordProductDeletion(ctx, deletion); err != nil {
+		slog.ErrorContext(ctx, "Ошибка записи аудита удаления товара", "product_id", lastProduct.ID, "error", err)
+		return fmt.Errorf("не удалось сохранить аудит удаления товара: %w", err)
+	}
+
 	slog.InfoContext(ctx, "Последний товар успешно удален из приемки", "product_id", lastProduct.ID, "reception_id", openReception.ID)
 	return nil
 }
 
+// UndoLastDeletion - восстанавливает последний удаленный товар открытой приемки ПВЗ
+func (s *receptionService) UndoLastDeletion(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Product, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return domain.Product{}, err
+	}
+	var restored domain.Product
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		restored, err = s.undoLastDeletion(ctx, pvzID, actor)
+		return err
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+	return restored, nil
+}
+
+// undoLastDeletion - тело UndoLastDeletion, выполняется внутри транзакции
+func (s *receptionService) undoLastDeletion(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Product, error) {
+	// 1. Открытая приемка (блокируется до конца транзакции)
+	openReception, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			slog.WarnContext(ctx, "Попытка отменить удаление без открытой приемки", "pvz_id", pvzID)
+			return domain.Product{}, fmt.Errorf("%w, чтобы отменить удаление товара", domain.ErrNoOpenReception)
+		}
+		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки при отмене удаления", "pvz_id", pvzID, "error", err)
+		return domain.Product{}, fmt.Errorf("ошибка поиска открытой приемки: %w", err)
+	}
+
+	// 2. Последний удаленный товар этой приемки
+	product, err := s.repo.GetLastDeletedProductFromReception(ctx, openReception.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return domain.Product{}, domain.ErrNoDeletedProduct
+		}
+		slog.ErrorContext(ctx, "Ошибка поиска удаленного товара в приемке", "reception_id", openReception.ID, "error", err)
+		return domain.Product{}, fmt.Errorf("ошибка поиска удаленного товара: %w", err)
+	}
+
+	// 3. Снимаем отметку удаления
+	if err := s.repo.RestoreProduct(ctx, product.ID); err != nil {
+		if errors.Is(err, repository.ErrProductBarcodeDuplicate) {
+			slog.WarnContext(ctx, "Штрихкод удаленного товара уже отсканирован заново", "product_id", product.ID, "barcode", product.Barcode)
+			return domain.Product{}, fmt.Errorf("%w: %q", domain.ErrDuplicateBarcode, product.Barcode) // Конфликт (409)
+		}
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return domain.Product{}, domain.ErrNoDeletedProduct
+		}
+		slog.ErrorContext(ctx, "Ошибка восстановления товара", "product_id", product.ID, "error", err)
+		return domain.Product{}, fmt.Errorf("не удалось восстановить товар: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Удаление товара отменено", "product_id", product.ID, "reception_id", openReception.ID,
+		"role", actor.Role, "user_id", actor.UserID)
+	product.DeletedAt, product.DeletedBy = nil, nil
+	return product, nil
+}
+
+// DeleteProduct - удаляет конкретный товар из приемки в статусе in_progress и пишет аудит (кто и почему).
+// Если в ПВЗ включен строгий LIFO, удалить можно только последний добавленный товар.
+func (s *receptionService) DeleteProduct(ctx context.Context, receptionID, productID uuid.UUID, actor domain.Actor, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxDeletionReasonLength {
+		return domain.ErrDeletionReason
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.deleteProduct(ctx, receptionID, productID, actor, reason)
+	})
+}
+
+// deleteProduct - тело DeleteProduct, выполняется внутри транзакции
+func (s *receptionService) deleteProduct(ctx context.Context, receptionID, productID uuid.UUID, actor domain.Actor, reason string) error {
+	// 1. Блокируем приемку и проверяем, что она еще открыта
+	reception, err := s.repo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			return domain.ErrReceptionNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения приемки при удалении товара", "reception_id", receptionID, "error", err)
+		return fmt.Errorf("ошибка получения приемки: %w", err)
+	}
+	if err := s.checkPVZAccess(ctx, reception.PVZID, actor); err != nil {
+		return err
+	}
+	if reception.Status != domain.StatusInProgress {
+		slog.WarnContext(ctx, "Попытка удалить товар из закрытой приемки", "reception_id", receptionID, "product_id", productID)
+		return domain.ErrReceptionClosed
+	}
+
+	// 2. Товар должен принадлежать этой приемке
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+		slog.ErrorContext(ctx, "Ошибка получения товара при удалении", "product_id", productID, "error", err)
+		return fmt.Errorf("ошибка получения товара: %w", err)
+	}
+	if err != nil || product.ReceptionID != reception.ID {
+		return domain.ErrProductNotFound
+	}
+
+	// 3. Строгий LIFO: разрешаем удалить только последний товар
+	pvz, err := s.pvzRepo.GetPVZByID(ctx, reception.PVZID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения ПВЗ при удалении товара", "pvz_id", reception.PVZID, "error", err)
+		return fmt.Errorf("ошибка получения ПВЗ: %w", err)
+	}
+	if pvz.StrictLIFO {
+		lastProduct, err := s.repo.GetLastProductFromReception(ctx, reception.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка поиска последнего товара в приемке", "reception_id", reception.ID, "error", err)
+			return fmt.Errorf("ошибка поиска последнего товара: %w", err)
+		}
+		if lastProduct.ID != product.ID {
+			slog.WarnContext(ctx, "Удаление не последнего товара в ПВЗ со строгим LIFO", "pvz_id", pvz.ID, "product_id", productID)
+			return domain.ErrStrictLIFO
+		}
+	}
+
+	// 4. Удаляем товар и сохраняем аудит в той же транзакции
+	if err := s.repo.DeleteProductByID(ctx, product.ID, actor); err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return domain.ErrProductNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка удаления товара из репозитория", "product_id", product.ID, "error", err)
+		return fmt.Errorf("не удалось удалить товар: %w", err)
+	}
+	if err := s.repo.RecordProductDeletion(ctx, domain.ProductDeletion{Product: product, DeletedBy: actor, Reason: reason}); err != nil {
+		slog.ErrorContext(ctx, "Ошибка записи аудита удаления товара", "product_id", product.ID, "error", err)
+		return fmt.Errorf("не удалось сохранить аудит удаления товара: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Товар удален из приемки", "product_id", product.ID, "reception_id", reception.ID,
+		"role", actor.Role, "user_id", actor.UserID, "reason", reason)
+	return nil
+}
+
 // CloseLastReception - закрывает последнюю открытую приемку
-func (s *receptionService) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error) {
+func (s *receptionService) CloseLastReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return domain.Reception{}, err
+	}
+	var result domain.Reception
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.closeLastReception(ctx, pvzID, actor)
+		return err
+	})
+	if err != nil {
+		return domain.Reception{}, err
+	}
+	return result, nil
+}
+
+// closeLastReception - тело CloseLastReception, выполняется внутри транзакции
+func (s *receptionService) closeLastReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
 	// 1. Находим последнюю открытую приемку
 	openReception, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
 	if err != nil {
 		if errors.Is(err, repository.ErrReceptionNotFound) {
 			slog.WarnContext(ctx, "Попытка закрыть приемку при отсутствии открытой", "pvz_id", pvzID)
-			return domain.Reception{}, errors.New("нет открытой приемки для данного ПВЗ для закрытия")
+			return domain.Reception{}, fmt.Errorf("%w для закрытия", domain.ErrNoOpenReception)
 		}
 		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки при закрытии", "pvz_id", pvzID, "error", err)
 		return domain.Reception{}, fmt.Errorf("ошибка поиска открытой приемки: %w", err)
 	}
 
-	// 2. Вызываем метод репозитория для изменения статуса на 'closed'
-	err = s.repo.CloseReceptionByID(ctx, openReception.ID)
+	// 2. Переводим приемку в 'closed'
+	closedReception, err := s.transitionReception(ctx, openReception, domain.EventClose, actor)
 	if err != nil {
 		// Обрабатываем случай, если приемка уже была закрыта или не найдена
 		if errors.Is(err, repository.ErrReceptionNotFound) { // Репозиторий должен вернуть это, если RowsAffected=0
 			slog.ErrorContext(ctx, "Ошибка закрытия приемки: приемка не найдена или уже закрыта", "reception_id", openReception.ID, "error", err)
-			return domain.Reception{}, errors.New("не удалось закрыть приемку, так как она не найдена или уже закрыта")
+			return domain.Reception{}, fmt.Errorf("не удалось закрыть приемку: %w", domain.ErrNoOpenReception)
 		}
 		// Другая ошибка репозитория
 		slog.ErrorContext(ctx, "Ошибка закрытия приемки в репозитории", "reception_id", openReception.ID, "error", err)
 		return domain.Reception{}, fmt.Errorf("не удалось закрыть приемку: %w", err)
 	}
 
-	// 3. Формируем ответ с обновленным статусом
-	closedReception := openReception             // Копируем данные найденной приемки
-	closedReception.Status = domain.StatusClosed // Обновляем статус
-	// Время DateTime остается временем начала приемки
-
 	slog.InfoContext(ctx, "Приемка успешно закрыта", "reception_id", closedReception.ID, "pvz_id", pvzID)
 	return closedReception, nil
 }
+
+// ReopenReception - возвращает закрытую приемку в статус in_progress (только модератор).
+// Переоткрыть можно только последнюю приемку ПВЗ.
+func (s *receptionService) ReopenReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	var result domain.Reception
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.reopenReception(ctx, receptionID, actor)
+		return err
+	})
+	if err != nil {
+		return domain.Reception{}, err
+	}
+	return result, nil
+}
+
+// reopenReception - тело ReopenReception, выполняется внутри транзакции
+func (s *receptionService) reopenReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	reception, err := s.lockReception(ctx, receptionID, actor)
+	if err != nil {
+		return domain.Reception{}, err
+	}
+	// Проверяем переход до остальных запросов, чтобы не ходить в БД зря
+	if _, err := domain.ReceptionLifecycle.Next(reception.Status, domain.EventReopen); err != nil {
+		return domain.Reception{}, err
+	}
+
+	// В деактивированный ПВЗ приемки не принимаются - переоткрытие тоже запрещено
+	pvz, err := s.pvzRepo.GetPVZByID(ctx, reception.PVZID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения ПВЗ при переоткрытии приемки", "pvz_id", reception.PVZID, "error", err)
+		return domain.Reception{}, fmt.Errorf("ошибка получения ПВЗ: %w", err)
+	}
+	if !pvz.IsActive {
+		return domain.Reception{}, domain.ErrPVZInactive
+	}
+
+	newer, err := s.repo.HasNewerReception(ctx, reception)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка поиска более новой приемки", "reception_id", reception.ID, "error", err)
+		return domain.Reception{}, fmt.Errorf("ошибка проверки более новых приемок: %w", err)
+	}
+	if newer {
+		slog.WarnContext(ctx, "Попытка переоткрыть не последнюю приемку ПВЗ", "reception_id", reception.ID, "pvz_id", reception.PVZID)
+		return domain.Reception{}, domain.ErrNewerReceptionExists
+	}
+
+	reopened, err := s.transitionReception(ctx, reception, domain.EventReopen, actor)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionAlreadyOpen) {
+			return domain.Reception{}, domain.ErrReceptionAlreadyOpen
+		}
+		slog.ErrorContext(ctx, "Ошибка переоткрытия приемки", "reception_id", reception.ID, "error", err)
+		return domain.Reception{}, fmt.Errorf("не удалось переоткрыть приемку: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Приемка переоткрыта", "reception_id", reopened.ID, "pvz_id", reopened.PVZID, "user_id", actor.UserID)
+	return reopened, nil
+}
+
+// CancelReception - отменяет открытую приемку, начатую по ошибке
+func (s *receptionService) CancelReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	var result domain.Reception
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		reception, err := s.lockReception(ctx, receptionID, actor)
+		if err != nil {
+			return err
+		}
+		result, err = s.transitionReception(ctx, reception, domain.EventCancel, actor)
+		if err != nil && !errors.Is(err, domain.ErrInvalidReceptionTransition) {
+			slog.ErrorContext(ctx, "Ошибка отмены приемки", "reception_id", reception.ID, "error", err)
+			return fmt.Errorf("не удалось отменить приемку: %w", err)
+		}
+		return err
+	})
+	if err != nil {
+		return domain.Reception{}, err
+	}
+
+	slog.InfoContext(ctx, "Приемка отменена", "reception_id", result.ID, "pvz_id", result.PVZID)
+	return result, nil
+}
+
+// lockReception получает приемку по ID (внутри транзакции строка блокируется)
+// и проверяет, что actor может работать с ее ПВЗ
+func (s *receptionService) lockReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error) {
+	reception, err := s.repo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			return domain.Reception{}, domain.ErrReceptionNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения приемки", "reception_id", receptionID, "error", err)
+		return domain.Reception{}, fmt.Errorf("ошибка получения приемки: %w", err)
+	}
+	if err := s.checkPVZAccess(ctx, reception.PVZID, actor); err != nil {
+		return domain.Reception{}, err
+	}
+	return reception, nil
+}
+
+// transitionReception проверяет событие по domain.ReceptionLifecycle и сохраняет новый статус и ClosedBy.
+// Возвращает приемку с обновленным статусом (DateTime остается временем начала приемки).
+func (s *receptionService) transitionReception(ctx context.Context, reception domain.Reception, event domain.ReceptionEvent, actor domain.Actor) (domain.Reception, error) {
+	next, err := domain.ReceptionLifecycle.Next(reception.Status, event)
+	if err != nil {
+		slog.WarnContext(ctx, "Недопустимая смена статуса приемки", "reception_id", reception.ID, "status", reception.Status, "event", event)
+		return domain.Reception{}, err
+	}
+	if err := s.repo.UpdateReceptionStatus(ctx, reception.ID, reception.Status, next, actor); err != nil {
+		return domain.Reception{}, err
+	}
+	reception.Status = next
+	reception.ClosedBy = nil
+	if next != domain.StatusInProgress {
+		reception.ClosedBy = actor.KnownUserID()
+	}
+	return reception, nil
+}
+
+// GetReception возвращает приемку по ID вместе с товарами.
+func (s *receptionService) GetReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor, includeDeleted bool) (ReceptionDetails, error) {
+	reception, err := s.repo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			return ReceptionDetails{}, domain.ErrReceptionNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения приемки", "reception_id", receptionID, "error", err)
+		return ReceptionDetails{}, fmt.Errorf("не удалось получить приемку: %w", err)
+	}
+	if err := s.checkPVZAccess(ctx, reception.PVZID, actor); err != nil {
+		return ReceptionDetails{}, err
+	}
+	return s.withProducts(ctx, reception, includeDeleted)
+}
+
+// GetCurrentReception возвращает открытую приемку ПВЗ вместе с товарами.
+// Если открытой приемки нет, возвращает RECEPTION_NOT_FOUND (404): для чтения это
+// не ошибка состояния, а отсутствие ресурса.
+func (s *receptionService) GetCurrentReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, includeDeleted bool) (ReceptionDetails, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return ReceptionDetails{}, err
+	}
+	reception, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			return ReceptionDetails{}, fmt.Errorf("%w: у ПВЗ нет открытой приемки", domain.ErrReceptionNotFound)
+		}
+		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки", "pvz_id", pvzID, "error", err)
+		return ReceptionDetails{}, fmt.Errorf("не удалось получить текущую приемку: %w", err)
+	}
+	return s.withProducts(ctx, reception, includeDeleted)
+}
+
+// withProducts дополняет приемку списком ее товаров (удаленные - только при includeDeleted).
+func (s *receptionService) withProducts(ctx context.Context, reception domain.Reception, includeDeleted bool) (ReceptionDetails, error) {
+	products, err := s.repo.ListProductsByReceptionIDs(ctx, []uuid.UUID{reception.ID}, includeDeleted)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения товаров приемки", "reception_id", reception.ID, "error", err)
+		return ReceptionDetails{}, fmt.Errorf("не удалось получить товары приемки: %w", err)
+	}
+	return ReceptionDetails{Reception: reception, Products: products}, nil
+}
+
+// ListReceptions возвращает страницу истории приемок ПВЗ (от новых к старым).
+// Курсор следующей страницы заполняется, только если страница заполнена целиком.
+func (s *receptionService) ListReceptions(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, filter domain.ReceptionFilter) (ListReceptionsResult, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return ListReceptionsResult{}, err
+	}
+	receptions, err := s.repo.ListReceptionsByPVZ(ctx, pvzID, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения истории приемок", "pvz_id", pvzID, "error", err)
+		return ListReceptionsResult{}, fmt.Errorf("не удалось получить историю приемок: %w", err)
+	}
+
+	result := ListReceptionsResult{Receptions: receptions}
+	if len(receptions) == filter.Limit && filter.Limit > 0 {
+		last := receptions[len(receptions)-1]
+		nextDateTime, nextID := last.DateTime, last.ID
+		result.NextAfterDateTime = &nextDateTime
+		result.NextAfterID = &nextID
+	}
+	return result, nil
+}
+
+// FindProductsByBarcode возвращает товары с указанным штрихкодом (от новых к старым): модераторам -
+// во всех приемках, остальным - только в приемках назначенных им ПВЗ.
+func (s *receptionService) FindProductsByBarcode(ctx context.Context, barcode string, actor domain.Actor) ([]domain.Product, error) {
+	barcode = strings.TrimSpace(barcode)
+	if !barcodePattern.MatchString(barcode) {
+		return nil, fmt.Errorf("%w: штрихкод должен состоять из печатных ASCII символов без пробелов (до 64)", domain.ErrInvalidProductIdentity)
+	}
+
+	var pvzIDs []uuid.UUID // nil - без ограничения по ПВЗ
+	if !hasAllPVZAccess(actor) {
+		if actor.UserID == uuid.Nil {
+			return nil, domain.ErrPVZNotAssigned
+		}
+		assignments, err := s.access.ListAssignmentsByUser(ctx, actor.UserID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка получения назначений на ПВЗ", "user_id", actor.UserID, "error", err)
+			return nil, fmt.Errorf("ошибка получения назначений на ПВЗ: %w", err)
+		}
+		if len(assignments) == 0 {
+			return []domain.Product{}, nil
+		}
+		pvzIDs = make([]uuid.UUID, 0, len(assignments))
+		for _, a := range assignments {
+			pvzIDs = append(pvzIDs, a.PVZID)
+		}
+	}
+
+	products, err := s.repo.ListProductsByBarcode(ctx, barcode, pvzIDs, productsByBarcodeLimit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка поиска товаров по штрихкоду", "barcode", barcode, "error", err)
+		return nil, fmt.Errorf("не удалось найти товары по штрихкоду: %w", err)
+	}
+	return products, nil
+}
+
+// AddProductsBatch - добавляет пачку товаров в последнюю открытую приемку ПВЗ.
+// Приемка ищется и блокируется один раз, товары вставляются одним INSERT в той же транзакции.
+// Некорректные товары и повторные штрихкоды отклоняются поштучно; при allOrNothing
+// любой отказ откатывает всю пачку, а корректные товары получают статус BatchItemSkipped.
+func (s *receptionService) AddProductsBatch(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, inputs []domain.ProductInput, allOrNothing bool) (ProductBatchResult, error) {
+	if err := s.checkPVZAccess(ctx, pvzID, actor); err != nil {
+		return ProductBatchResult{}, err
+	}
+	var result ProductBatchResult
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.addProductsBatch(ctx, pvzID, actor, inputs, allOrNothing)
+		return err
+	})
+	if err != nil && !errors.Is(err, errBatchRolledBack) {
+		return ProductBatchResult{}, err
+	}
+
+	mmetrics.ProductsAddedTotal.Add(float64(result.Added))
+	slog.InfoContext(ctx, "Пачка товаров обработана", "pvz_id", pvzID, "reception_id", result.ReceptionID,
+		"total", len(inputs), "added", result.Added, "all_or_nothing", allOrNothing)
+	return result, nil
+}
+
+// addProductsBatch - тело AddProductsBatch, выполняется внутри транзакции
+func (s *receptionService) addProductsBatch(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, inputs []domain.ProductInput, allOrNothing bool) (ProductBatchResult, error) {
+	// 1. Проверяем каждый товар; тип из справочника читаем один раз на код
+	result := ProductBatchResult{Items: make([]ProductBatchItem, len(inputs))}
+	types := make(map[domain.ProductType]domain.ProductTypeInfo)
+	typeErrs := make(map[domain.ProductType]error)
+	barcodes := make(map[string]int) // Штрихкод -> индекс первого товара с ним в пачке
+	normalized := make([]domain.ProductInput, len(inputs))
+	rejected := 0
+	for i, input := range inputs {
+		input.Barcode, input.OrderID = strings.TrimSpace(input.Barcode), strings.TrimSpace(input.OrderID)
+		normalized[i] = input
+		result.Items[i] = ProductBatchItem{Index: i}
+
+		itemErr := validateProductIdentity(input)
+		if itemErr == nil {
+			productType, cached := types[input.Type]
+			typeErr, failed := typeErrs[input.Type]
+			if !cached && !failed {
+				productType, typeErr = s.activeProductType(ctx, input.Type)
+				if typeErr != nil {
+					if _, ok := domain.AsError(typeErr); !ok {
+						return ProductBatchResult{}, typeErr // Ошибка БД - прерываем всю пачку
+					}
+					typeErrs[input.Type] = typeErr
+				} else {
+					types[input.Type] = productType
+				}
+			}
+			itemErr = typeErr
+			if itemErr == nil {
+				itemErr = productType.AttributesSchema.ValidateAttributes(input.Attributes)
+			}
+		}
+		if itemErr == nil && input.Barcode != "" {
+			if first, dup := barcodes[input.Barcode]; dup {
+				itemErr = fmt.Errorf("%w: %q (совпадает с товаром #%d пачки)", domain.ErrDuplicateBarcode, input.Barcode, first)
+			} else {
+				barcodes[input.Barcode] = i
+			}
+		}
+
+		if itemErr != nil {
+			result.Items[i].Status, result.Items[i].Err = BatchItemRejected, itemErr
+			rejected++
+		}
+	}
+
+	// 2. Находим и блокируем открытую приемку
+	openReception, err := s.repo.GetLastOpenReceptionByPVZ(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			slog.WarnContext(ctx, "Попытка добавить пачку товаров без открытой приемки", "pvz_id", pvzID)
+			return ProductBatchResult{}, fmt.Errorf("%w, чтобы добавить товары", domain.ErrNoOpenReception)
+		}
+		slog.ErrorContext(ctx, "Ошибка поиска открытой приемки", "pvz_id", pvzID, "error", err)
+		return ProductBatchResult{}, fmt.Errorf("ошибка поиска открытой приемки: %w", err)
+	}
+	result.ReceptionID = openReception.ID
+
+	if allOrNothing && rejected > 0 {
+		markSkipped(result.Items)
+		return result, errBatchRolledBack
+	}
+
+	// 3. Вставляем корректные товары одним запросом
+	toCreate := make([]domain.Product, 0, len(inputs)-rejected)
+	for i, input := range normalized {
+		if result.Items[i].Status == BatchItemRejected {
+			continue
+		}
+		toCreate = append(toCreate, domain.Product{
+			ID:          uuid.New(),
+			ReceptionID: openReception.ID,
+			Type:        input.Type,
+			Barcode:     input.Barcode,
+			OrderID:     input.OrderID,
+			Attributes:  input.Attributes,
+			CreatedBy:   actor.KnownUserID(),
+		})
+	}
+	added, err := s.repo.AddProductsToReception(ctx, toCreate)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка пакетного добавления товаров в репозиторий", "reception_id", openReception.ID, "count", len(toCreate), "error", err)
+		return ProductBatchResult{}, fmt.Errorf("не удалось добавить товары в приемку: %w", err)
+	}
+
+	// 4. Сопоставляем вставленные строки с товарами запроса; пропущенные БД - повторные штрихкоды
+	addedByID := make(map[uuid.UUID]domain.Product, len(added))
+	for _, p := range added {
+		addedByID[p.ID] = p
+	}
+	next := 0
+	for i := range result.Items {
+		if result.Items[i].Status == BatchItemRejected {
+			continue
+		}
+		candidate := toCreate[next]
+		next++
+		if p, ok := addedByID[candidate.ID]; ok {
+			result.Items[i].Status, result.Items[i].Product = BatchItemAdded, p
+			result.Added++
+			continue
+		}
+		result.Items[i].Status = BatchItemRejected
+		result.Items[i].Err = fmt.Errorf("%w: %q", domain.ErrDuplicateBarcode, candidate.Barcode)
+	}
+
+	if allOrNothing && result.Added < len(inputs) {
+		result.Added = 0
+		markSkipped(result.Items)
+		return result, errBatchRolledBack
+	}
+	return result, nil
+}
+
+// markSkipped помечает все неотклоненные товары пачки как BatchItemSkipped (пачка allOrNothing откатывается).
+func markSkipped(items []ProductBatchItem) {
+	for i := range items {
+		if items[i].Status != BatchItemRejected {
+			items[i].Status, items[i].Product = BatchItemSkipped, domain.Product{}
+		}
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time" // Для проверки времени в AddProduct
 
@@ -17,6 +19,68 @@ import (
 	"github.com/stretchr/testify/require" // Добавим require для setup
 )
 
+// newPassthroughTransactor возвращает мок Transactor, который просто вызывает fn
+// с тем же контекстом (транзакция в юнит-тестах не нужна).
+func newPassthroughTransactor(t *testing.T) *mocks.Transactor {
+	tx := mocks.NewTransactor(t)
+	tx.On("WithinTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		Maybe()
+	return tx
+}
+
+// Акторы тестовых операций: ID пользователя попадает в created_by/closed_by
+var (
+	testEmployee  = domain.Actor{UserID: uuid.New(), Role: domain.RoleEmployee}
+	testModerator = domain.Actor{UserID: uuid.New(), Role: domain.RoleModerator}
+)
+
+// newActivePVZRepo возвращает мок PVZRepository, в котором pvzID - существующий активный ПВЗ.
+func newActivePVZRepo(t *testing.T, pvzID uuid.UUID) *mocks.PVZRepository {
+	t.Helper()
+	pvzRepo := mocks.NewPVZRepository(t)
+	pvzRepo.On("GetPVZByID", mock.Anything, pvzID).
+		Return(domain.PVZ{ID: pvzID, City: "Москва", IsActive: true}, nil).
+		Maybe()
+	return pvzRepo
+}
+
+// newAssignedEverywhere возвращает мок AssignmentRepository, в котором сотрудник назначен на любой ПВЗ.
+func newAssignedEverywhere(t *testing.T) *mocks.AssignmentRepository {
+	t.Helper()
+	access := mocks.NewAssignmentRepository(t)
+	access.On("IsAssigned", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Maybe()
+	return access
+}
+
+// newTestProductTypeRepo возвращает мок справочника типов товаров: три базовых типа без схемы,
+// "посылка" со схемой атрибутов и неактивный тип "архив".
+func newTestProductTypeRepo() *mocks.ProductTypeRepository {
+	minWeight := 0.0
+	types := map[domain.ProductType]domain.ProductTypeInfo{
+		domain.TypeElectronics: {Code: domain.TypeElectronics, IsActive: true},
+		domain.TypeClothes:     {Code: domain.TypeClothes, IsActive: true},
+		domain.TypeShoes:       {Code: domain.TypeShoes, IsActive: true},
+		"архив":                {Code: "архив", IsActive: false},
+		"посылка": {Code: "посылка", IsActive: true, AttributesSchema: &domain.AttributeSchema{
+			Properties: map[string]domain.AttributeProperty{
+				"weight":  {Type: domain.AttributeTypeNumber, Minimum: &minWeight},
+				"size":    {Type: domain.AttributeTypeString, Enum: []string{"S", "M", "L"}},
+				"fragile": {Type: domain.AttributeTypeBoolean},
+			},
+			Required: []string{"weight"},
+		}},
+	}
+	typeRepo := new(mocks.ProductTypeRepository)
+	typeRepo.On("GetProductType", mock.Anything, mock.Anything).Return(func(_ context.Context, code domain.ProductType) (domain.ProductTypeInfo, error) {
+		if pt, ok := types[code]; ok {
+			return pt, nil
+		}
+		return domain.ProductTypeInfo{}, repository.ErrProductTypeNotFound
+	}).Maybe()
+	return typeRepo
+}
+
 // TestReceptionService_InitiateReception
 func TestReceptionService_InitiateReception(t *testing.T) {
 	ctx := context.Background()
@@ -25,27 +89,30 @@ func TestReceptionService_InitiateReception(t *testing.T) {
 	t.Run("Success - No open reception", func(t *testing.T) {
 		// --- ИСПРАВЛЕНО: Используем правильное имя мока ---
 		mockReceptionRepo := new(mocks.ReceptionRepository)
-		receptionService := NewReceptionService(mockReceptionRepo) // Конструктор принимает интерфейс
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t)) // Конструктор принимает интерфейс
 		expectedNewID := uuid.New()
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
-		mockReceptionRepo.On("CreateReception", mock.Anything, mock.MatchedBy(func(r domain.Reception) bool { return r.PVZID == testPVZID })).Return(expectedNewID, nil).Once()
+		mockReceptionRepo.On("CreateReception", mock.Anything, mock.MatchedBy(func(r domain.Reception) bool {
+			return r.PVZID == testPVZID && r.CreatedBy != nil && *r.CreatedBy == testEmployee.UserID
+		})).Return(expectedNewID, nil).Once()
 
-		createdReception, err := receptionService.InitiateReception(ctx, testPVZID)
+		createdReception, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
 
 		require.NoError(t, err) // Используем require для прерывания при ошибке
 		assert.Equal(t, expectedNewID, createdReception.ID)
+		assert.Equal(t, &testEmployee.UserID, createdReception.CreatedBy)
 		mockReceptionRepo.AssertExpectations(t)
 	})
 
 	t.Run("Fail - Already open reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		existingReception := domain.Reception{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusInProgress}
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(existingReception, nil).Once()
 
-		_, err := receptionService.InitiateReception(ctx, testPVZID)
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "предыдущая приемка для этого ПВЗ еще не закрыта")
@@ -55,12 +122,12 @@ func TestReceptionService_InitiateReception(t *testing.T) {
 
 	t.Run("Fail - Error checking existing reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		repoError := errors.New("DB connection error")
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repoError).Once()
 
-		_, err := receptionService.InitiateReception(ctx, testPVZID)
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repoError)
@@ -70,21 +137,83 @@ func TestReceptionService_InitiateReception(t *testing.T) {
 
 	t.Run("Fail - Error creating reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		repoError := errors.New("Failed to insert")
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
 		mockReceptionRepo.On("CreateReception", mock.Anything, mock.AnythingOfType("domain.Reception")).Return(uuid.Nil, repoError).Once()
 
-		_, err := receptionService.InitiateReception(ctx, testPVZID)
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repoError)
 		mockReceptionRepo.AssertExpectations(t)
 	})
+
+	t.Run("Fail - Concurrent reception created (unique index)", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+		mockReceptionRepo.On("CreateReception", mock.Anything, mock.AnythingOfType("domain.Reception")).Return(uuid.Nil, repository.ErrReceptionAlreadyOpen).Once()
+
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrReceptionAlreadyOpen)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Transaction error", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		mockTx := new(mocks.Transactor)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), mockTx)
+		txError := errors.New("begin tx failed")
+
+		mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(txError).Once()
+
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, txError)
+		mockTx.AssertExpectations(t)
+		mockReceptionRepo.AssertNotCalled(t, "CreateReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - PVZ Not Found", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		mockPVZRepo := new(mocks.PVZRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, mockPVZRepo, new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, testPVZID).Return(domain.PVZ{}, repository.ErrPVZNotFound).Once()
+
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZNotFound)
+		mockPVZRepo.AssertExpectations(t)
+		mockReceptionRepo.AssertNotCalled(t, "CreateReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - PVZ Deactivated", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		mockPVZRepo := new(mocks.PVZRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, mockPVZRepo, new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		deactivatedAt := time.Now()
+
+		mockPVZRepo.On("GetPVZByID", mock.Anything, testPVZID).
+			Return(domain.PVZ{ID: testPVZID, City: "Казань", IsActive: false, DeactivatedAt: &deactivatedAt}, nil).Once()
+
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrPVZInactive)
+		mockPVZRepo.AssertExpectations(t)
+		mockReceptionRepo.AssertNotCalled(t, "GetLastOpenReceptionByPVZ", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "CreateReception", mock.Anything, mock.Anything)
+	})
 }
 
-// Тесты для AddProduct
 func TestReceptionService_AddProduct(t *testing.T) {
 	ctx := context.Background()
 	testPVZID := uuid.New()
@@ -94,18 +223,19 @@ func TestReceptionService_AddProduct(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		productType := domain.TypeClothes
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
 		mockReceptionRepo.On("AddProductToReception", mock.Anything, mock.MatchedBy(func(p domain.Product) bool {
-			return p.ReceptionID == testReceptionID && p.Type == productType
+			return p.ReceptionID == testReceptionID && p.Type == productType && p.CreatedBy != nil && *p.CreatedBy == testEmployee.UserID
 		})).Return(testProductID, nil).Once()
 
-		addedProduct, err := receptionService.AddProduct(ctx, testPVZID, productType)
+		addedProduct, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: productType})
 
 		require.NoError(t, err)
 		assert.Equal(t, testProductID, addedProduct.ID)
+		assert.Equal(t, &testEmployee.UserID, addedProduct.CreatedBy)
 		assert.Equal(t, testReceptionID, addedProduct.ReceptionID)
 		assert.Equal(t, productType, addedProduct.Type)
 		assert.False(t, addedProduct.DateTimeAdded.IsZero()) // Проверяем, что дата добавлена в сервисе
@@ -114,22 +244,83 @@ func TestReceptionService_AddProduct(t *testing.T) {
 
 	t.Run("Fail - Invalid Product Type", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
-		_, err := receptionService.AddProduct(ctx, testPVZID, "invalid_type")
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: "invalid_type"})
 
 		require.Error(t, err)
-		assert.EqualError(t, err, "недопустимый тип товара")
+		assert.ErrorIs(t, err, domain.ErrInvalidProductType)
+		assert.Contains(t, err.Error(), "недопустимый тип товара")
 		mockReceptionRepo.AssertNotCalled(t, "GetLastOpenReceptionByPVZ", mock.Anything, mock.Anything)
 	})
 
+	t.Run("Fail - Inactive Product Type", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: "архив"})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidProductType)
+		mockReceptionRepo.AssertNotCalled(t, "GetLastOpenReceptionByPVZ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Attributes Match Schema", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		attrs := map[string]any{"weight": 1.5, "size": "M", "fragile": true}
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("AddProductToReception", mock.Anything, mock.MatchedBy(func(p domain.Product) bool {
+			return p.Type == "посылка" && p.Attributes["size"] == "M"
+		})).Return(testProductID, nil).Once()
+
+		addedProduct, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: "посылка", Attributes: attrs})
+
+		require.NoError(t, err)
+		assert.Equal(t, attrs, addedProduct.Attributes)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Attributes Do Not Match Schema", func(t *testing.T) {
+		cases := map[string]map[string]any{
+			"missing required": {"size": "M"},
+			"wrong type":       {"weight": "heavy"},
+			"below minimum":    {"weight": -1.0},
+			"not in enum":      {"weight": 1.0, "size": "XXL"},
+			"unknown":          {"weight": 1.0, "color": "red"},
+		}
+		for name, attrs := range cases {
+			t.Run(name, func(t *testing.T) {
+				mockReceptionRepo := new(mocks.ReceptionRepository)
+				receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+				_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: "посылка", Attributes: attrs})
+
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrInvalidProductAttributes)
+				mockReceptionRepo.AssertNotCalled(t, "AddProductToReception", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Fail - Attributes For Type Without Schema", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: domain.TypeShoes, Attributes: map[string]any{"size": "42"}})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidProductAttributes)
+	})
+
 	t.Run("Fail - No Open Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
 
-		_, err := receptionService.AddProduct(ctx, testPVZID, domain.TypeShoes)
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: domain.TypeShoes})
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "нет открытой приемки для данного ПВЗ, чтобы добавить товар")
@@ -139,12 +330,12 @@ func TestReceptionService_AddProduct(t *testing.T) {
 
 	t.Run("Fail - Error Finding Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		repoError := errors.New("DB error find reception")
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repoError).Once()
 
-		_, err := receptionService.AddProduct(ctx, testPVZID, domain.TypeElectronics)
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: domain.TypeElectronics})
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repoError)
@@ -154,19 +345,260 @@ func TestReceptionService_AddProduct(t *testing.T) {
 
 	t.Run("Fail - Error Adding Product", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		productType := domain.TypeClothes
 		repoError := errors.New("DB error add product")
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
 		mockReceptionRepo.On("AddProductToReception", mock.Anything, mock.AnythingOfType("domain.Product")).Return(uuid.Nil, repoError).Once()
 
-		_, err := receptionService.AddProduct(ctx, testPVZID, productType)
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: productType})
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repoError)
 		mockReceptionRepo.AssertExpectations(t)
 	})
+
+	t.Run("Success - Barcode And Order ID", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("AddProductToReception", mock.Anything, mock.MatchedBy(func(p domain.Product) bool {
+			return p.Barcode == "4601234567893" && p.OrderID == "WB-100500"
+		})).Return(testProductID, nil).Once()
+
+		addedProduct, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: domain.TypeShoes, Barcode: " 4601234567893 ", OrderID: "WB-100500"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "4601234567893", addedProduct.Barcode)
+		assert.Equal(t, "WB-100500", addedProduct.OrderID)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Invalid Barcode Or Order ID", func(t *testing.T) {
+		cases := map[string]domain.ProductInput{
+			"barcode with space":    {Type: domain.TypeShoes, Barcode: "460 123"},
+			"barcode non-ascii":     {Type: domain.TypeShoes, Barcode: "штрихкод"},
+			"barcode too long":      {Type: domain.TypeShoes, Barcode: strings.Repeat("1", 65)},
+			"order id with newline": {Type: domain.TypeShoes, OrderID: "WB\n1"},
+		}
+		for name, input := range cases {
+			t.Run(name, func(t *testing.T) {
+				mockReceptionRepo := new(mocks.ReceptionRepository)
+				receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+				_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, input)
+
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrInvalidProductIdentity)
+				mockReceptionRepo.AssertNotCalled(t, "AddProductToReception", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Fail - Duplicate Barcode In Reception", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("AddProductToReception", mock.Anything, mock.Anything).Return(uuid.Nil, repository.ErrProductBarcodeDuplicate).Once()
+
+		_, err := receptionService.AddProduct(ctx, testPVZID, testEmployee, domain.ProductInput{Type: domain.TypeShoes, Barcode: "4601234567893"})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrDuplicateBarcode)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+}
+
+func TestReceptionService_FindProductsByBarcode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		products := []domain.Product{{ID: uuid.New(), ReceptionID: uuid.New(), Type: domain.TypeShoes, Barcode: "4601234567893"}}
+
+		mockReceptionRepo.On("ListProductsByBarcode", mock.Anything, "4601234567893", []uuid.UUID(nil), uint64(productsByBarcodeLimit)).Return(products, nil).Once()
+
+		result, err := receptionService.FindProductsByBarcode(ctx, "4601234567893", testModerator)
+
+		require.NoError(t, err)
+		assert.Equal(t, products, result)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Employee Sees Assigned PVZs Only", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		assignedPVZ := uuid.New()
+		products := []domain.Product{{ID: uuid.New(), ReceptionID: uuid.New(), Type: domain.TypeShoes, Barcode: "4601234567893"}}
+
+		access.On("ListAssignmentsByUser", mock.Anything, testEmployee.UserID).
+			Return([]domain.PVZAssignment{{UserID: testEmployee.UserID, PVZID: assignedPVZ}}, nil).Once()
+		mockReceptionRepo.On("ListProductsByBarcode", mock.Anything, "4601234567893", []uuid.UUID{assignedPVZ}, uint64(productsByBarcodeLimit)).Return(products, nil).Once()
+
+		result, err := receptionService.FindProductsByBarcode(ctx, "4601234567893", testEmployee)
+
+		require.NoError(t, err)
+		assert.Equal(t, products, result)
+	})
+
+	t.Run("Success - Employee Without Assignments", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		access.On("ListAssignmentsByUser", mock.Anything, testEmployee.UserID).Return([]domain.PVZAssignment{}, nil).Once()
+
+		result, err := receptionService.FindProductsByBarcode(ctx, "4601234567893", testEmployee)
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByBarcode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Employee Without User", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), mocks.NewAssignmentRepository(t), newPassthroughTransactor(t))
+
+		_, err := receptionService.FindProductsByBarcode(ctx, "4601234567893", domain.Actor{Role: domain.RoleEmployee})
+
+		assert.ErrorIs(t, err, domain.ErrPVZNotAssigned)
+	})
+
+	t.Run("Fail - Invalid Barcode", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		_, err := receptionService.FindProductsByBarcode(ctx, "", testModerator)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidProductIdentity)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByBarcode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReceptionService_AddProductsBatch(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	testReceptionID := uuid.New()
+	openReception := domain.Reception{ID: testReceptionID, PVZID: testPVZID, Status: domain.StatusInProgress}
+	inputs := []domain.ProductInput{
+		{Type: domain.TypeShoes, Barcode: "111"},
+		{Type: "invalid_type", Barcode: "222"},
+		{Type: domain.TypeClothes, Barcode: "111"}, // Повтор штрихкода внутри пачки
+		{Type: domain.TypeElectronics, Barcode: "333"},
+	}
+
+	// insertAllExcept имитирует ON CONFLICT DO NOTHING: возвращает вставленные товары,
+	// кроме товаров со штрихкодами из skip (уже есть в приемке).
+	insertAllExcept := func(skip ...string) func(context.Context, []domain.Product) ([]domain.Product, error) {
+		return func(_ context.Context, products []domain.Product) ([]domain.Product, error) {
+			added := make([]domain.Product, 0, len(products))
+			for _, p := range products {
+				if !slices.Contains(skip, p.Barcode) {
+					p.DateTimeAdded = time.Now()
+					added = append(added, p)
+				}
+			}
+			return added, nil
+		}
+	}
+
+	t.Run("Success - Partial", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("AddProductsToReception", mock.Anything, mock.MatchedBy(func(ps []domain.Product) bool {
+			return len(ps) == 2 && ps[0].Barcode == "111" && ps[1].Barcode == "333" && ps[0].ReceptionID == testReceptionID
+		})).Return(insertAllExcept("333")).Once()
+
+		result, err := receptionService.AddProductsBatch(ctx, testPVZID, testEmployee, inputs, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, testReceptionID, result.ReceptionID)
+		assert.Equal(t, 1, result.Added)
+		require.Len(t, result.Items, 4)
+		assert.Equal(t, BatchItemAdded, result.Items[0].Status)
+		assert.NotEqual(t, uuid.Nil, result.Items[0].Product.ID)
+		assert.Equal(t, BatchItemRejected, result.Items[1].Status)
+		assert.ErrorIs(t, result.Items[1].Err, domain.ErrInvalidProductType)
+		assert.Equal(t, BatchItemRejected, result.Items[2].Status)
+		assert.ErrorIs(t, result.Items[2].Err, domain.ErrDuplicateBarcode)
+		assert.Equal(t, BatchItemRejected, result.Items[3].Status) // Штрихкод уже есть в приемке
+		assert.ErrorIs(t, result.Items[3].Err, domain.ErrDuplicateBarcode)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("All Or Nothing - Invalid Item Rejects Batch", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+
+		result, err := receptionService.AddProductsBatch(ctx, testPVZID, testEmployee, inputs, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Added)
+		assert.Equal(t, BatchItemSkipped, result.Items[0].Status)
+		assert.Equal(t, BatchItemRejected, result.Items[1].Status)
+		assert.Equal(t, BatchItemSkipped, result.Items[3].Status)
+		mockReceptionRepo.AssertNotCalled(t, "AddProductsToReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("All Or Nothing - Conflict In Reception Rolls Back", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		tx := mocks.NewTransactor(t)
+		var txErr error
+		tx.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error { txErr = fn(ctx); return txErr }).
+			Once()
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), tx)
+		valid := []domain.ProductInput{{Type: domain.TypeShoes, Barcode: "111"}, {Type: domain.TypeShoes, Barcode: "333"}}
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("AddProductsToReception", mock.Anything, mock.Anything).Return(insertAllExcept("333")).Once()
+
+		result, err := receptionService.AddProductsBatch(ctx, testPVZID, testEmployee, valid, true)
+
+		require.NoError(t, err)
+		require.Error(t, txErr, "транзакция должна откатиться")
+		assert.Equal(t, 0, result.Added)
+		assert.Equal(t, BatchItemSkipped, result.Items[0].Status)
+		assert.Equal(t, uuid.Nil, result.Items[0].Product.ID)
+		assert.Equal(t, BatchItemRejected, result.Items[1].Status)
+		assert.ErrorIs(t, result.Items[1].Err, domain.ErrDuplicateBarcode)
+	})
+
+	t.Run("Fail - No Open Reception", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), newTestProductTypeRepo(), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+
+		_, err := receptionService.AddProductsBatch(ctx, testPVZID, testEmployee, inputs[:1], false)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrNoOpenReception)
+		mockReceptionRepo.AssertNotCalled(t, "AddProductsToReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Product Type Repository Error", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		typeRepo := new(mocks.ProductTypeRepository)
+		repoError := errors.New("DB error product types")
+		typeRepo.On("GetProductType", mock.Anything, mock.Anything).Return(domain.ProductTypeInfo{}, repoError).Once()
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), typeRepo, newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		_, err := receptionService.AddProductsBatch(ctx, testPVZID, testEmployee, inputs, false)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+		mockReceptionRepo.AssertNotCalled(t, "GetLastOpenReceptionByPVZ", mock.Anything, mock.Anything)
+	})
 }
 
 // Тесты для DeleteLastProduct
@@ -177,16 +609,20 @@ func TestReceptionService_DeleteLastProduct(t *testing.T) {
 	testProductID := uuid.New()
 	openReception := domain.Reception{ID: testReceptionID, PVZID: testPVZID, Status: domain.StatusInProgress}
 	lastProduct := domain.Product{ID: testProductID, ReceptionID: testReceptionID, Type: domain.TypeShoes}
+	actor := testEmployee
 
 	t.Run("Success", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
 		mockReceptionRepo.On("GetLastProductFromReception", mock.Anything, testReceptionID).Return(lastProduct, nil).Once()
-		mockReceptionRepo.On("DeleteProductByID", mock.Anything, testProductID).Return(nil).Once()
+		mockReceptionRepo.On("DeleteProductByID", mock.Anything, testProductID, actor).Return(nil).Once()
+		mockReceptionRepo.On("RecordProductDeletion", mock.Anything, mock.MatchedBy(func(d domain.ProductDeletion) bool {
+			return d.Product.ID == testProductID && d.DeletedBy == actor && d.Reason != ""
+		})).Return(nil).Once()
 
-		err := receptionService.DeleteLastProduct(ctx, testPVZID)
+		err := receptionService.DeleteLastProduct(ctx, testPVZID, actor)
 
 		assert.NoError(t, err)
 		mockReceptionRepo.AssertExpectations(t)
@@ -194,37 +630,201 @@ func TestReceptionService_DeleteLastProduct(t *testing.T) {
 
 	t.Run("Fail - No Open Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
 
-		err := receptionService.DeleteLastProduct(ctx, testPVZID)
+		err := receptionService.DeleteLastProduct(ctx, testPVZID, actor)
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "нет открытой приемки для данного ПВЗ, чтобы удалить товар")
 		mockReceptionRepo.AssertExpectations(t)
 		mockReceptionRepo.AssertNotCalled(t, "GetLastProductFromReception", mock.Anything, mock.Anything)
-		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("Fail - No Products in Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
 		mockReceptionRepo.On("GetLastProductFromReception", mock.Anything, testReceptionID).Return(domain.Product{}, repository.ErrProductNotFound).Once()
 
-		err := receptionService.DeleteLastProduct(ctx, testPVZID)
+		err := receptionService.DeleteLastProduct(ctx, testPVZID, actor)
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "в текущей открытой приемке нет товаров для удаления")
 		mockReceptionRepo.AssertExpectations(t)
-		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	// TODO: Добавить тесты на ошибки репозитория при поиске приемки, поиске товара, удалении товара
 }
 
+func TestReceptionService_UndoLastDeletion(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	testReceptionID := uuid.New()
+	testProductID := uuid.New()
+	actor := testEmployee
+	openReception := domain.Reception{ID: testReceptionID, PVZID: testPVZID, Status: domain.StatusInProgress}
+	deletedAt := time.Now()
+	deletedProduct := domain.Product{ID: testProductID, ReceptionID: testReceptionID, Type: domain.TypeShoes, Barcode: "4601234567893", DeletedAt: &deletedAt}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetLastDeletedProductFromReception", mock.Anything, testReceptionID).Return(deletedProduct, nil).Once()
+		mockReceptionRepo.On("RestoreProduct", mock.Anything, testProductID).Return(nil).Once()
+
+		product, err := receptionService.UndoLastDeletion(ctx, testPVZID, actor)
+
+		require.NoError(t, err)
+		assert.Equal(t, testProductID, product.ID)
+		assert.Nil(t, product.DeletedAt)
+	})
+
+	t.Run("Fail - No Open Reception", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+
+		_, err := receptionService.UndoLastDeletion(ctx, testPVZID, actor)
+
+		assert.ErrorIs(t, err, domain.ErrNoOpenReception)
+	})
+
+	t.Run("Fail - Nothing To Restore", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetLastDeletedProductFromReception", mock.Anything, testReceptionID).Return(domain.Product{}, repository.ErrProductNotFound).Once()
+
+		_, err := receptionService.UndoLastDeletion(ctx, testPVZID, actor)
+
+		assert.ErrorIs(t, err, domain.ErrNoDeletedProduct)
+	})
+
+	t.Run("Fail - Barcode Scanned Again", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetLastDeletedProductFromReception", mock.Anything, testReceptionID).Return(deletedProduct, nil).Once()
+		mockReceptionRepo.On("RestoreProduct", mock.Anything, testProductID).Return(repository.ErrProductBarcodeDuplicate).Once()
+
+		_, err := receptionService.UndoLastDeletion(ctx, testPVZID, actor)
+
+		assert.ErrorIs(t, err, domain.ErrDuplicateBarcode)
+	})
+}
+
+func TestReceptionService_DeleteProduct(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	testReceptionID := uuid.New()
+	testProductID := uuid.New()
+	actor := testEmployee
+	openReception := domain.Reception{ID: testReceptionID, PVZID: testPVZID, Status: domain.StatusInProgress}
+	product := domain.Product{ID: testProductID, ReceptionID: testReceptionID, Type: domain.TypeShoes}
+
+	strictPVZRepo := func(t *testing.T) *mocks.PVZRepository {
+		pvzRepo := mocks.NewPVZRepository(t)
+		pvzRepo.On("GetPVZByID", mock.Anything, testPVZID).
+			Return(domain.PVZ{ID: testPVZID, City: "Москва", IsActive: true, StrictLIFO: true}, nil).Once()
+		return pvzRepo
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetProductByID", mock.Anything, testProductID).Return(product, nil).Once()
+		mockReceptionRepo.On("DeleteProductByID", mock.Anything, testProductID, actor).Return(nil).Once()
+		mockReceptionRepo.On("RecordProductDeletion", mock.Anything, mock.MatchedBy(func(d domain.ProductDeletion) bool {
+			return d.Product.ID == testProductID && d.DeletedBy == actor && d.Reason == "пересорт"
+		})).Return(nil).Once()
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "  пересорт ")
+
+		assert.NoError(t, err)
+		mockReceptionRepo.AssertNotCalled(t, "GetLastProductFromReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Empty Reason", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), new(mocks.Transactor))
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "   ")
+
+		assert.ErrorIs(t, err, domain.ErrDeletionReason)
+	})
+
+	t.Run("Fail - Reception Closed", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		closedReception := openReception
+		closedReception.Status = domain.StatusClosed
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "пересорт")
+
+		assert.ErrorIs(t, err, domain.ErrReceptionClosed)
+		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Product From Another Reception", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		foreignProduct := product
+		foreignProduct.ReceptionID = uuid.New()
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetProductByID", mock.Anything, testProductID).Return(foreignProduct, nil).Once()
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "пересорт")
+
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Strict LIFO Not Last Product", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, strictPVZRepo(t), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetProductByID", mock.Anything, testProductID).Return(product, nil).Once()
+		mockReceptionRepo.On("GetLastProductFromReception", mock.Anything, testReceptionID).
+			Return(domain.Product{ID: uuid.New(), ReceptionID: testReceptionID}, nil).Once()
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "пересорт")
+
+		assert.ErrorIs(t, err, domain.ErrStrictLIFO)
+		mockReceptionRepo.AssertNotCalled(t, "DeleteProductByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Strict LIFO Last Product", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, strictPVZRepo(t), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("GetProductByID", mock.Anything, testProductID).Return(product, nil).Once()
+		mockReceptionRepo.On("GetLastProductFromReception", mock.Anything, testReceptionID).Return(product, nil).Once()
+		mockReceptionRepo.On("DeleteProductByID", mock.Anything, testProductID, actor).Return(nil).Once()
+		mockReceptionRepo.On("RecordProductDeletion", mock.Anything, mock.AnythingOfType("domain.ProductDeletion")).Return(nil).Once()
+
+		err := receptionService.DeleteProduct(ctx, testReceptionID, testProductID, actor, "пересорт")
+
+		assert.NoError(t, err)
+	})
+}
+
 // Тесты для CloseLastReception
 func TestReceptionService_CloseLastReception(t *testing.T) {
 	ctx := context.Background()
@@ -234,42 +834,57 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
-		mockReceptionRepo.On("CloseReceptionByID", mock.Anything, testReceptionID).Return(nil).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusInProgress, domain.StatusClosed, testEmployee).Return(nil).Once()
 
-		closedReception, err := receptionService.CloseLastReception(ctx, testPVZID)
+		closedReception, err := receptionService.CloseLastReception(ctx, testPVZID, testEmployee)
 
 		require.NoError(t, err)
 		assert.Equal(t, testReceptionID, closedReception.ID)
 		assert.Equal(t, domain.StatusClosed, closedReception.Status) // Проверяем статус
+		assert.Equal(t, &testEmployee.UserID, closedReception.ClosedBy)
 		mockReceptionRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - Token Without User", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		dummyActor := domain.Actor{Role: domain.RoleModerator} // Токен /dummyLogin: sub пустой
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusInProgress, domain.StatusClosed, dummyActor).Return(nil).Once()
+
+		closedReception, err := receptionService.CloseLastReception(ctx, testPVZID, dummyActor)
+
+		require.NoError(t, err)
+		assert.Nil(t, closedReception.ClosedBy)
+	})
+
 	t.Run("Fail - No Open Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
 
-		_, err := receptionService.CloseLastReception(ctx, testPVZID)
+		_, err := receptionService.CloseLastReception(ctx, testPVZID, testEmployee)
 
 		require.Error(t, err)
 		assert.EqualError(t, err, "нет открытой приемки для данного ПВЗ для закрытия")
 		mockReceptionRepo.AssertExpectations(t)
-		mockReceptionRepo.AssertNotCalled(t, "CloseReceptionByID", mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "UpdateReceptionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("Fail - Error Closing Reception", func(t *testing.T) {
 		mockReceptionRepo := new(mocks.ReceptionRepository) // ИСПРАВЛЕНО
-		receptionService := NewReceptionService(mockReceptionRepo)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
 		repoError := errors.New("DB error close reception")
 
 		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
-		mockReceptionRepo.On("CloseReceptionByID", mock.Anything, testReceptionID).Return(repoError).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusInProgress, domain.StatusClosed, testEmployee).Return(repoError).Once()
 
-		_, err := receptionService.CloseLastReception(ctx, testPVZID)
+		_, err := receptionService.CloseLastReception(ctx, testPVZID, testEmployee)
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, repoError)
@@ -278,3 +893,354 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 
 	// TODO: Добавить тест на ошибку поиска открытой приемки
 }
+
+func TestReceptionService_ReopenReception(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	testReceptionID := uuid.New()
+	closedReception := domain.Reception{ID: testReceptionID, PVZID: testPVZID, Status: domain.StatusClosed, DateTime: time.Now()}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+		mockReceptionRepo.On("HasNewerReception", mock.Anything, closedReception).Return(false, nil).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusClosed, domain.StatusInProgress, testModerator).Return(nil).Once()
+
+		reception, err := receptionService.ReopenReception(ctx, testReceptionID, testModerator)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, reception.Status)
+	})
+
+	t.Run("Fail - Newer Reception Exists", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+		mockReceptionRepo.On("HasNewerReception", mock.Anything, closedReception).Return(true, nil).Once()
+
+		_, err := receptionService.ReopenReception(ctx, testReceptionID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrNewerReceptionExists)
+		mockReceptionRepo.AssertNotCalled(t, "UpdateReceptionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Reception Not Closed", func(t *testing.T) {
+		for _, status := range []domain.ReceptionStatus{domain.StatusInProgress, domain.StatusCancelled} {
+			mockReceptionRepo := mocks.NewReceptionRepository(t)
+			receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+			reception := closedReception
+			reception.Status = status
+			mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(reception, nil).Once()
+
+			_, err := receptionService.ReopenReception(ctx, testReceptionID, testModerator)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidReceptionTransition, "status %s", status)
+		}
+	})
+
+	t.Run("Fail - PVZ Inactive", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		mockPVZRepo := mocks.NewPVZRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, mockPVZRepo, new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+		mockPVZRepo.On("GetPVZByID", mock.Anything, testPVZID).Return(domain.PVZ{ID: testPVZID, IsActive: false}, nil).Once()
+
+		_, err := receptionService.ReopenReception(ctx, testReceptionID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrPVZInactive)
+	})
+
+	t.Run("Fail - Another Reception Open", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, newActivePVZRepo(t, testPVZID), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closedReception, nil).Once()
+		mockReceptionRepo.On("HasNewerReception", mock.Anything, closedReception).Return(false, nil).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusClosed, domain.StatusInProgress, testModerator).
+			Return(repository.ErrReceptionAlreadyOpen).Once()
+
+		_, err := receptionService.ReopenReception(ctx, testReceptionID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrReceptionAlreadyOpen)
+	})
+}
+
+func TestReceptionService_CancelReception(t *testing.T) {
+	ctx := context.Background()
+	testReceptionID := uuid.New()
+	openReception := domain.Reception{ID: testReceptionID, PVZID: uuid.New(), Status: domain.StatusInProgress, DateTime: time.Now()}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("UpdateReceptionStatus", mock.Anything, testReceptionID, domain.StatusInProgress, domain.StatusCancelled, testEmployee).Return(nil).Once()
+
+		reception, err := receptionService.CancelReception(ctx, testReceptionID, testEmployee)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCancelled, reception.Status)
+	})
+
+	t.Run("Fail - Already Closed", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		closed := openReception
+		closed.Status = domain.StatusClosed
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(closed, nil).Once()
+
+		_, err := receptionService.CancelReception(ctx, testReceptionID, testEmployee)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidReceptionTransition)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+
+		_, err := receptionService.CancelReception(ctx, testReceptionID, testEmployee)
+
+		assert.ErrorIs(t, err, domain.ErrReceptionNotFound)
+	})
+}
+
+func TestReceptionService_GetReception(t *testing.T) {
+	ctx := context.Background()
+	testReceptionID := uuid.New()
+	reception := domain.Reception{ID: testReceptionID, PVZID: uuid.New(), Status: domain.StatusClosed, DateTime: time.Now()}
+	products := []domain.Product{
+		{ID: uuid.New(), ReceptionID: testReceptionID, Type: domain.TypeShoes},
+		{ID: uuid.New(), ReceptionID: testReceptionID, Type: domain.TypeClothes},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(reception, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{testReceptionID}, false).Return(products, nil).Once()
+
+		details, err := receptionService.GetReception(ctx, testReceptionID, testEmployee, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, reception, details.Reception)
+		assert.Equal(t, products, details.Products)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+
+		_, err := receptionService.GetReception(ctx, testReceptionID, testEmployee, false)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrReceptionNotFound)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Error Listing Products", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		repoError := errors.New("DB error list products")
+
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, testReceptionID).Return(reception, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{testReceptionID}, false).Return(nil, repoError).Once()
+
+		_, err := receptionService.GetReception(ctx, testReceptionID, testEmployee, false)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+}
+
+func TestReceptionService_GetCurrentReception(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	openReception := domain.Reception{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusInProgress, DateTime: time.Now()}
+
+	t.Run("Success", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{openReception.ID}, false).Return([]domain.Product{}, nil).Once()
+
+		details, err := receptionService.GetCurrentReception(ctx, testPVZID, testEmployee, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, openReception, details.Reception)
+		assert.Empty(t, details.Products)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - No Open Reception", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(domain.Reception{}, repository.ErrReceptionNotFound).Once()
+
+		_, err := receptionService.GetCurrentReception(ctx, testPVZID, testEmployee, false)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrReceptionNotFound)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+}
+
+func TestReceptionService_ListReceptions(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+	now := time.Now()
+	receptions := []domain.Reception{
+		{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusInProgress, DateTime: now},
+		{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusClosed, DateTime: now.Add(-time.Hour)},
+	}
+
+	t.Run("Success - Full Page Returns Cursor", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		filter := domain.ReceptionFilter{Limit: 2}
+
+		mockReceptionRepo.On("ListReceptionsByPVZ", mock.Anything, testPVZID, filter).Return(receptions, nil).Once()
+
+		result, err := receptionService.ListReceptions(ctx, testPVZID, testEmployee, filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, receptions, result.Receptions)
+		require.NotNil(t, result.NextAfterDateTime)
+		require.NotNil(t, result.NextAfterID)
+		assert.Equal(t, receptions[1].DateTime, *result.NextAfterDateTime)
+		assert.Equal(t, receptions[1].ID, *result.NextAfterID)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Last Page Without Cursor", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		status := domain.StatusClosed
+		filter := domain.ReceptionFilter{Limit: 10, Status: &status}
+
+		mockReceptionRepo.On("ListReceptionsByPVZ", mock.Anything, testPVZID, filter).Return(receptions[1:], nil).Once()
+
+		result, err := receptionService.ListReceptions(ctx, testPVZID, testEmployee, filter)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Receptions, 1)
+		assert.Nil(t, result.NextAfterDateTime)
+		assert.Nil(t, result.NextAfterID)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail - Repository Error", func(t *testing.T) {
+		mockReceptionRepo := new(mocks.ReceptionRepository)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+		repoError := errors.New("DB error list receptions")
+		filter := domain.ReceptionFilter{Limit: 10}
+
+		mockReceptionRepo.On("ListReceptionsByPVZ", mock.Anything, testPVZID, filter).Return(nil, repoError).Once()
+
+		_, err := receptionService.ListReceptions(ctx, testPVZID, testEmployee, filter)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+		mockReceptionRepo.AssertExpectations(t)
+	})
+}
+
+func TestReceptionService_PVZAssignment(t *testing.T) {
+	ctx := context.Background()
+	testPVZID := uuid.New()
+
+	t.Run("Fail - Employee Not Assigned", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, mocks.NewPVZRepository(t), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		access.On("IsAssigned", mock.Anything, testEmployee.UserID, testPVZID).Return(false, nil).Once()
+
+		_, err := receptionService.InitiateReception(ctx, testPVZID, testEmployee)
+
+		assert.ErrorIs(t, err, domain.ErrPVZNotAssigned)
+		mockReceptionRepo.AssertNotCalled(t, "GetLastOpenReceptionByPVZ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Moderator Is Exempt", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		openReception := domain.Reception{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusInProgress}
+		mockReceptionRepo.On("GetLastOpenReceptionByPVZ", mock.Anything, testPVZID).Return(openReception, nil).Once()
+		mockReceptionRepo.On("ListProductsByReceptionIDs", mock.Anything, []uuid.UUID{openReception.ID}, false).Return([]domain.Product{}, nil).Once()
+
+		_, err := receptionService.GetCurrentReception(ctx, testPVZID, testModerator, false)
+
+		require.NoError(t, err)
+		access.AssertNotCalled(t, "IsAssigned", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - System Actor Is Exempt", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		filter := domain.ReceptionFilter{Limit: 10}
+		mockReceptionRepo.On("ListReceptionsByPVZ", mock.Anything, testPVZID, filter).Return([]domain.Reception{}, nil).Once()
+
+		_, err := receptionService.ListReceptions(ctx, testPVZID, domain.SystemActor, filter)
+
+		require.NoError(t, err)
+		access.AssertNotCalled(t, "IsAssigned", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Employee Without User", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+
+		// Токен /dummyLogin: sub пустой, назначений нет
+		_, err := receptionService.ListReceptions(ctx, testPVZID, domain.Actor{Role: domain.RoleEmployee}, domain.ReceptionFilter{Limit: 10})
+
+		assert.ErrorIs(t, err, domain.ErrPVZNotAssigned)
+		access.AssertNotCalled(t, "IsAssigned", mock.Anything, mock.Anything, mock.Anything)
+		mockReceptionRepo.AssertNotCalled(t, "ListReceptionsByPVZ", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Reception Of Foreign PVZ", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		reception := domain.Reception{ID: uuid.New(), PVZID: testPVZID, Status: domain.StatusClosed}
+		mockReceptionRepo.On("GetReceptionByID", mock.Anything, reception.ID).Return(reception, nil).Once()
+		access.On("IsAssigned", mock.Anything, testEmployee.UserID, testPVZID).Return(false, nil).Once()
+
+		_, err := receptionService.GetReception(ctx, reception.ID, testEmployee, false)
+
+		assert.ErrorIs(t, err, domain.ErrPVZNotAssigned)
+		mockReceptionRepo.AssertNotCalled(t, "ListProductsByReceptionIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Assignment Check Error", func(t *testing.T) {
+		mockReceptionRepo := mocks.NewReceptionRepository(t)
+		access := mocks.NewAssignmentRepository(t)
+		receptionService := NewReceptionService(mockReceptionRepo, mocks.NewPVZRepository(t), new(mocks.ProductTypeRepository), access, newPassthroughTransactor(t))
+		repoError := errors.New("DB error is assigned")
+		access.On("IsAssigned", mock.Anything, testEmployee.UserID, testPVZID).Return(false, repoError).Once()
+
+		_, err := receptionService.CloseLastReception(ctx, testPVZID, testEmployee)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repoError)
+		assert.NotErrorIs(t, err, domain.ErrPVZNotAssigned)
+	})
+}
@@ -11,28 +11,187 @@ import (
 // AuthService определяет методы для сервиса аутентификации.
 // Эти методы будут использоваться в API слое (хендлеры, middleware).
 type AuthService interface {
-	Register(ctx context.Context, email, password, role string) (domain.User, error)
-	Login(ctx context.Context, email, password string) (string, error) // Возвращает токен или ошибку
-	// GenerateToken создает новый JWT для указанной роли.
+	// Register регистрирует пользователя. inviteToken - приглашение модератора (пусто - самостоятельная регистрация).
+	Register(ctx context.Context, email, password, role, inviteToken string) (domain.User, error)
+	// Login проверяет пароль и возвращает пару токенов. После серии неудач по email или clientIP
+	// возвращает *LoginThrottledError (domain.ErrLoginThrottled) без проверки пароля.
+	Login(ctx context.Context, email, password, clientIP string) (TokenPair, error)
+	// RefreshTokens обменивает refresh-токен на новую пару (ротация в пределах семейства).
+	RefreshTokens(ctx context.Context, refreshToken string) (TokenPair, error)
+	// Logout отзывает текущий access-токен и, если передан, семейство refresh-токена.
+	Logout(ctx context.Context, principal domain.Principal, refreshToken string) error
+	// ChangePassword меняет пароль пользователя токена после проверки текущего. Все ранее выданные
+	// токены пользователя отзываются; возвращается новая пара, чтобы клиент остался в системе.
+	ChangePassword(ctx context.Context, principal domain.Principal, currentPassword, newPassword string) (TokenPair, error)
+	// RequestPasswordReset создает одноразовый токен сброса и отправляет его через Notifier.
+	// Для незарегистрированного или отключенного email ничего не делает и тоже возвращает nil.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword устанавливает новый пароль по токену сброса и отзывает все токены пользователя.
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	// GenerateToken создает новый JWT для указанной роли без пользователя (/dummyLogin).
 	GenerateToken(role string) (string, error)
 	// ValidateToken проверяет токен. Возвращает роль или другую информацию,
 	// если токен валиден, и ошибку в противном случае.
 	// Вместо *Claims можно вернуть просто роль (string) или кастомную структуру UserInfo.
 	// Давайте вернем *Claims, как было в реализации.
 	ValidateToken(tokenString string) (*Claims, error) // Возвращаем *Claims (структура из auth_service.go)
+	// JWKS возвращает открытые ключи подписи (для HS256 - пустой набор).
+	JWKS() JSONWebKeySet
 }
 
 // ReceptionService определяет методы для управления приемками товаров.
+// Сотрудник работает только с приемками ПВЗ, на которые назначен (иначе domain.ErrPVZNotAssigned), модераторы - с любыми.
+//
+//go:generate mockery --name ReceptionService --output ./mocks --outpkg mocks --case underscore --filename reception_service_mock.go
 type ReceptionService interface {
 	// InitiateReception начинает новую приемку для указанного ПВЗ
-	InitiateReception(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error)
+	// actor - кто начинает приемку (сохраняется в created_by)
+	InitiateReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error)
 	// Добавляем метод добавления товара
-	// Принимает ID ПВЗ (чтобы найти нужную приемку) и данные товара
-	AddProduct(ctx context.Context, pvzID uuid.UUID, productType domain.ProductType) (domain.Product, error)
-	// Добавляем метод удаления последнего товара
-	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
-	// Возвращает данные закрытой приемки или ошибку
-	CloseLastReception(ctx context.Context, pvzID uuid.UUID) (domain.Reception, error)
+	// Принимает ID ПВЗ (чтобы найти нужную приемку) и данные товара; тип и атрибуты проверяются по справочнику
+	AddProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, input domain.ProductInput) (domain.Product, error)
+	// DeleteLastProduct мягко удаляет последний добавленный товар открытой приемки (actor сохраняется в аудите)
+	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) error
+	// DeleteProduct удаляет конкретный товар из открытой приемки, сохраняя в аудите actor и reason
+	DeleteProduct(ctx context.Context, receptionID, productID uuid.UUID, actor domain.Actor, reason string) error
+	// UndoLastDeletion восстанавливает последний удаленный товар открытой приемки ПВЗ
+	UndoLastDeletion(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Product, error)
+	// Возвращает данные закрытой приемки или ошибку; actor сохраняется в closed_by
+	CloseLastReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor) (domain.Reception, error)
+	// ReopenReception возвращает закрытую приемку в работу, если у ПВЗ нет более новой приемки (closed_by очищается)
+	ReopenReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error)
+	// CancelReception переводит открытую приемку в статус cancelled; actor сохраняется в closed_by
+	CancelReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor) (domain.Reception, error)
+	// GetReception возвращает приемку по ID вместе с ее товарами (удаленные - только при includeDeleted)
+	GetReception(ctx context.Context, receptionID uuid.UUID, actor domain.Actor, includeDeleted bool) (ReceptionDetails, error)
+	// GetCurrentReception возвращает открытую приемку ПВЗ вместе с ее товарами (удаленные - только при includeDeleted)
+	GetCurrentReception(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, includeDeleted bool) (ReceptionDetails, error)
+	// ListReceptions возвращает страницу истории приемок ПВЗ и курсор следующей страницы
+	ListReceptions(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, filter domain.ReceptionFilter) (ListReceptionsResult, error)
+	// FindProductsByBarcode возвращает товары с указанным штрихкодом в приемках ПВЗ, доступных actor
+	FindProductsByBarcode(ctx context.Context, barcode string, actor domain.Actor) ([]domain.Product, error)
+	// AddProductsBatch добавляет пачку товаров в открытую приемку ПВЗ одной транзакцией и возвращает результат по каждому товару.
+	// При allOrNothing товары добавляются, только если приняты все.
+	AddProductsBatch(ctx context.Context, pvzID uuid.UUID, actor domain.Actor, inputs []domain.ProductInput, allOrNothing bool) (ProductBatchResult, error)
+	// ProcessStaleReceptions закрывает или помечает одну пачку приемок, зависших на момент now (от имени domain.SystemActor)
+	ProcessStaleReceptions(ctx context.Context, policy StaleReceptionPolicy, now time.Time) (StaleSweepResult, error)
+}
+
+// CityService определяет методы управления справочником городов (только модератор).
+type CityService interface {
+	// CreateCity добавляет город в справочник
+	CreateCity(ctx context.Context, city domain.City) (domain.City, error)
+	// GetCity возвращает город по коду
+	GetCity(ctx context.Context, code string) (domain.City, error)
+	// ListCities возвращает города (неактивные - только при includeInactive)
+	ListCities(ctx context.Context, includeInactive bool) ([]domain.City, error)
+	// UpdateCity изменяет поля города, заданные в update
+	UpdateCity(ctx context.Context, code string, update domain.CityUpdate) (domain.City, error)
+	// DeleteCity удаляет город, в котором нет ни одного ПВЗ
+	DeleteCity(ctx context.Context, code string) error
+}
+
+// UserService определяет методы администрирования пользователей (только модератор).
+type UserService interface {
+	// ListUsers возвращает страницу пользователей и курсор следующей страницы
+	ListUsers(ctx context.Context, filter domain.UserFilter) (ListUsersResult, error)
+	// GetUser возвращает пользователя по ID
+	GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
+	// DisableUser отключает учетную запись и отзывает ее токены
+	DisableUser(ctx context.Context, id uuid.UUID, actor domain.Actor) (domain.User, error)
+	// EnableUser снова разрешает вход в учетную запись
+	EnableUser(ctx context.Context, id uuid.UUID, actor domain.Actor) (domain.User, error)
+	// ChangeUserRole меняет роль пользователя и отзывает токены со старой ролью
+	ChangeUserRole(ctx context.Context, id uuid.UUID, role string, actor domain.Actor) (domain.User, error)
+	// CreateInvite создает приглашение на регистрацию с заданной ролью (email - необязательная привязка)
+	CreateInvite(ctx context.Context, role, email string, actor domain.Actor) (CreatedInvite, error)
+	// ListUserPVZs возвращает назначения пользователя на ПВЗ
+	ListUserPVZs(ctx context.Context, userID uuid.UUID) ([]domain.PVZAssignment, error)
+	// AssignPVZ назначает пользователя на ПВЗ (повторное назначение не ошибка)
+	AssignPVZ(ctx context.Context, userID, pvzID uuid.UUID, actor domain.Actor) error
+	// UnassignPVZ снимает назначение пользователя на ПВЗ
+	UnassignPVZ(ctx context.Context, userID, pvzID uuid.UUID, actor domain.Actor) error
+}
+
+// ListUsersResult - страница списка пользователей
+type ListUsersResult struct {
+	Users []domain.User
+	// Курсор следующей страницы; nil, если это последняя страница
+	NextAfterCreatedAt *time.Time
+	NextAfterID        *uuid.UUID
+}
+
+// CreatedInvite - созданное приглашение. Token возвращается только здесь, в БД хранится его хеш.
+type CreatedInvite struct {
+	Token  string
+	Invite domain.UserInvite
+}
+
+// ProductTypeService определяет методы управления справочником типов товаров.
+type ProductTypeService interface {
+	// CreateProductType добавляет тип товара в справочник
+	CreateProductType(ctx context.Context, productType domain.ProductTypeInfo) (domain.ProductTypeInfo, error)
+	// GetProductType возвращает тип товара по коду
+	GetProductType(ctx context.Context, code domain.ProductType) (domain.ProductTypeInfo, error)
+	// ListProductTypes возвращает типы товаров (неактивные - только при includeInactive)
+	ListProductTypes(ctx context.Context, includeInactive bool) ([]domain.ProductTypeInfo, error)
+	// UpdateProductType заменяет названия, активность и схему атрибутов типа с кодом productType.Code
+	UpdateProductType(ctx context.Context, productType domain.ProductTypeInfo) (domain.ProductTypeInfo, error)
+}
+
+// ReceptionDetails - приемка вместе с ее товарами (в порядке добавления)
+type ReceptionDetails struct {
+	Reception domain.Reception
+	Products  []domain.Product
+}
+
+// ListReceptionsResult - страница истории приемок ПВЗ
+type ListReceptionsResult struct {
+	Receptions []domain.Reception
+	// Курсор следующей страницы; nil, если это последняя страница
+	NextAfterDateTime *time.Time
+	NextAfterID       *uuid.UUID
+}
+
+// BatchItemStatus - итог обработки одного товара пачки
+type BatchItemStatus string
+
+const (
+	BatchItemAdded    BatchItemStatus = "added"    // Товар добавлен
+	BatchItemRejected BatchItemStatus = "rejected" // Товар отклонен, причина в Err
+	BatchItemSkipped  BatchItemStatus = "skipped"  // Товар корректен, но не добавлен: пачка allOrNothing отклонена из-за других товаров
+)
+
+// ProductBatchItem - результат по одному товару пачки (в порядке запроса)
+type ProductBatchItem struct {
+	Index   int // Позиция товара в запросе
+	Status  BatchItemStatus
+	Product domain.Product // Добавленный товар (только для BatchItemAdded)
+	Err     error          // Доменная ошибка (только для BatchItemRejected)
+}
+
+// ProductBatchResult - результат AddProductsBatch
+type ProductBatchResult struct {
+	ReceptionID uuid.UUID
+	Items       []ProductBatchItem
+	Added       int // Сколько товаров добавлено (0, если пачка allOrNothing отклонена)
+}
+
+// StaleReceptionPolicy - какие приемки считать зависшими и что с ними делать
+type StaleReceptionPolicy struct {
+	MaxAge    time.Duration // Открыта дольше; 0 - не проверять
+	MaxIdle   time.Duration // Нет новых товаров дольше; 0 - не проверять
+	Action    string        // config.StaleActionClose или config.StaleActionFlag
+	BatchSize int           // Сколько приемок обработать за один вызов
+}
+
+// StaleSweepResult - итог одного прохода по зависшим приемкам
+type StaleSweepResult struct {
+	Checked int // Сколько кандидатов вернул репозиторий
+	Closed  int
+	Flagged int
+	Skipped int // Заблокированы другим экземпляром сервиса или уже не зависли
+	Failed  int
 }
 
 // Claims определяет структуру полезной нагрузки токена (переносим сюда для видимости в интерфейсе)
@@ -54,6 +213,36 @@ type AuthService interface {
 // Проще всего оставить Claims в auth_service.go и интерфейс ValidateToken тоже вернет *Claims.
 */
 
+// Notifier доставляет пользователю токен сброса пароля (почта, мессенджер; локально - лог или файл).
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+// TokenPair - результат входа и обновления токенов.
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// JSONWebKey - открытый ключ в формате JWK (RFC 7517): RSA (n, e) или OKP Ed25519 (crv, x).
+type JSONWebKey struct {
+	Kty string
+	Kid string
+	Use string
+	Alg string
+	N   string
+	E   string
+	Crv string
+	X   string
+}
+
+// JSONWebKeySet - набор открытых ключей для /.well-known/jwks.json.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey
+}
+
 // --- Убедимся, что тип Claims видим ---
 // Поскольку Claims используется и в auth_service.go и возвращается из интерфейса,
 // его либо нужно вынести в отдельный пакет (например, domain), либо оставить в service
@@ -62,10 +251,23 @@ type AuthService interface {
 
 // В файле auth_service.go: Убедитесь, что структура Claims называется с большой буквы.
 // PVZService определяет методы бизнес-логики для ПВЗ
+//
+//go:generate mockery --name PVZService --output ./mocks --outpkg mocks --case underscore --filename pvz_service_mock.go
 type PVZService interface {
 	CreatePVZ(ctx context.Context, input domain.PVZ) (domain.PVZ, error)
-	GetPVZList(ctx context.Context, startDate, endDate *time.Time, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID) (GetPVZListResult, error)
-	// Другие методы, если есть...
+	// GetPVZList возвращает страницу ПВЗ с приемками; деактивированные ПВЗ - только при includeInactive
+	// assignedTo (если задан) оставляет только ПВЗ, на которые назначен этот пользователь
+	GetPVZList(ctx context.Context, startDate, endDate *time.Time, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (GetPVZListResult, error)
+	// ListPVZs возвращает страницу ПВЗ без приемок с тем же курсором и фильтрами, что и GetPVZList
+	ListPVZs(ctx context.Context, limit int, afterRegistrationDate *time.Time, afterID *uuid.UUID, includeInactive bool, assignedTo *uuid.UUID) (GetPVZListResult, error)
+	// GetPVZ возвращает ПВЗ по ID (в том числе деактивированный)
+	GetPVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error)
+	// UpdatePVZ изменяет поля ПВЗ, заданные в update
+	UpdatePVZ(ctx context.Context, id uuid.UUID, update domain.PVZUpdate) (domain.PVZ, error)
+	// DeactivatePVZ мягко деактивирует ПВЗ: он скрывается из списков и не принимает новые приемки
+	DeactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error)
+	// ReactivatePVZ возвращает деактивированный ПВЗ в работу
+	ReactivatePVZ(ctx context.Context, id uuid.UUID) (domain.PVZ, error)
 }
 
 // GetPVZListResult - структура для возврата результата из сервиса GetPVZList
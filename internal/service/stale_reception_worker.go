@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	mmetrics "github.com/Artem0405/pvz-service/internal/metrics"
+	"github.com/Artem0405/pvz-service/internal/repository"
+)
+
+// staleOutcome - итог обработки одной зависшей приемки
+type staleOutcome int
+
+const (
+	staleSkipped staleOutcome = iota
+	staleClosed
+	staleFlagged
+)
+
+// ProcessStaleReceptions находит до policy.BatchSize зависших приемок и закрывает или помечает их.
+// Каждая приемка обрабатывается в своей транзакции под advisory-блокировкой, поэтому несколько
+// экземпляров сервиса не трогают одну приемку одновременно. Ошибка по одной приемке не прерывает проход.
+func (s *receptionService) ProcessStaleReceptions(ctx context.Context, policy StaleReceptionPolicy, now time.Time) (StaleSweepResult, error) {
+	filter := domain.StaleReceptionFilter{
+		SkipFlagged: policy.Action == config.StaleActionFlag, // Помеченные повторно не помечаем
+		Limit:       policy.BatchSize,
+	}
+	if policy.MaxAge > 0 {
+		openedBefore := now.Add(-policy.MaxAge)
+		filter.OpenedBefore = &openedBefore
+	}
+	if policy.MaxIdle > 0 {
+		idleBefore := now.Add(-policy.MaxIdle)
+		filter.IdleBefore = &idleBefore
+	}
+
+	candidates, err := s.repo.ListStaleReceptions(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка поиска зависших приемок", "error", err)
+		return StaleSweepResult{}, fmt.Errorf("ошибка поиска зависших приемок: %w", err)
+	}
+
+	result := StaleSweepResult{Checked: len(candidates)}
+	for _, candidate := range candidates {
+		var outcome staleOutcome
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			outcome, err = s.processStaleReception(ctx, candidate, policy, now)
+			return err
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Ошибка обработки зависшей приемки", "reception_id", candidate.ID, "error", err)
+			mmetrics.StaleReceptionsTotal.WithLabelValues("failed").Inc()
+			result.Failed++
+			continue
+		}
+		switch outcome {
+		case staleClosed:
+			mmetrics.StaleReceptionsTotal.WithLabelValues("closed").Inc()
+			result.Closed++
+		case staleFlagged:
+			mmetrics.StaleReceptionsTotal.WithLabelValues("flagged").Inc()
+			result.Flagged++
+		default:
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+// processStaleReception - обработка одной приемки, выполняется внутри транзакции
+func (s *receptionService) processStaleReception(ctx context.Context, candidate domain.Reception, policy StaleReceptionPolicy, now time.Time) (staleOutcome, error) {
+	// 1. Приемку уже обрабатывает другой экземпляр - не ждем его
+	locked, err := s.repo.TryLockReception(ctx, candidate.ID)
+	if err != nil {
+		return staleSkipped, err
+	}
+	if !locked {
+		slog.DebugContext(ctx, "Зависшая приемка обрабатывается другим экземпляром", "reception_id", candidate.ID)
+		return staleSkipped, nil
+	}
+
+	// 2. Перечитываем приемку под блокировкой: за время после поиска ее могли закрыть или пополнить
+	reception, err := s.lockReception(ctx, candidate.ID, domain.SystemActor)
+	if err != nil {
+		if errors.Is(err, domain.ErrReceptionNotFound) {
+			return staleSkipped, nil
+		}
+		return staleSkipped, err
+	}
+	if reception.Status != domain.StatusInProgress {
+		return staleSkipped, nil
+	}
+	if policy.Action == config.StaleActionFlag && reception.StaleFlaggedAt != nil {
+		return staleSkipped, nil
+	}
+
+	reason, stale, err := s.staleReason(ctx, reception, policy, now)
+	if err != nil || !stale {
+		return staleSkipped, err
+	}
+
+	// 3. Закрываем или помечаем и пишем аудит от имени системы
+	entry := domain.ReceptionAuditEntry{ReceptionID: reception.ID, Actor: domain.SystemActor, Reason: reason}
+	outcome := staleClosed
+	if policy.Action == config.StaleActionFlag {
+		entry.Action = domain.AuditStaleFlag
+		outcome = staleFlagged
+		err = s.repo.FlagReceptionStale(ctx, reception.ID)
+	} else {
+		entry.Action = domain.AuditAutoClose
+		_, err = s.transitionReception(ctx, reception, domain.EventClose, domain.SystemActor)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrReceptionNotFound) {
+			return staleSkipped, nil
+		}
+		return staleSkipped, fmt.Errorf("не удалось обработать зависшую приемку: %w", err)
+	}
+	if err := s.repo.RecordReceptionAudit(ctx, entry); err != nil {
+		return staleSkipped, err
+	}
+
+	slog.InfoContext(ctx, "Зависшая приемка обработана", "reception_id", reception.ID, "pvz_id", reception.PVZID,
+		"action", entry.Action, "reason", reason)
+	return outcome, nil
+}
+
+// staleReason проверяет, зависла ли приемка на момент now, и возвращает причину для аудита
+func (s *receptionService) staleReason(ctx context.Context, reception domain.Reception, policy StaleReceptionPolicy, now time.Time) (string, bool, error) {
+	if policy.MaxAge > 0 && reception.DateTime.Before(now.Add(-policy.MaxAge)) {
+		return fmt.Sprintf("приемка открыта дольше %s", policy.MaxAge), true, nil
+	}
+	if policy.MaxIdle <= 0 {
+		return "", false, nil
+	}
+
+	lastActivity := reception.DateTime
+	lastProduct, err := s.repo.GetLastProductFromReception(ctx, reception.ID)
+	switch {
+	case err == nil:
+		lastActivity = lastProduct.DateTimeAdded
+	case !errors.Is(err, repository.ErrProductNotFound):
+		return "", false, fmt.Errorf("ошибка поиска последнего товара: %w", err)
+	}
+	if lastActivity.Before(now.Add(-policy.MaxIdle)) {
+		return fmt.Sprintf("нет новых товаров дольше %s", policy.MaxIdle), true, nil
+	}
+	return "", false, nil
+}
+
+// staleReceptionWorker - фоновый обработчик зависших приемок
+type staleReceptionWorker struct {
+	receptions ReceptionService
+	interval   time.Duration
+	policy     StaleReceptionPolicy
+}
+
+// NewStaleReceptionWorker - конструктор
+func NewStaleReceptionWorker(cfg config.StaleReceptionsConfig, receptions ReceptionService) *staleReceptionWorker {
+	return &staleReceptionWorker{
+		receptions: receptions,
+		interval:   cfg.Interval,
+		policy: StaleReceptionPolicy{
+			MaxAge:    cfg.MaxAge,
+			MaxIdle:   cfg.MaxIdle,
+			Action:    cfg.Action,
+			BatchSize: cfg.BatchSize,
+		},
+	}
+}
+
+// Run обрабатывает зависшие приемки сразу и затем раз в interval, пока не отменен ctx.
+// За один проход обрабатывается не больше BatchSize приемок; остальные - на следующих проходах.
+func (w *staleReceptionWorker) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Обработчик зависших приемок запущен", "interval", w.interval,
+		"max_age", w.policy.MaxAge, "max_idle", w.policy.MaxIdle, "action", w.policy.Action)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "Обработчик зависших приемок остановлен")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep - один проход обработчика
+func (w *staleReceptionWorker) sweep(ctx context.Context) {
+	result, err := w.receptions.ProcessStaleReceptions(ctx, w.policy, time.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Проход по зависшим приемкам завершился ошибкой", "error", err)
+		}
+		return
+	}
+	if result.Checked > 0 {
+		slog.InfoContext(ctx, "Проход по зависшим приемкам завершен", "checked", result.Checked,
+			"closed", result.Closed, "flagged", result.Flagged, "skipped", result.Skipped, "failed", result.Failed)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceptionService_ProcessStaleReceptions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	closePolicy := StaleReceptionPolicy{MaxAge: 24 * time.Hour, MaxIdle: 2 * time.Hour, Action: config.StaleActionClose, BatchSize: 10}
+	flagPolicy := closePolicy
+	flagPolicy.Action = config.StaleActionFlag
+
+	// Открыта 3 часа назад: по возрасту не зависла, зависание определяется по последнему товару
+	idleReception := domain.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusInProgress, DateTime: now.Add(-3 * time.Hour)}
+	oldReception := domain.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: domain.StatusInProgress, DateTime: now.Add(-48 * time.Hour)}
+
+	newService := func(t *testing.T, repo *mocks.ReceptionRepository) ReceptionService {
+		return NewReceptionService(repo, new(mocks.PVZRepository), new(mocks.ProductTypeRepository), newAssignedEverywhere(t), newPassthroughTransactor(t))
+	}
+	isAudit := func(receptionID uuid.UUID, action domain.ReceptionAuditAction) any {
+		return mock.MatchedBy(func(e domain.ReceptionAuditEntry) bool {
+			return e.ReceptionID == receptionID && e.Action == action && e.Actor == domain.SystemActor && e.Reason != ""
+		})
+	}
+
+	t.Run("Success - Close By Age", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		repo.On("ListStaleReceptions", mock.Anything, mock.MatchedBy(func(f domain.StaleReceptionFilter) bool {
+			return f.OpenedBefore.Equal(now.Add(-24*time.Hour)) && f.IdleBefore.Equal(now.Add(-2*time.Hour)) && !f.SkipFlagged && f.Limit == 10
+		})).Return([]domain.Reception{oldReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, oldReception.ID).Return(true, nil).Once()
+		repo.On("GetReceptionByID", mock.Anything, oldReception.ID).Return(oldReception, nil).Once()
+		repo.On("UpdateReceptionStatus", mock.Anything, oldReception.ID, domain.StatusInProgress, domain.StatusClosed, domain.SystemActor).Return(nil).Once()
+		repo.On("RecordReceptionAudit", mock.Anything, isAudit(oldReception.ID, domain.AuditAutoClose)).Return(nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 1, Closed: 1}, result)
+		// Возраст уже превышен - последний товар не запрашиваем
+		repo.AssertNotCalled(t, "GetLastProductFromReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Flag Idle Reception", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		repo.On("ListStaleReceptions", mock.Anything, mock.MatchedBy(func(f domain.StaleReceptionFilter) bool { return f.SkipFlagged })).
+			Return([]domain.Reception{idleReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, idleReception.ID).Return(true, nil).Once()
+		repo.On("GetReceptionByID", mock.Anything, idleReception.ID).Return(idleReception, nil).Once()
+		repo.On("GetLastProductFromReception", mock.Anything, idleReception.ID).
+			Return(domain.Product{ID: uuid.New(), DateTimeAdded: now.Add(-150 * time.Minute)}, nil).Once()
+		repo.On("FlagReceptionStale", mock.Anything, idleReception.ID).Return(nil).Once()
+		repo.On("RecordReceptionAudit", mock.Anything, isAudit(idleReception.ID, domain.AuditStaleFlag)).Return(nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, flagPolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 1, Flagged: 1}, result)
+		repo.AssertNotCalled(t, "UpdateReceptionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skip - Locked By Another Instance", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		repo.On("ListStaleReceptions", mock.Anything, mock.Anything).Return([]domain.Reception{oldReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, oldReception.ID).Return(false, nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 1, Skipped: 1}, result)
+		repo.AssertNotCalled(t, "GetReceptionByID", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "RecordReceptionAudit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skip - Product Added Since Listing", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		repo.On("ListStaleReceptions", mock.Anything, mock.Anything).Return([]domain.Reception{idleReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, idleReception.ID).Return(true, nil).Once()
+		repo.On("GetReceptionByID", mock.Anything, idleReception.ID).Return(idleReception, nil).Once()
+		repo.On("GetLastProductFromReception", mock.Anything, idleReception.ID).
+			Return(domain.Product{ID: uuid.New(), DateTimeAdded: now.Add(-time.Minute)}, nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 1, Skipped: 1}, result)
+		repo.AssertNotCalled(t, "UpdateReceptionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skip - Closed Since Listing", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		closed := oldReception
+		closed.Status = domain.StatusClosed
+		repo.On("ListStaleReceptions", mock.Anything, mock.Anything).Return([]domain.Reception{oldReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, oldReception.ID).Return(true, nil).Once()
+		repo.On("GetReceptionByID", mock.Anything, oldReception.ID).Return(closed, nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 1, Skipped: 1}, result)
+	})
+
+	t.Run("Failure Does Not Stop Sweep", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		dbErr := errors.New("db error")
+		repo.On("ListStaleReceptions", mock.Anything, mock.Anything).Return([]domain.Reception{oldReception, idleReception}, nil).Once()
+		repo.On("TryLockReception", mock.Anything, oldReception.ID).Return(false, dbErr).Once()
+		repo.On("TryLockReception", mock.Anything, idleReception.ID).Return(true, nil).Once()
+		repo.On("GetReceptionByID", mock.Anything, idleReception.ID).Return(idleReception, nil).Once()
+		repo.On("GetLastProductFromReception", mock.Anything, idleReception.ID).Return(domain.Product{}, repository.ErrProductNotFound).Once()
+		repo.On("UpdateReceptionStatus", mock.Anything, idleReception.ID, domain.StatusInProgress, domain.StatusClosed, domain.SystemActor).Return(nil).Once()
+		repo.On("RecordReceptionAudit", mock.Anything, isAudit(idleReception.ID, domain.AuditAutoClose)).Return(nil).Once()
+
+		result, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StaleSweepResult{Checked: 2, Closed: 1, Failed: 1}, result)
+	})
+
+	t.Run("Fail - List Error", func(t *testing.T) {
+		repo := mocks.NewReceptionRepository(t)
+		repo.On("ListStaleReceptions", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		_, err := newService(t, repo).ProcessStaleReceptions(ctx, closePolicy, now)
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+// denylistSyncOverlap - запас при догрузке denylist: транзакция с более ранним revoked_at
+// может зафиксироваться позже уже прочитанных записей, поэтому окно перечитывается.
+const denylistSyncOverlap = time.Minute
+
+// TokenDenylist - кеш отозванных access-токенов (jti) поверх таблицы revoked_access_tokens.
+// ValidateToken проверяет только память; отзывы, сделанные другими экземплярами сервиса,
+// догружаются Sync (периодически - Run). Записи удаляются из кеша после истечения токена.
+type TokenDenylist struct {
+	repo repository.TokenRepository
+
+	mu          sync.RWMutex
+	entries     map[uuid.UUID]time.Time // jti -> срок действия токена
+	syncedUntil time.Time               // Наибольшее revoked_at среди загруженных записей
+}
+
+// NewTokenDenylist - конструктор. Перед приемом запросов нужно вызвать Sync.
+func NewTokenDenylist(repo repository.TokenRepository) *TokenDenylist {
+	return &TokenDenylist{
+		repo:    repo,
+		entries: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Contains сообщает, отозван ли еще не истекший токен с указанным jti.
+func (d *TokenDenylist) Contains(jti uuid.UUID) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	expiresAt, ok := d.entries[jti]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Add добавляет токены в кеш. Запись в БД делает вызывающий (в своей транзакции).
+func (d *TokenDenylist) Add(tokens ...domain.RevokedToken) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, t := range tokens {
+		d.entries[t.JTI] = t.ExpiresAt
+	}
+}
+
+// Sync догружает записи denylist, появившиеся с прошлой синхронизации, и чистит истекшие.
+func (d *TokenDenylist) Sync(ctx context.Context) error {
+	d.mu.RLock()
+	since := d.syncedUntil
+	d.mu.RUnlock()
+	if !since.IsZero() {
+		since = since.Add(-denylistSyncOverlap)
+	}
+
+	tokens, latest, err := d.repo.ListRevokedAccessTokens(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, t := range tokens {
+		d.entries[t.JTI] = t.ExpiresAt
+	}
+	for jti, expiresAt := range d.entries {
+		if !now.Before(expiresAt) {
+			delete(d.entries, jti)
+		}
+	}
+	if latest.After(d.syncedUntil) {
+		d.syncedUntil = latest
+	}
+	return nil
+}
+
+// Run вызывает Sync раз в interval, пока не отменен ctx.
+func (d *TokenDenylist) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Sync(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Не удалось догрузить отозванные токены", "error", err)
+			}
+		}
+	}
+}
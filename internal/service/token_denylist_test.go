@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/domain"
+	mocks "github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenDenylist_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Loads Revocations And Moves Cursor With Overlap", func(t *testing.T) {
+		repo := mocks.NewTokenRepository(t)
+		denylist := NewTokenDenylist(repo)
+
+		revoked := domain.RevokedToken{JTI: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		latest := time.Now().Add(-time.Second)
+		// Первая загрузка - с начала таблицы
+		repo.On("ListRevokedAccessTokens", mock.Anything, time.Time{}).
+			Return([]domain.RevokedToken{revoked}, latest, nil).Once()
+		require.NoError(t, denylist.Sync(ctx))
+		assert.True(t, denylist.Contains(revoked.JTI))
+		assert.False(t, denylist.Contains(uuid.New()))
+
+		// Следующая - с запасом назад от последнего revoked_at
+		repo.On("ListRevokedAccessTokens", mock.Anything, latest.Add(-denylistSyncOverlap)).
+			Return([]domain.RevokedToken(nil), time.Time{}, nil).Once()
+		require.NoError(t, denylist.Sync(ctx))
+		assert.True(t, denylist.Contains(revoked.JTI))
+	})
+
+	t.Run("Purges Expired Entries", func(t *testing.T) {
+		repo := mocks.NewTokenRepository(t)
+		denylist := NewTokenDenylist(repo)
+
+		expired := domain.RevokedToken{JTI: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}
+		denylist.Add(expired)
+		assert.False(t, denylist.Contains(expired.JTI), "Истекший токен и так отклоняется по exp")
+
+		repo.On("ListRevokedAccessTokens", mock.Anything, mock.Anything).
+			Return([]domain.RevokedToken(nil), time.Time{}, nil).Once()
+		require.NoError(t, denylist.Sync(ctx))
+		assert.Empty(t, denylist.entries)
+	})
+
+	t.Run("Repository Error Keeps Cache", func(t *testing.T) {
+		repo := mocks.NewTokenRepository(t)
+		denylist := NewTokenDenylist(repo)
+		revoked := domain.RevokedToken{JTI: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		denylist.Add(revoked)
+
+		repoErr := errors.New("db down")
+		repo.On("ListRevokedAccessTokens", mock.Anything, mock.Anything).
+			Return([]domain.RevokedToken(nil), time.Time{}, repoErr).Once()
+		assert.ErrorIs(t, denylist.Sync(ctx), repoErr)
+		assert.True(t, denylist.Contains(revoked.JTI))
+	})
+}
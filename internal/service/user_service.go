@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+// userService - администрирование пользователей модераторами.
+type userService struct {
+	userRepo    repository.UserRepository
+	tokenRepo   repository.TokenRepository
+	pvzRepo     repository.PVZRepository        // Проверка существования ПВЗ при назначении
+	assignments repository.AssignmentRepository // Назначения сотрудников на ПВЗ (user_pvz)
+	denylist    *TokenDenylist
+	tx          repository.Transactor
+	inviteTTL   time.Duration
+}
+
+// NewUserService - конструктор сервиса администрирования пользователей.
+// Репозиторий токенов и denylist нужны, чтобы отключение и смена роли действовали сразу,
+// а не после истечения уже выданных токенов.
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, pvzRepo repository.PVZRepository,
+	assignments repository.AssignmentRepository, denylist *TokenDenylist, tx repository.Transactor, registration config.RegistrationConfig) UserService {
+	return &userService{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		pvzRepo:     pvzRepo,
+		assignments: assignments,
+		denylist:    denylist,
+		tx:          tx,
+		inviteTTL:   registration.InviteTTL,
+	}
+}
+
+// ListUsers возвращает страницу пользователей в порядке регистрации.
+// Курсор следующей страницы заполняется, только если страница заполнена целиком.
+func (s *userService) ListUsers(ctx context.Context, filter domain.UserFilter) (ListUsersResult, error) {
+	if filter.Role != nil && !domain.IsValidRole(*filter.Role) {
+		return ListUsersResult{}, domain.ErrUserInvalidRole
+	}
+
+	users, err := s.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения списка пользователей", "error", err)
+		return ListUsersResult{}, fmt.Errorf("не удалось получить список пользователей: %w", err)
+	}
+
+	result := ListUsersResult{Users: users}
+	if len(users) == filter.Limit && filter.Limit > 0 {
+		last := users[len(users)-1]
+		nextCreatedAt, nextID := last.CreatedAt, last.ID
+		result.NextAfterCreatedAt = &nextCreatedAt
+		result.NextAfterID = &nextID
+	}
+	return result, nil
+}
+
+// GetUser возвращает пользователя по ID.
+func (s *userService) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return domain.User{}, domain.ErrUserNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка получения пользователя", "user_id", id, "error", err)
+		return domain.User{}, fmt.Errorf("не удалось получить пользователя: %w", err)
+	}
+	return user, nil
+}
+
+// DisableUser отключает учетную запись: вход и обмен refresh-токенов отклоняются,
+// выданные токены отзываются. Повторный вызов не меняет состояние.
+func (s *userService) DisableUser(ctx context.Context, id uuid.UUID, actor domain.Actor) (domain.User, error) {
+	if actor.UserID == id {
+		return domain.User{}, fmt.Errorf("%w: нельзя отключить собственную учетную запись", domain.ErrUserSelfModification)
+	}
+	return s.modify(ctx, id, actor, "отключение", func(ctx context.Context, user domain.User) (bool, error) {
+		if user.IsDisabled() {
+			return false, nil
+		}
+		return true, s.userRepo.SetUserDisabled(ctx, id, true)
+	})
+}
+
+// EnableUser снова разрешает вход. Повторный вызов не меняет состояние.
+func (s *userService) EnableUser(ctx context.Context, id uuid.UUID, actor domain.Actor) (domain.User, error) {
+	return s.modify(ctx, id, actor, "включение", func(ctx context.Context, user domain.User) (bool, error) {
+		if !user.IsDisabled() {
+			return false, nil
+		}
+		// Токены при отключении уже отозваны, пользователь войдет заново
+		return false, s.userRepo.SetUserDisabled(ctx, id, false)
+	})
+}
+
+// ChangeUserRole меняет роль. Токены несут роль в claims, поэтому выданные токены отзываются.
+func (s *userService) ChangeUserRole(ctx context.Context, id uuid.UUID, role string, actor domain.Actor) (domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return domain.User{}, fmt.Errorf("%w: %q", domain.ErrUserInvalidRole, role)
+	}
+	if actor.UserID == id {
+		return domain.User{}, fmt.Errorf("%w: нельзя изменить собственную роль", domain.ErrUserSelfModification)
+	}
+	return s.modify(ctx, id, actor, "смена роли на "+role, func(ctx context.Context, user domain.User) (bool, error) {
+		if user.Role == role {
+			return false, nil
+		}
+		return true, s.userRepo.UpdateUserRole(ctx, id, role)
+	})
+}
+
+// modify - общая часть DisableUser / EnableUser / ChangeUserRole. В одной транзакции блокирует
+// пользователя, применяет change и, если change вернул revoke = true, отзывает его токены;
+// после фиксации добавляет отозванные access-токены в локальный кеш и возвращает пользователя.
+func (s *userService) modify(ctx context.Context, id uuid.UUID, actor domain.Actor, action string,
+	change func(ctx context.Context, user domain.User) (revoke bool, err error)) (domain.User, error) {
+	var revoked []domain.RevokedToken
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.GetUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		revoke, err := change(ctx, user)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return domain.ErrUserNotFound
+			}
+			return fmt.Errorf("ошибка изменения пользователя: %w", err)
+		}
+		if !revoke {
+			return nil
+		}
+
+		revoked, err = s.tokenRepo.RevokeUserRefreshTokens(ctx, id)
+		if err != nil {
+			return fmt.Errorf("ошибка отзыва refresh-токенов пользователя: %w", err)
+		}
+		if err := s.tokenRepo.RevokeAccessTokens(ctx, revoked); err != nil {
+			return fmt.Errorf("ошибка отзыва access-токенов пользователя: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := domain.AsError(err); !ok {
+			slog.ErrorContext(ctx, "Ошибка администрирования пользователя", "user_id", id, "action", action, "error", err)
+		}
+		return domain.User{}, err
+	}
+	s.denylist.Add(revoked...)
+
+	slog.InfoContext(ctx, "Пользователь изменен модератором", "user_id", id, "action", action,
+		"revoked_tokens", len(revoked), "actor_id", actor.UserID)
+	return s.GetUser(ctx, id)
+}
+
+// CreateInvite создает приглашение на регистрацию. Токен возвращается только в ответе.
+func (s *userService) CreateInvite(ctx context.Context, role, email string, actor domain.Actor) (CreatedInvite, error) {
+	if !domain.IsValidRole(role) {
+		return CreatedInvite{}, fmt.Errorf("%w: %q", domain.ErrUserInvalidRole, role)
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return CreatedInvite{}, fmt.Errorf("ошибка генерации приглашения: %w", err)
+	}
+	invite := domain.UserInvite{
+		ID:        uuid.New(),
+		TokenHash: hashOpaqueToken(token),
+		Role:      role,
+		Email:     strings.TrimSpace(email),
+		CreatedBy: actor.KnownUserID(),
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(s.inviteTTL),
+	}
+	if err := s.userRepo.CreateInvite(ctx, invite); err != nil {
+		slog.ErrorContext(ctx, "Ошибка создания приглашения", "error", err)
+		return CreatedInvite{}, fmt.Errorf("не удалось создать приглашение: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Создано приглашение на регистрацию", "invite_id", invite.ID, "role", role,
+		"email", invite.Email, "actor_id", actor.UserID)
+	return CreatedInvite{Token: token, Invite: invite}, nil
+}
+
+// ListUserPVZs возвращает назначения пользователя на ПВЗ.
+func (s *userService) ListUserPVZs(ctx context.Context, userID uuid.UUID) ([]domain.PVZAssignment, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	assignments, err := s.assignments.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Ошибка получения назначений пользователя", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("не удалось получить назначения пользователя: %w", err)
+	}
+	return assignments, nil
+}
+
+// AssignPVZ назначает пользователя на ПВЗ. Назначение на деактивированный ПВЗ допустимо:
+// сотрудник сможет смотреть его историю, а новые приемки ПВЗ и так не принимает.
+func (s *userService) AssignPVZ(ctx context.Context, userID, pvzID uuid.UUID, actor domain.Actor) error {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+	if _, err := s.pvzRepo.GetPVZByID(ctx, pvzID); err != nil {
+		if errors.Is(err, repository.ErrPVZNotFound) {
+			return domain.ErrPVZNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка проверки ПВЗ при назначении", "pvz_id", pvzID, "error", err)
+		return fmt.Errorf("ошибка проверки ПВЗ: %w", err)
+	}
+
+	assignment := domain.PVZAssignment{UserID: userID, PVZID: pvzID, AssignedBy: actor.KnownUserID()}
+	if err := s.assignments.AssignPVZ(ctx, assignment); err != nil {
+		slog.ErrorContext(ctx, "Ошибка назначения на ПВЗ", "user_id", userID, "pvz_id", pvzID, "error", err)
+		return fmt.Errorf("не удалось назначить пользователя на ПВЗ: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Пользователь назначен на ПВЗ", "user_id", userID, "pvz_id", pvzID, "actor_id", actor.UserID)
+	return nil
+}
+
+// UnassignPVZ снимает назначение пользователя на ПВЗ. Уже выданные токены остаются действительными:
+// назначение проверяется при каждом обращении к приемкам, а не хранится в токене.
+func (s *userService) UnassignPVZ(ctx context.Context, userID, pvzID uuid.UUID, actor domain.Actor) error {
+	if err := s.assignments.UnassignPVZ(ctx, userID, pvzID); err != nil {
+		if errors.Is(err, repository.ErrAssignmentNotFound) {
+			return domain.ErrAssignmentNotFound
+		}
+		slog.ErrorContext(ctx, "Ошибка снятия назначения на ПВЗ", "user_id", userID, "pvz_id", pvzID, "error", err)
+		return fmt.Errorf("не удалось снять назначение на ПВЗ: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Назначение на ПВЗ снято", "user_id", userID, "pvz_id", pvzID, "actor_id", actor.UserID)
+	return nil
+}
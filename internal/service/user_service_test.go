@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Artem0405/pvz-service/internal/config"
+	"github.com/Artem0405/pvz-service/internal/domain"
+	"github.com/Artem0405/pvz-service/internal/repository"
+	"github.com/Artem0405/pvz-service/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupUserServiceTest создает сервис администрирования с моками репозиториев и его denylist
+func setupUserServiceTest(t *testing.T) (UserService, *mocks.UserRepository, *mocks.TokenRepository, *TokenDenylist) {
+	t.Helper()
+	userService, userRepo, tokenRepo, denylist, _, _ := setupUserServiceWithPVZ(t)
+	return userService, userRepo, tokenRepo, denylist
+}
+
+// setupUserServiceWithPVZ дополнительно возвращает моки ПВЗ и назначений сотрудников
+func setupUserServiceWithPVZ(t *testing.T) (UserService, *mocks.UserRepository, *mocks.TokenRepository, *TokenDenylist, *mocks.PVZRepository, *mocks.AssignmentRepository) {
+	t.Helper()
+	userRepo := mocks.NewUserRepository(t)
+	tokenRepo := mocks.NewTokenRepository(t)
+	pvzRepo := mocks.NewPVZRepository(t)
+	assignments := mocks.NewAssignmentRepository(t)
+	denylist := NewTokenDenylist(tokenRepo)
+	userService := NewUserService(userRepo, tokenRepo, pvzRepo, assignments, denylist, newPassthroughTransactor(t), config.Default().Registration)
+	return userService, userRepo, tokenRepo, denylist, pvzRepo, assignments
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	users := []domain.User{
+		{ID: uuid.New(), Email: "a@example.com", Role: domain.RoleEmployee, CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: uuid.New(), Email: "b@example.com", Role: domain.RoleEmployee, CreatedAt: time.Now()},
+	}
+
+	t.Run("Success - Full Page Returns Cursor", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		filter := domain.UserFilter{Limit: 2}
+		userRepo.On("ListUsers", mock.Anything, filter).Return(users, nil).Once()
+
+		result, err := userService.ListUsers(ctx, filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, users, result.Users)
+		require.NotNil(t, result.NextAfterCreatedAt)
+		require.NotNil(t, result.NextAfterID)
+		assert.Equal(t, users[1].CreatedAt, *result.NextAfterCreatedAt)
+		assert.Equal(t, users[1].ID, *result.NextAfterID)
+	})
+
+	t.Run("Success - Last Page Without Cursor", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		filter := domain.UserFilter{Limit: 20}
+		userRepo.On("ListUsers", mock.Anything, filter).Return(users, nil).Once()
+
+		result, err := userService.ListUsers(ctx, filter)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.NextAfterCreatedAt)
+		assert.Nil(t, result.NextAfterID)
+	})
+
+	t.Run("Fail - Repository Error", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		repoErr := errors.New("db error")
+		userRepo.On("ListUsers", mock.Anything, mock.Anything).Return(nil, repoErr).Once()
+
+		_, err := userService.ListUsers(ctx, domain.UserFilter{Limit: 20})
+
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestUserService_GetUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		id := uuid.New()
+		userRepo.On("GetUserByID", mock.Anything, id).Return(domain.User{}, repository.ErrUserNotFound).Once()
+
+		_, err := userService.GetUser(ctx, id)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestUserService_DisableUser(t *testing.T) {
+	ctx := context.Background()
+	target := domain.User{ID: uuid.New(), Email: "employee@example.com", Role: domain.RoleEmployee}
+
+	t.Run("Success - Tokens Revoked", func(t *testing.T) {
+		userService, userRepo, tokenRepo, denylist := setupUserServiceTest(t)
+		disabledAt := time.Now()
+		disabled := target
+		disabled.DisabledAt = &disabledAt
+		revoked := []domain.RevokedToken{{JTI: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}}
+
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		userRepo.On("SetUserDisabled", mock.Anything, target.ID, true).Return(nil).Once()
+		tokenRepo.On("RevokeUserRefreshTokens", mock.Anything, target.ID).Return(revoked, nil).Once()
+		tokenRepo.On("RevokeAccessTokens", mock.Anything, revoked).Return(nil).Once()
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(disabled, nil).Once()
+
+		user, err := userService.DisableUser(ctx, target.ID, testModerator)
+
+		require.NoError(t, err)
+		assert.True(t, user.IsDisabled())
+		// Отозванный access-токен отклоняется сразу, без ожидания синхронизации
+		assert.True(t, denylist.Contains(revoked[0].JTI))
+	})
+
+	t.Run("Success - Already Disabled Is Idempotent", func(t *testing.T) {
+		userService, userRepo, tokenRepo, _ := setupUserServiceTest(t)
+		disabledAt := time.Now()
+		disabled := target
+		disabled.DisabledAt = &disabledAt
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(disabled, nil).Twice()
+
+		user, err := userService.DisableUser(ctx, target.ID, testModerator)
+
+		require.NoError(t, err)
+		assert.True(t, user.IsDisabled())
+		userRepo.AssertNotCalled(t, "SetUserDisabled", mock.Anything, mock.Anything, mock.Anything)
+		tokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Self", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+
+		_, err := userService.DisableUser(ctx, testModerator.UserID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserSelfModification)
+		userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Not Found", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(domain.User{}, repository.ErrUserNotFound).Once()
+
+		_, err := userService.DisableUser(ctx, target.ID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("Fail - Revoke Error", func(t *testing.T) {
+		userService, userRepo, tokenRepo, _ := setupUserServiceTest(t)
+		repoErr := errors.New("db error")
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		userRepo.On("SetUserDisabled", mock.Anything, target.ID, true).Return(nil).Once()
+		tokenRepo.On("RevokeUserRefreshTokens", mock.Anything, target.ID).Return(nil, repoErr).Once()
+
+		_, err := userService.DisableUser(ctx, target.ID, testModerator)
+
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestUserService_EnableUser(t *testing.T) {
+	ctx := context.Background()
+	disabledAt := time.Now()
+	target := domain.User{ID: uuid.New(), Email: "employee@example.com", Role: domain.RoleEmployee, DisabledAt: &disabledAt}
+
+	t.Run("Success", func(t *testing.T) {
+		userService, userRepo, tokenRepo, _ := setupUserServiceTest(t)
+		enabled := target
+		enabled.DisabledAt = nil
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		userRepo.On("SetUserDisabled", mock.Anything, target.ID, false).Return(nil).Once()
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(enabled, nil).Once()
+
+		user, err := userService.EnableUser(ctx, target.ID, testModerator)
+
+		require.NoError(t, err)
+		assert.False(t, user.IsDisabled())
+		tokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ChangeUserRole(t *testing.T) {
+	ctx := context.Background()
+	target := domain.User{ID: uuid.New(), Email: "employee@example.com", Role: domain.RoleEmployee}
+
+	t.Run("Success - Tokens Revoked", func(t *testing.T) {
+		userService, userRepo, tokenRepo, _ := setupUserServiceTest(t)
+		promoted := target
+		promoted.Role = domain.RoleModerator
+
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		userRepo.On("UpdateUserRole", mock.Anything, target.ID, domain.RoleModerator).Return(nil).Once()
+		tokenRepo.On("RevokeUserRefreshTokens", mock.Anything, target.ID).Return([]domain.RevokedToken{}, nil).Once()
+		tokenRepo.On("RevokeAccessTokens", mock.Anything, []domain.RevokedToken{}).Return(nil).Once()
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(promoted, nil).Once()
+
+		user, err := userService.ChangeUserRole(ctx, target.ID, domain.RoleModerator, testModerator)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleModerator, user.Role)
+	})
+
+	t.Run("Success - Same Role Is Idempotent", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Twice()
+
+		_, err := userService.ChangeUserRole(ctx, target.ID, domain.RoleEmployee, testModerator)
+
+		require.NoError(t, err)
+		userRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - Invalid Role", func(t *testing.T) {
+		userService, _, _, _ := setupUserServiceTest(t)
+
+		_, err := userService.ChangeUserRole(ctx, target.ID, "admin", testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserInvalidRole)
+	})
+
+	t.Run("Fail - Self", func(t *testing.T) {
+		userService, _, _, _ := setupUserServiceTest(t)
+
+		_, err := userService.ChangeUserRole(ctx, testModerator.UserID, domain.RoleEmployee, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserSelfModification)
+	})
+}
+
+func TestUserService_CreateInvite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+		var stored domain.UserInvite
+		userRepo.On("CreateInvite", mock.Anything, mock.AnythingOfType("domain.UserInvite")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(domain.UserInvite) }).
+			Return(nil).Once()
+
+		created, err := userService.CreateInvite(ctx, domain.RoleModerator, " new@example.com ", testModerator)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.Token)
+		// В БД хранится только хеш токена
+		assert.Equal(t, hashOpaqueToken(created.Token), stored.TokenHash)
+		assert.Equal(t, domain.RoleModerator, stored.Role)
+		assert.Equal(t, "new@example.com", stored.Email)
+		require.NotNil(t, stored.CreatedBy)
+		assert.Equal(t, testModerator.UserID, *stored.CreatedBy)
+		assert.WithinDuration(t, time.Now().Add(config.Default().Registration.InviteTTL), stored.ExpiresAt, 10*time.Second)
+	})
+
+	t.Run("Fail - Invalid Role", func(t *testing.T) {
+		userService, userRepo, _, _ := setupUserServiceTest(t)
+
+		_, err := userService.CreateInvite(ctx, "admin", "", testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserInvalidRole)
+		userRepo.AssertNotCalled(t, "CreateInvite", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_AssignPVZ(t *testing.T) {
+	ctx := context.Background()
+	employee := domain.User{ID: uuid.New(), Email: "emp@example.com", Role: domain.RoleEmployee}
+	pvzID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		userService, userRepo, _, _, pvzRepo, assignments := setupUserServiceWithPVZ(t)
+		userRepo.On("GetUserByID", mock.Anything, employee.ID).Return(employee, nil).Once()
+		pvzRepo.On("GetPVZByID", mock.Anything, pvzID).Return(domain.PVZ{ID: pvzID, IsActive: true}, nil).Once()
+		var stored domain.PVZAssignment
+		assignments.On("AssignPVZ", mock.Anything, mock.AnythingOfType("domain.PVZAssignment")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(domain.PVZAssignment) }).
+			Return(nil).Once()
+
+		err := userService.AssignPVZ(ctx, employee.ID, pvzID, testModerator)
+
+		require.NoError(t, err)
+		assert.Equal(t, employee.ID, stored.UserID)
+		assert.Equal(t, pvzID, stored.PVZID)
+		require.NotNil(t, stored.AssignedBy)
+		assert.Equal(t, testModerator.UserID, *stored.AssignedBy)
+	})
+
+	t.Run("Fail - User Not Found", func(t *testing.T) {
+		userService, userRepo, _, _, pvzRepo, assignments := setupUserServiceWithPVZ(t)
+		userRepo.On("GetUserByID", mock.Anything, employee.ID).Return(domain.User{}, repository.ErrUserNotFound).Once()
+
+		err := userService.AssignPVZ(ctx, employee.ID, pvzID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		pvzRepo.AssertNotCalled(t, "GetPVZByID", mock.Anything, mock.Anything)
+		assignments.AssertNotCalled(t, "AssignPVZ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail - PVZ Not Found", func(t *testing.T) {
+		userService, userRepo, _, _, pvzRepo, assignments := setupUserServiceWithPVZ(t)
+		userRepo.On("GetUserByID", mock.Anything, employee.ID).Return(employee, nil).Once()
+		pvzRepo.On("GetPVZByID", mock.Anything, pvzID).Return(domain.PVZ{}, repository.ErrPVZNotFound).Once()
+
+		err := userService.AssignPVZ(ctx, employee.ID, pvzID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrPVZNotFound)
+		assignments.AssertNotCalled(t, "AssignPVZ", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_UnassignPVZ(t *testing.T) {
+	ctx := context.Background()
+	userID, pvzID := uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		userService, _, _, _, _, assignments := setupUserServiceWithPVZ(t)
+		assignments.On("UnassignPVZ", mock.Anything, userID, pvzID).Return(nil).Once()
+
+		err := userService.UnassignPVZ(ctx, userID, pvzID, testModerator)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail - Not Assigned", func(t *testing.T) {
+		userService, _, _, _, _, assignments := setupUserServiceWithPVZ(t)
+		assignments.On("UnassignPVZ", mock.Anything, userID, pvzID).Return(repository.ErrAssignmentNotFound).Once()
+
+		err := userService.UnassignPVZ(ctx, userID, pvzID, testModerator)
+
+		assert.ErrorIs(t, err, domain.ErrAssignmentNotFound)
+	})
+}
+
+func TestUserService_ListUserPVZs(t *testing.T) {
+	ctx := context.Background()
+	employee := domain.User{ID: uuid.New(), Email: "emp@example.com", Role: domain.RoleEmployee}
+
+	t.Run("Success", func(t *testing.T) {
+		userService, userRepo, _, _, _, assignments := setupUserServiceWithPVZ(t)
+		list := []domain.PVZAssignment{{UserID: employee.ID, PVZID: uuid.New(), AssignedAt: time.Now()}}
+		userRepo.On("GetUserByID", mock.Anything, employee.ID).Return(employee, nil).Once()
+		assignments.On("ListAssignmentsByUser", mock.Anything, employee.ID).Return(list, nil).Once()
+
+		result, err := userService.ListUserPVZs(ctx, employee.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, list, result)
+	})
+
+	t.Run("Fail - User Not Found", func(t *testing.T) {
+		userService, userRepo, _, _, _, assignments := setupUserServiceWithPVZ(t)
+		userRepo.On("GetUserByID", mock.Anything, employee.ID).Return(domain.User{}, repository.ErrUserNotFound).Once()
+
+		_, err := userService.ListUserPVZs(ctx, employee.ID)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assignments.AssertNotCalled(t, "ListAssignmentsByUser", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,3 @@
+-- Возвращаем обычный (неуникальный) индекс для поиска открытой приемки
+DROP INDEX IF EXISTS uq_receptions_pvz_in_progress;
+CREATE INDEX IF NOT EXISTS idx_receptions_pvz_status ON receptions (pvz_id, status) WHERE status = 'in_progress';
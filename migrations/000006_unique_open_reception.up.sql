@@ -0,0 +1,14 @@
+-- Закрываем "лишние" открытые приемки, которые могли появиться из-за гонок:
+-- для каждого ПВЗ остается открытой только самая свежая.
+UPDATE receptions SET status = 'closed'
+WHERE status = 'in_progress'
+  AND id NOT IN (
+    SELECT DISTINCT ON (pvz_id) id
+    FROM receptions
+    WHERE status = 'in_progress'
+    ORDER BY pvz_id, date_time DESC
+  );
+
+-- Не более одной приемки 'in_progress' на ПВЗ. Индекс заменяет idx_receptions_pvz_status
+DROP INDEX IF EXISTS idx_receptions_pvz_status;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receptions_pvz_in_progress ON receptions (pvz_id) WHERE status = 'in_progress';
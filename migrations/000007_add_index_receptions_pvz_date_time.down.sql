@@ -0,0 +1,2 @@
+-- Удаляем индекс истории приемок ПВЗ
+DROP INDEX CONCURRENTLY IF EXISTS idx_receptions_pvz_date_time;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Индекс для истории приемок ПВЗ: фильтр по pvz_id и keyset пагинация по (date_time, id) от новых к старым
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_receptions_pvz_date_time ON receptions (pvz_id, date_time DESC, id DESC);
\ No newline at end of file
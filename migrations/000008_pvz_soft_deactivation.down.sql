@@ -0,0 +1,3 @@
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS deactivated_at,
+    DROP COLUMN IF EXISTS is_active;
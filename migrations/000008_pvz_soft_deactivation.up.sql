@@ -0,0 +1,5 @@
+-- Мягкая деактивация ПВЗ: строка остается (на нее ссылаются приемки),
+-- но ПВЗ скрыт из списков и не принимает новые приемки.
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ NULL;
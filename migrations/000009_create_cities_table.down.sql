@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pvz_city;
+ALTER TABLE pvz DROP CONSTRAINT IF EXISTS pvz_city_fkey;
+-- Откат возможен, только если все ПВЗ находятся в исходных трех городах
+ALTER TABLE pvz
+    ADD CONSTRAINT pvz_city_check CHECK (city IN ('Москва', 'Санкт-Петербург', 'Казань'));
+DROP TABLE IF EXISTS cities;
@@ -0,0 +1,26 @@
+-- Справочник городов: вместо CHECK на pvz.city список городов хранится в таблице
+-- и редактируется модератором через API без релиза и миграции.
+CREATE TABLE IF NOT EXISTS cities (
+    code VARCHAR(50) PRIMARY KEY,          -- Стабильный машинный код (msk, spb, ...)
+    name VARCHAR(100) NOT NULL UNIQUE,     -- Отображаемое имя; именно его хранит pvz.city
+    timezone VARCHAR(64) NOT NULL,         -- IANA таймзона (Europe/Moscow)
+    is_active BOOLEAN NOT NULL DEFAULT TRUE, -- false - новые ПВЗ в городе открыть нельзя
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Города, которые раньше были зашиты в CHECK constraint
+INSERT INTO cities (code, name, timezone) VALUES
+    ('msk', 'Москва', 'Europe/Moscow'),
+    ('spb', 'Санкт-Петербург', 'Europe/Moscow'),
+    ('kzn', 'Казань', 'Europe/Moscow')
+ON CONFLICT DO NOTHING;
+
+-- pvz.city становится внешним ключом на cities(name). Существующие строки
+-- проходят проверку, т.к. CHECK допускал только эти три города.
+-- ON UPDATE CASCADE: переименование города переносится на его ПВЗ.
+ALTER TABLE pvz DROP CONSTRAINT IF EXISTS pvz_city_check;
+ALTER TABLE pvz
+    ADD CONSTRAINT pvz_city_fkey FOREIGN KEY (city) REFERENCES cities (name) ON UPDATE CASCADE;
+
+-- Индекс для проверки FK при удалении/переименовании города
+CREATE INDEX IF NOT EXISTS idx_pvz_city ON pvz (city);
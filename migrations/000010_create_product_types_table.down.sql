@@ -0,0 +1,6 @@
+ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_type_fkey;
+-- Откат возможен, только если все товары имеют один из исходных трех типов
+CREATE TYPE product_type AS ENUM ('электроника', 'одежда', 'обувь');
+ALTER TABLE products ALTER COLUMN type TYPE product_type USING type::product_type;
+DROP TABLE IF EXISTS product_types;
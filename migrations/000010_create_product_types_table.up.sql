@@ -0,0 +1,26 @@
+-- Справочник типов товаров вместо ENUM product_type: новый тип добавляет модератор через API.
+CREATE TABLE IF NOT EXISTS product_types (
+    code VARCHAR(50) PRIMARY KEY,            -- Код типа; его передают клиенты в поле type товара
+    names JSONB NOT NULL,                    -- Локализованные названия: {"ru": "...", "en": "..."}
+    is_active BOOLEAN NOT NULL DEFAULT TRUE, -- false - новые товары этого типа добавить нельзя
+    attributes_schema JSONB NULL,            -- Схема дополнительных атрибутов (подмножество JSON Schema); NULL - атрибуты не допускаются
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_types_names_ru CHECK (names ? 'ru')
+);
+
+-- Типы, которые раньше были значениями ENUM product_type
+INSERT INTO product_types (code, names) VALUES
+    ('электроника', '{"ru": "Электроника", "en": "Electronics"}'),
+    ('одежда', '{"ru": "Одежда", "en": "Clothes"}'),
+    ('обувь', '{"ru": "Обувь", "en": "Shoes"}')
+ON CONFLICT DO NOTHING;
+
+-- products.type: ENUM -> VARCHAR с внешним ключом на справочник (перезаписывает таблицу products).
+-- Существующие значения совпадают с кодами, добавленными выше.
+ALTER TABLE products ALTER COLUMN type TYPE VARCHAR(50) USING type::text;
+ALTER TABLE products
+    ADD CONSTRAINT products_type_fkey FOREIGN KEY (type) REFERENCES product_types (code);
+DROP TYPE IF EXISTS product_type;
+
+-- Значения дополнительных атрибутов товара, проверенные по attributes_schema его типа
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uq_products_barcode_reception;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS order_id,
+    DROP COLUMN IF EXISTS barcode;
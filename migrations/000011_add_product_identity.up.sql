@@ -0,0 +1,9 @@
+-- Идентификация товара: штрихкод (SKU) и номер внешнего заказа.
+-- Колонки необязательные - товары, принятые до миграции, их не имеют.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS barcode VARCHAR(64) NULL,
+    ADD COLUMN IF NOT EXISTS order_id VARCHAR(100) NULL;
+
+-- Штрихкод уникален в пределах приемки (повторное сканирование -> конфликт).
+-- barcode идет первым, поэтому индекс служит и для поиска товара по штрихкоду.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_barcode_reception ON products (barcode, reception_id) WHERE barcode IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_deletions;
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS strict_lifo;
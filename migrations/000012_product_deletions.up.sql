@@ -0,0 +1,20 @@
+-- Строгий LIFO для ПВЗ: если включен, удалить можно только последний добавленный товар
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS strict_lifo BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Аудит удаления конкретного товара из открытой приемки: снимок товара, кто удалил и почему.
+-- product_id без внешнего ключа: запись аудита хранит снимок товара и не зависит от строки в products.
+CREATE TABLE IF NOT EXISTS product_deletions (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    reception_id UUID NOT NULL REFERENCES receptions(id),
+    product_type VARCHAR(50) NOT NULL,
+    barcode VARCHAR(64) NULL,
+    product_added_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_by UUID NULL,                 -- ID пользователя, если известен из токена
+    deleted_by_role VARCHAR(20) NOT NULL, -- Роль из токена
+    reason TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_deletions_reception ON product_deletions (reception_id, deleted_at);
@@ -0,0 +1,10 @@
+-- Мягко удаленные товары при откате удаляются окончательно
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_products_reception_deleted;
+DROP INDEX IF EXISTS uq_products_barcode_reception;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_barcode_reception ON products (barcode, reception_id) WHERE barcode IS NOT NULL;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
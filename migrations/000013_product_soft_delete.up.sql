@@ -0,0 +1,11 @@
+-- Мягкое удаление товаров: строка остается в БД, чтобы ошибочное удаление можно было отменить.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID NULL; -- ID пользователя, если известен из токена
+
+-- Удаленный товар не должен мешать повторно отсканировать тот же штрихкод в приемке.
+DROP INDEX IF EXISTS uq_products_barcode_reception;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_barcode_reception ON products (barcode, reception_id) WHERE barcode IS NOT NULL AND deleted_at IS NULL;
+
+-- Поиск последнего удаленного товара приемки для отмены удаления
+CREATE INDEX IF NOT EXISTS idx_products_reception_deleted ON products (reception_id, deleted_at) WHERE deleted_at IS NOT NULL;
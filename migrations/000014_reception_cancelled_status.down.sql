@@ -0,0 +1,14 @@
+-- Удалить значение из enum нельзя - пересоздаем тип. Отмененные приемки считаем закрытыми.
+UPDATE receptions SET status = 'closed' WHERE status = 'cancelled';
+
+-- Индекс ссылается на тип в условии, поэтому пересоздается вместе с ним
+DROP INDEX IF EXISTS uq_receptions_pvz_in_progress;
+
+ALTER TYPE reception_status RENAME TO reception_status_old;
+CREATE TYPE reception_status AS ENUM ('in_progress', 'closed');
+ALTER TABLE receptions ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE receptions ALTER COLUMN status TYPE reception_status USING status::text::reception_status;
+ALTER TABLE receptions ALTER COLUMN status SET DEFAULT 'in_progress';
+DROP TYPE reception_status_old;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receptions_pvz_in_progress ON receptions (pvz_id) WHERE status = 'in_progress';
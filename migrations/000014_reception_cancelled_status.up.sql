@@ -0,0 +1,3 @@
+-- Статус для приемок, начатых по ошибке. Значение enum нельзя использовать
+-- в той же транзакции, где оно добавлено, поэтому миграция из одной команды.
+ALTER TYPE reception_status ADD VALUE IF NOT EXISTS 'cancelled';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_receptions_in_progress_date_time;
+DROP TABLE IF EXISTS reception_audit;
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS stale_flagged_at;
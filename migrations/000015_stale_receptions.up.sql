@@ -0,0 +1,19 @@
+-- Пометка зависшей приемки фоновым обработчиком (режим action=flag)
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS stale_flagged_at TIMESTAMPTZ NULL;
+
+-- Аудит действий над приемками, которые выполнил не пользователь, а сервис (actor_role = 'system')
+CREATE TABLE IF NOT EXISTS reception_audit (
+    id UUID PRIMARY KEY,
+    reception_id UUID NOT NULL REFERENCES receptions(id),
+    action VARCHAR(30) NOT NULL,  -- auto_close, stale_flag
+    actor_id UUID NULL,           -- ID пользователя; NULL для системного актора
+    actor_role VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reception_audit_reception ON reception_audit (reception_id, created_at);
+
+-- Поиск открытых приемок по возрасту для фонового обработчика
+CREATE INDEX IF NOT EXISTS idx_receptions_in_progress_date_time ON receptions (date_time) WHERE status = 'in_progress';
@@ -0,0 +1,8 @@
+-- Кто начал и кто закрыл приемку, кто добавил товар (ID из claim sub токена).
+-- NULL - пользователь неизвестен: строки до этой миграции, токены /dummyLogin, действия фонового обработчика.
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS created_by UUID NULL,
+    ADD COLUMN IF NOT EXISTS closed_by UUID NULL;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS created_by UUID NULL;
@@ -0,0 +1,26 @@
+-- Refresh-токены: в БД хранится только SHA-256 хеш. Все токены, полученные обменом
+-- от одного входа, образуют семейство (family_id) и отзываются вместе.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    token_hash BYTEA NOT NULL UNIQUE,
+    access_jti UUID NOT NULL,                -- jti access-токена, выданного в паре с этим refresh-токеном
+    access_expires_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMPTZ NULL,                -- токен обменян на новую пару; повторное использование - признак кражи
+    revoked_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_id);
+
+-- Отозванные access-токены (denylist по jti). Строка нужна только до истечения токена.
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti UUID PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Догрузка denylist в кеш экземпляров сервиса по времени отзыва
+CREATE INDEX IF NOT EXISTS idx_revoked_access_tokens_revoked_at ON revoked_access_tokens (revoked_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_invites;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS created_at;
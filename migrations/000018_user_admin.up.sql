@@ -0,0 +1,17 @@
+-- Администрирование пользователей: дата создания (порядок списка) и отключение учетной записи
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ NULL; -- NULL - учетная запись активна
+
+-- Приглашения на регистрацию. Как и refresh-токены, хранится только SHA-256 хеш.
+CREATE TABLE IF NOT EXISTS user_invites (
+    id UUID PRIMARY KEY,
+    token_hash BYTEA NOT NULL UNIQUE,
+    role VARCHAR(50) NOT NULL CHECK (role IN ('employee', 'moderator')),
+    email VARCHAR(255) NULL,                 -- Если задан, приглашение действует только для этого email
+    created_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NULL,
+    used_by UUID NULL REFERENCES users(id) ON DELETE SET NULL
+);
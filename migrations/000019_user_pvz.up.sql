@@ -0,0 +1,11 @@
+-- Назначение сотрудников на ПВЗ: сотрудник работает с приемками только назначенных ему ПВЗ
+CREATE TABLE IF NOT EXISTS user_pvz (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    assigned_by UUID NULL REFERENCES users(id) ON DELETE SET NULL, -- Модератор, назначивший сотрудника
+    PRIMARY KEY (user_id, pvz_id)
+);
+
+-- Сотрудники ПВЗ (первичный ключ покрывает поиск по user_id)
+CREATE INDEX IF NOT EXISTS idx_user_pvz_pvz_id ON user_pvz (pvz_id);
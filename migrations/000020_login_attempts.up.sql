@@ -0,0 +1,12 @@
+-- Счетчики неудачных попыток входа (защита /login от перебора паролей).
+-- Состояние блокировки вычисляется сервисом по failures и last_failure_at.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('email', 'ip')),
+    key VARCHAR(255) NOT NULL,               -- Email в нижнем регистре или IP-адрес
+    failures INTEGER NOT NULL,               -- Неудачных попыток подряд (сбрасывается после окна login.window)
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+-- Для периодической очистки устаревших счетчиков
+CREATE INDEX IF NOT EXISTS idx_login_attempts_last_failure_at ON login_attempts (last_failure_at);
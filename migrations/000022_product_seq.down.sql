@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_reception_seq;
+ALTER TABLE products DROP COLUMN IF EXISTS seq; -- Последовательность удаляется вместе со столбцом (OWNED BY)
@@ -0,0 +1,21 @@
+-- Монотонный порядок добавления товаров для LIFO.
+-- date_time_added берется из NOW() (время начала транзакции), поэтому товары из
+-- параллельных транзакций могут получить время "не по порядку" вставки.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+CREATE SEQUENCE IF NOT EXISTS products_seq_seq OWNED BY products.seq;
+
+-- Существующие товары нумеруем в прежнем порядке (по времени добавления)
+UPDATE products p
+SET seq = o.rn
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY date_time_added, id) AS rn FROM products) o
+WHERE p.id = o.id;
+
+SELECT setval('products_seq_seq', COALESCE((SELECT MAX(seq) FROM products), 0) + 1, false);
+
+ALTER TABLE products
+    ALTER COLUMN seq SET DEFAULT nextval('products_seq_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+
+-- Индекс для LIFO удаления (по порядку добавления)
+CREATE INDEX IF NOT EXISTS idx_products_reception_seq ON products (reception_id, seq);
@@ -11,6 +11,7 @@ package pvz_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -24,14 +25,14 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Оставляем enum ReceptionStatus, хотя он не используется в этом RPC,
-// он может понадобиться для будущих методов.
+// Статус приемки
 type ReceptionStatus int32
 
 const (
 	ReceptionStatus_RECEPTION_STATUS_UNSPECIFIED ReceptionStatus = 0 // Хорошая практика - иметь нулевое значение по умолчанию
 	ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS ReceptionStatus = 1
 	ReceptionStatus_RECEPTION_STATUS_CLOSED      ReceptionStatus = 2
+	ReceptionStatus_RECEPTION_STATUS_CANCELLED   ReceptionStatus = 3 // Приемка начата по ошибке и отменена
 )
 
 // Enum value maps for ReceptionStatus.
@@ -40,11 +41,13 @@ var (
 		0: "RECEPTION_STATUS_UNSPECIFIED",
 		1: "RECEPTION_STATUS_IN_PROGRESS",
 		2: "RECEPTION_STATUS_CLOSED",
+		3: "RECEPTION_STATUS_CANCELLED",
 	}
 	ReceptionStatus_value = map[string]int32{
 		"RECEPTION_STATUS_UNSPECIFIED": 0,
 		"RECEPTION_STATUS_IN_PROGRESS": 1,
 		"RECEPTION_STATUS_CLOSED":      2,
+		"RECEPTION_STATUS_CANCELLED":   3,
 	}
 )
 
@@ -81,6 +84,9 @@ type PVZ struct {
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                     // UUID ПВЗ как строка
 	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"` // Дата регистрации
 	City             string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`                                                 // Город
+	IsActive         bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`                        // false - ПВЗ деактивирован и не принимает новые приемки
+	DeactivatedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deactivated_at,json=deactivatedAt,proto3" json:"deactivated_at,omitempty"`          // Момент деактивации, пусто для активного ПВЗ
+	StrictLifo       bool                   `protobuf:"varint,6,opt,name=strict_lifo,json=strictLifo,proto3" json:"strict_lifo,omitempty"`                  // true - удалять из приемки можно только последний товар
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -136,16 +142,307 @@ func (x *PVZ) GetCity() string {
 	return ""
 }
 
-// Пустое сообщение для запроса GetPVZList (параметры не нужны)
-type GetPVZListRequest struct {
+func (x *PVZ) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *PVZ) GetDeactivatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeactivatedAt
+	}
+	return nil
+}
+
+func (x *PVZ) GetStrictLifo() bool {
+	if x != nil {
+		return x.StrictLifo
+	}
+	return false
+}
+
+// Сообщение, описывающее приемку товаров
+type Reception struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                      // UUID приемки
+	PvzId         string                 `protobuf:"bytes,2,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`                   // UUID ПВЗ, к которому относится приемка
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`          // Время начала приемки
+	Status        ReceptionStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=pvz.v1.ReceptionStatus" json:"status,omitempty"` // Статус приемки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reception) Reset() {
+	*x = Reception{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reception) ProtoMessage() {}
+
+func (x *Reception) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reception.ProtoReflect.Descriptor instead.
+func (*Reception) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Reception) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reception) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *Reception) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Reception) GetStatus() ReceptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReceptionStatus_RECEPTION_STATUS_UNSPECIFIED
+}
+
+// Сообщение, описывающее товар в приемке
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                              // UUID товара
+	ReceptionId   string                 `protobuf:"bytes,2,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`         // UUID приемки
+	DateTimeAdded *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date_time_added,json=dateTimeAdded,proto3" json:"date_time_added,omitempty"` // Время добавления товара
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                                          // Код типа товара из справочника product_types
+	Attributes    *structpb.Struct       `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`                              // Дополнительные атрибуты (пусто, если не заданы)
+	Barcode       string                 `protobuf:"bytes,6,opt,name=barcode,proto3" json:"barcode,omitempty"`                                    // Штрихкод/SKU (пусто, если не указан)
+	OrderId       string                 `protobuf:"bytes,7,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                     // Номер внешнего заказа (пусто, если не указан)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *Product) GetDateTimeAdded() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTimeAdded
+	}
+	return nil
+}
+
+func (x *Product) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Product) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Product) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Приемка вместе с ее товарами (аналог ReceptionInfo в HTTP API)
+type ReceptionWithProducts struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reception     *Reception             `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"`
+	Products      []*Product             `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionWithProducts) Reset() {
+	*x = ReceptionWithProducts{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionWithProducts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionWithProducts) ProtoMessage() {}
+
+func (x *ReceptionWithProducts) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionWithProducts.ProtoReflect.Descriptor instead.
+func (*ReceptionWithProducts) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReceptionWithProducts) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+func (x *ReceptionWithProducts) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// ПВЗ вместе с приемками (аналог PvzListItem в HTTP API).
+// receptions заполняется только при include_receptions = true.
+type PVZWithReceptions struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Pvz           *PVZ                     `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	Receptions    []*ReceptionWithProducts `protobuf:"bytes,2,rep,name=receptions,proto3" json:"receptions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *PVZWithReceptions) Reset() {
+	*x = PVZWithReceptions{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZWithReceptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZWithReceptions) ProtoMessage() {}
+
+func (x *PVZWithReceptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZWithReceptions.ProtoReflect.Descriptor instead.
+func (*PVZWithReceptions) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PVZWithReceptions) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *PVZWithReceptions) GetReceptions() []*ReceptionWithProducts {
+	if x != nil {
+		return x.Receptions
+	}
+	return nil
+}
+
+// Запрос GetPVZList. По умолчанию деактивированные ПВЗ не возвращаются.
+type GetPVZListRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"` // Включить деактивированные ПВЗ
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *GetPVZListRequest) Reset() {
 	*x = GetPVZListRequest{}
-	mi := &file_pvz_v1_pvz_proto_msgTypes[1]
+	mi := &file_pvz_v1_pvz_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -157,7 +454,7 @@ func (x *GetPVZListRequest) String() string {
 func (*GetPVZListRequest) ProtoMessage() {}
 
 func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_v1_pvz_proto_msgTypes[1]
+	mi := &file_pvz_v1_pvz_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +467,14 @@ func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
 func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
-	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{1}
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPVZListRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
 }
 
 // Сообщение для ответа GetPVZList
@@ -183,7 +487,7 @@ type GetPVZListResponse struct {
 
 func (x *GetPVZListResponse) Reset() {
 	*x = GetPVZListResponse{}
-	mi := &file_pvz_v1_pvz_proto_msgTypes[2]
+	mi := &file_pvz_v1_pvz_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -195,7 +499,7 @@ func (x *GetPVZListResponse) String() string {
 func (*GetPVZListResponse) ProtoMessage() {}
 
 func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pvz_v1_pvz_proto_msgTypes[2]
+	mi := &file_pvz_v1_pvz_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -208,7 +512,7 @@ func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
 func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
-	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{2}
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetPVZListResponse) GetPvzs() []*PVZ {
@@ -218,26 +522,849 @@ func (x *GetPVZListResponse) GetPvzs() []*PVZ {
 	return nil
 }
 
-var File_pvz_v1_pvz_proto protoreflect.FileDescriptor
+type CreatePVZRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"` // Город ПВЗ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_pvz_v1_pvz_proto_rawDesc = "" +
-	"\n" +
-	"\x10pvz/v1/pvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"r\n" +
-	"\x03PVZ\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
-	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"\x13\n" +
-	"\x11GetPVZListRequest\"5\n" +
-	"\x12GetPVZListResponse\x12\x1f\n" +
-	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs*r\n" +
-	"\x0fReceptionStatus\x12 \n" +
-	"\x1cRECEPTION_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
-	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x01\x12\x1b\n" +
-	"\x17RECEPTION_STATUS_CLOSED\x10\x022Q\n" +
-	"\n" +
-	"PVZService\x12C\n" +
+func (x *CreatePVZRequest) Reset() {
+	*x = CreatePVZRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZRequest) ProtoMessage() {}
+
+func (x *CreatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZRequest.ProtoReflect.Descriptor instead.
+func (*CreatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreatePVZRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type CreatePVZResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"` // Созданный ПВЗ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePVZResponse) Reset() {
+	*x = CreatePVZResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZResponse) ProtoMessage() {}
+
+func (x *CreatePVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZResponse.ProtoReflect.Descriptor instead.
+func (*CreatePVZResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePVZResponse) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+type InitiateReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"` // UUID ПВЗ, для которого создается приемка
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateReceptionRequest) Reset() {
+	*x = InitiateReceptionRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateReceptionRequest) ProtoMessage() {}
+
+func (x *InitiateReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateReceptionRequest.ProtoReflect.Descriptor instead.
+func (*InitiateReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InitiateReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type InitiateReceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reception     *Reception             `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"` // Созданная приемка
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateReceptionResponse) Reset() {
+	*x = InitiateReceptionResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateReceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateReceptionResponse) ProtoMessage() {}
+
+func (x *InitiateReceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateReceptionResponse.ProtoReflect.Descriptor instead.
+func (*InitiateReceptionResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InitiateReceptionResponse) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+type AddProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`       // UUID ПВЗ с открытой приемкой
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                      // Код активного типа товара из справочника
+	Attributes    *structpb.Struct       `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`          // Дополнительные атрибуты, проверяются по схеме типа
+	Barcode       string                 `protobuf:"bytes,4,opt,name=barcode,proto3" json:"barcode,omitempty"`                // Необязательный штрихкод; повтор в той же приемке -> ALREADY_EXISTS
+	OrderId       string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Необязательный номер внешнего заказа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductRequest) Reset() {
+	*x = AddProductRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRequest) ProtoMessage() {}
+
+func (x *AddProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *AddProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type AddProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"` // Добавленный товар
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductResponse) Reset() {
+	*x = AddProductResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductResponse) ProtoMessage() {}
+
+func (x *AddProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductResponse.ProtoReflect.Descriptor instead.
+func (*AddProductResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteLastProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"` // UUID ПВЗ, из открытой приемки которого удаляется товар
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductRequest) Reset() {
+	*x = DeleteLastProductRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductRequest) ProtoMessage() {}
+
+func (x *DeleteLastProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteLastProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+// Пустой ответ: успешное удаление не возвращает данных
+type DeleteLastProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductResponse) Reset() {
+	*x = DeleteLastProductResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductResponse) ProtoMessage() {}
+
+func (x *DeleteLastProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{14}
+}
+
+type CloseLastReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"` // UUID ПВЗ, приемка которого закрывается
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseLastReceptionRequest) Reset() {
+	*x = CloseLastReceptionRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseLastReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseLastReceptionRequest) ProtoMessage() {}
+
+func (x *CloseLastReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseLastReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CloseLastReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CloseLastReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type CloseLastReceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reception     *Reception             `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"` // Закрытая приемка
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseLastReceptionResponse) Reset() {
+	*x = CloseLastReceptionResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseLastReceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseLastReceptionResponse) ProtoMessage() {}
+
+func (x *CloseLastReceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseLastReceptionResponse.ProtoReflect.Descriptor instead.
+func (*CloseLastReceptionResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CloseLastReceptionResponse) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+type ListPVZsRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Limit                 int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                                               // Размер страницы (1-30), по умолчанию 10
+	AfterRegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=after_registration_date,json=afterRegistrationDate,proto3" json:"after_registration_date,omitempty"` // Курсор: дата регистрации последнего ПВЗ предыдущей страницы
+	AfterId               string                 `protobuf:"bytes,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`                                             // Курсор: UUID последнего ПВЗ предыдущей страницы
+	IncludeReceptions     bool                   `protobuf:"varint,4,opt,name=include_receptions,json=includeReceptions,proto3" json:"include_receptions,omitempty"`              // Включить приемки и товары
+	StartDate             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                                       // Фильтр приемок: не раньше этой даты
+	EndDate               *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                             // Фильтр приемок: не позже этой даты
+	IncludeInactive       bool                   `protobuf:"varint,7,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`                    // Включить деактивированные ПВЗ
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListPVZsRequest) Reset() {
+	*x = ListPVZsRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZsRequest) ProtoMessage() {}
+
+func (x *ListPVZsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZsRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZsRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPVZsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPVZsRequest) GetAfterRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AfterRegistrationDate
+	}
+	return nil
+}
+
+func (x *ListPVZsRequest) GetAfterId() string {
+	if x != nil {
+		return x.AfterId
+	}
+	return ""
+}
+
+func (x *ListPVZsRequest) GetIncludeReceptions() bool {
+	if x != nil {
+		return x.IncludeReceptions
+	}
+	return false
+}
+
+func (x *ListPVZsRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ListPVZsRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *ListPVZsRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListPVZsResponse struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Items                     []*PVZWithReceptions   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextAfterRegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=next_after_registration_date,json=nextAfterRegistrationDate,proto3" json:"next_after_registration_date,omitempty"` // Пусто на последней странице
+	NextAfterId               string                 `protobuf:"bytes,3,opt,name=next_after_id,json=nextAfterId,proto3" json:"next_after_id,omitempty"`                                             // Пусто на последней странице
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListPVZsResponse) Reset() {
+	*x = ListPVZsResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZsResponse) ProtoMessage() {}
+
+func (x *ListPVZsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZsResponse.ProtoReflect.Descriptor instead.
+func (*ListPVZsResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPVZsResponse) GetItems() []*PVZWithReceptions {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListPVZsResponse) GetNextAfterRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAfterRegistrationDate
+	}
+	return nil
+}
+
+func (x *ListPVZsResponse) GetNextAfterId() string {
+	if x != nil {
+		return x.NextAfterId
+	}
+	return ""
+}
+
+type ListPVZsStreamRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChunkSize         int32                  `protobuf:"varint,1,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`                         // Количество ПВЗ в одном сообщении (1-500), по умолчанию 100
+	IncludeReceptions bool                   `protobuf:"varint,2,opt,name=include_receptions,json=includeReceptions,proto3" json:"include_receptions,omitempty"` // Включить приемки и товары
+	StartDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                          // Фильтр приемок: не раньше этой даты
+	EndDate           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                // Фильтр приемок: не позже этой даты
+	IncludeInactive   bool                   `protobuf:"varint,5,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`       // Включить деактивированные ПВЗ
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListPVZsStreamRequest) Reset() {
+	*x = ListPVZsStreamRequest{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZsStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZsStreamRequest) ProtoMessage() {}
+
+func (x *ListPVZsStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZsStreamRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZsStreamRequest) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPVZsStreamRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *ListPVZsStreamRequest) GetIncludeReceptions() bool {
+	if x != nil {
+		return x.IncludeReceptions
+	}
+	return false
+}
+
+func (x *ListPVZsStreamRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ListPVZsStreamRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *ListPVZsStreamRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+// Одна порция потока ListPVZsStream
+type ListPVZsStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PVZWithReceptions   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZsStreamResponse) Reset() {
+	*x = ListPVZsStreamResponse{}
+	mi := &file_pvz_v1_pvz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZsStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZsStreamResponse) ProtoMessage() {}
+
+func (x *ListPVZsStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pvz_v1_pvz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZsStreamResponse.ProtoReflect.Descriptor instead.
+func (*ListPVZsStreamResponse) Descriptor() ([]byte, []int) {
+	return file_pvz_v1_pvz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPVZsStreamResponse) GetItems() []*PVZWithReceptions {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_pvz_v1_pvz_proto protoreflect.FileDescriptor
+
+const file_pvz_v1_pvz_proto_rawDesc = "" +
+	"\n" +
+	"\x10pvz/v1/pvz.proto\x12\x06pvz.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf3\x01\n" +
+	"\x03PVZ\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
+	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12A\n" +
+	"\x0edeactivated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rdeactivatedAt\x12\x1f\n" +
+	"\vstrict_lifo\x18\x06 \x01(\bR\n" +
+	"strictLifo\"\x9c\x01\n" +
+	"\tReception\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06pvz_id\x18\x02 \x01(\tR\x05pvzId\x127\n" +
+	"\tdate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.pvz.v1.ReceptionStatusR\x06status\"\x82\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\freception_id\x18\x02 \x01(\tR\vreceptionId\x12B\n" +
+	"\x0fdate_time_added\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdateTimeAdded\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x127\n" +
+	"\n" +
+	"attributes\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x18\n" +
+	"\abarcode\x18\x06 \x01(\tR\abarcode\x12\x19\n" +
+	"\border_id\x18\a \x01(\tR\aorderId\"u\n" +
+	"\x15ReceptionWithProducts\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\x12+\n" +
+	"\bproducts\x18\x02 \x03(\v2\x0f.pvz.v1.ProductR\bproducts\"q\n" +
+	"\x11PVZWithReceptions\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12=\n" +
+	"\n" +
+	"receptions\x18\x02 \x03(\v2\x1d.pvz.v1.ReceptionWithProductsR\n" +
+	"receptions\">\n" +
+	"\x11GetPVZListRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\"5\n" +
+	"\x12GetPVZListResponse\x12\x1f\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs\"&\n" +
+	"\x10CreatePVZRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"2\n" +
+	"\x11CreatePVZResponse\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\"1\n" +
+	"\x18InitiateReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"L\n" +
+	"\x19InitiateReceptionResponse\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\"\xac\x01\n" +
+	"\x11AddProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x127\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x18\n" +
+	"\abarcode\x18\x04 \x01(\tR\abarcode\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\"?\n" +
+	"\x12AddProductResponse\x12)\n" +
+	"\aproduct\x18\x01 \x01(\v2\x0f.pvz.v1.ProductR\aproduct\"1\n" +
+	"\x18DeleteLastProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"\x1b\n" +
+	"\x19DeleteLastProductResponse\"2\n" +
+	"\x19CloseLastReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"M\n" +
+	"\x1aCloseLastReceptionResponse\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\"\xe2\x02\n" +
+	"\x0fListPVZsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12R\n" +
+	"\x17after_registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15afterRegistrationDate\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\tR\aafterId\x12-\n" +
+	"\x12include_receptions\x18\x04 \x01(\bR\x11includeReceptions\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12)\n" +
+	"\x10include_inactive\x18\a \x01(\bR\x0fincludeInactive\"\xc4\x01\n" +
+	"\x10ListPVZsResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.pvz.v1.PVZWithReceptionsR\x05items\x12[\n" +
+	"\x1cnext_after_registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x19nextAfterRegistrationDate\x12\"\n" +
+	"\rnext_after_id\x18\x03 \x01(\tR\vnextAfterId\"\x82\x02\n" +
+	"\x15ListPVZsStreamRequest\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x01 \x01(\x05R\tchunkSize\x12-\n" +
+	"\x12include_receptions\x18\x02 \x01(\bR\x11includeReceptions\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12)\n" +
+	"\x10include_inactive\x18\x05 \x01(\bR\x0fincludeInactive\"I\n" +
+	"\x16ListPVZsStreamResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.pvz.v1.PVZWithReceptionsR\x05items*\x92\x01\n" +
+	"\x0fReceptionStatus\x12 \n" +
+	"\x1cRECEPTION_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x01\x12\x1b\n" +
+	"\x17RECEPTION_STATUS_CLOSED\x10\x02\x12\x1e\n" +
+	"\x1aRECEPTION_STATUS_CANCELLED\x10\x032\xfb\x04\n" +
+	"\n" +
+	"PVZService\x12C\n" +
+	"\n" +
+	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponse\x12@\n" +
+	"\tCreatePVZ\x12\x18.pvz.v1.CreatePVZRequest\x1a\x19.pvz.v1.CreatePVZResponse\x12X\n" +
+	"\x11InitiateReception\x12 .pvz.v1.InitiateReceptionRequest\x1a!.pvz.v1.InitiateReceptionResponse\x12C\n" +
 	"\n" +
-	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponseB4Z2github.com/Artem0405/pvz-service/pkg/pvz_v1;pvz_v1b\x06proto3"
+	"AddProduct\x12\x19.pvz.v1.AddProductRequest\x1a\x1a.pvz.v1.AddProductResponse\x12X\n" +
+	"\x11DeleteLastProduct\x12 .pvz.v1.DeleteLastProductRequest\x1a!.pvz.v1.DeleteLastProductResponse\x12[\n" +
+	"\x12CloseLastReception\x12!.pvz.v1.CloseLastReceptionRequest\x1a\".pvz.v1.CloseLastReceptionResponse\x12=\n" +
+	"\bListPVZs\x12\x17.pvz.v1.ListPVZsRequest\x1a\x18.pvz.v1.ListPVZsResponse\x12Q\n" +
+	"\x0eListPVZsStream\x12\x1d.pvz.v1.ListPVZsStreamRequest\x1a\x1e.pvz.v1.ListPVZsStreamResponse0\x01B4Z2github.com/Artem0405/pvz-service/pkg/pvz_v1;pvz_v1b\x06proto3"
 
 var (
 	file_pvz_v1_pvz_proto_rawDescOnce sync.Once
@@ -252,24 +1379,79 @@ func file_pvz_v1_pvz_proto_rawDescGZIP() []byte {
 }
 
 var file_pvz_v1_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pvz_v1_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pvz_v1_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_pvz_v1_pvz_proto_goTypes = []any{
-	(ReceptionStatus)(0),          // 0: pvz.v1.ReceptionStatus
-	(*PVZ)(nil),                   // 1: pvz.v1.PVZ
-	(*GetPVZListRequest)(nil),     // 2: pvz.v1.GetPVZListRequest
-	(*GetPVZListResponse)(nil),    // 3: pvz.v1.GetPVZListResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(ReceptionStatus)(0),               // 0: pvz.v1.ReceptionStatus
+	(*PVZ)(nil),                        // 1: pvz.v1.PVZ
+	(*Reception)(nil),                  // 2: pvz.v1.Reception
+	(*Product)(nil),                    // 3: pvz.v1.Product
+	(*ReceptionWithProducts)(nil),      // 4: pvz.v1.ReceptionWithProducts
+	(*PVZWithReceptions)(nil),          // 5: pvz.v1.PVZWithReceptions
+	(*GetPVZListRequest)(nil),          // 6: pvz.v1.GetPVZListRequest
+	(*GetPVZListResponse)(nil),         // 7: pvz.v1.GetPVZListResponse
+	(*CreatePVZRequest)(nil),           // 8: pvz.v1.CreatePVZRequest
+	(*CreatePVZResponse)(nil),          // 9: pvz.v1.CreatePVZResponse
+	(*InitiateReceptionRequest)(nil),   // 10: pvz.v1.InitiateReceptionRequest
+	(*InitiateReceptionResponse)(nil),  // 11: pvz.v1.InitiateReceptionResponse
+	(*AddProductRequest)(nil),          // 12: pvz.v1.AddProductRequest
+	(*AddProductResponse)(nil),         // 13: pvz.v1.AddProductResponse
+	(*DeleteLastProductRequest)(nil),   // 14: pvz.v1.DeleteLastProductRequest
+	(*DeleteLastProductResponse)(nil),  // 15: pvz.v1.DeleteLastProductResponse
+	(*CloseLastReceptionRequest)(nil),  // 16: pvz.v1.CloseLastReceptionRequest
+	(*CloseLastReceptionResponse)(nil), // 17: pvz.v1.CloseLastReceptionResponse
+	(*ListPVZsRequest)(nil),            // 18: pvz.v1.ListPVZsRequest
+	(*ListPVZsResponse)(nil),           // 19: pvz.v1.ListPVZsResponse
+	(*ListPVZsStreamRequest)(nil),      // 20: pvz.v1.ListPVZsStreamRequest
+	(*ListPVZsStreamResponse)(nil),     // 21: pvz.v1.ListPVZsStreamResponse
+	(*timestamppb.Timestamp)(nil),      // 22: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),            // 23: google.protobuf.Struct
 }
 var file_pvz_v1_pvz_proto_depIdxs = []int32{
-	4, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
-	1, // 1: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
-	2, // 2: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
-	3, // 3: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	22, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	22, // 1: pvz.v1.PVZ.deactivated_at:type_name -> google.protobuf.Timestamp
+	22, // 2: pvz.v1.Reception.date_time:type_name -> google.protobuf.Timestamp
+	0,  // 3: pvz.v1.Reception.status:type_name -> pvz.v1.ReceptionStatus
+	22, // 4: pvz.v1.Product.date_time_added:type_name -> google.protobuf.Timestamp
+	23, // 5: pvz.v1.Product.attributes:type_name -> google.protobuf.Struct
+	2,  // 6: pvz.v1.ReceptionWithProducts.reception:type_name -> pvz.v1.Reception
+	3,  // 7: pvz.v1.ReceptionWithProducts.products:type_name -> pvz.v1.Product
+	1,  // 8: pvz.v1.PVZWithReceptions.pvz:type_name -> pvz.v1.PVZ
+	4,  // 9: pvz.v1.PVZWithReceptions.receptions:type_name -> pvz.v1.ReceptionWithProducts
+	1,  // 10: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
+	1,  // 11: pvz.v1.CreatePVZResponse.pvz:type_name -> pvz.v1.PVZ
+	2,  // 12: pvz.v1.InitiateReceptionResponse.reception:type_name -> pvz.v1.Reception
+	23, // 13: pvz.v1.AddProductRequest.attributes:type_name -> google.protobuf.Struct
+	3,  // 14: pvz.v1.AddProductResponse.product:type_name -> pvz.v1.Product
+	2,  // 15: pvz.v1.CloseLastReceptionResponse.reception:type_name -> pvz.v1.Reception
+	22, // 16: pvz.v1.ListPVZsRequest.after_registration_date:type_name -> google.protobuf.Timestamp
+	22, // 17: pvz.v1.ListPVZsRequest.start_date:type_name -> google.protobuf.Timestamp
+	22, // 18: pvz.v1.ListPVZsRequest.end_date:type_name -> google.protobuf.Timestamp
+	5,  // 19: pvz.v1.ListPVZsResponse.items:type_name -> pvz.v1.PVZWithReceptions
+	22, // 20: pvz.v1.ListPVZsResponse.next_after_registration_date:type_name -> google.protobuf.Timestamp
+	22, // 21: pvz.v1.ListPVZsStreamRequest.start_date:type_name -> google.protobuf.Timestamp
+	22, // 22: pvz.v1.ListPVZsStreamRequest.end_date:type_name -> google.protobuf.Timestamp
+	5,  // 23: pvz.v1.ListPVZsStreamResponse.items:type_name -> pvz.v1.PVZWithReceptions
+	6,  // 24: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
+	8,  // 25: pvz.v1.PVZService.CreatePVZ:input_type -> pvz.v1.CreatePVZRequest
+	10, // 26: pvz.v1.PVZService.InitiateReception:input_type -> pvz.v1.InitiateReceptionRequest
+	12, // 27: pvz.v1.PVZService.AddProduct:input_type -> pvz.v1.AddProductRequest
+	14, // 28: pvz.v1.PVZService.DeleteLastProduct:input_type -> pvz.v1.DeleteLastProductRequest
+	16, // 29: pvz.v1.PVZService.CloseLastReception:input_type -> pvz.v1.CloseLastReceptionRequest
+	18, // 30: pvz.v1.PVZService.ListPVZs:input_type -> pvz.v1.ListPVZsRequest
+	20, // 31: pvz.v1.PVZService.ListPVZsStream:input_type -> pvz.v1.ListPVZsStreamRequest
+	7,  // 32: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
+	9,  // 33: pvz.v1.PVZService.CreatePVZ:output_type -> pvz.v1.CreatePVZResponse
+	11, // 34: pvz.v1.PVZService.InitiateReception:output_type -> pvz.v1.InitiateReceptionResponse
+	13, // 35: pvz.v1.PVZService.AddProduct:output_type -> pvz.v1.AddProductResponse
+	15, // 36: pvz.v1.PVZService.DeleteLastProduct:output_type -> pvz.v1.DeleteLastProductResponse
+	17, // 37: pvz.v1.PVZService.CloseLastReception:output_type -> pvz.v1.CloseLastReceptionResponse
+	19, // 38: pvz.v1.PVZService.ListPVZs:output_type -> pvz.v1.ListPVZsResponse
+	21, // 39: pvz.v1.PVZService.ListPVZsStream:output_type -> pvz.v1.ListPVZsStreamResponse
+	32, // [32:40] is the sub-list for method output_type
+	24, // [24:32] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_pvz_v1_pvz_proto_init() }
@@ -283,7 +1465,7 @@ func file_pvz_v1_pvz_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pvz_v1_pvz_proto_rawDesc), len(file_pvz_v1_pvz_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
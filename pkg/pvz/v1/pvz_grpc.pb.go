@@ -21,7 +21,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PVZService_GetPVZList_FullMethodName = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_GetPVZList_FullMethodName         = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_CreatePVZ_FullMethodName          = "/pvz.v1.PVZService/CreatePVZ"
+	PVZService_InitiateReception_FullMethodName  = "/pvz.v1.PVZService/InitiateReception"
+	PVZService_AddProduct_FullMethodName         = "/pvz.v1.PVZService/AddProduct"
+	PVZService_DeleteLastProduct_FullMethodName  = "/pvz.v1.PVZService/DeleteLastProduct"
+	PVZService_CloseLastReception_FullMethodName = "/pvz.v1.PVZService/CloseLastReception"
+	PVZService_ListPVZs_FullMethodName           = "/pvz.v1.PVZService/ListPVZs"
+	PVZService_ListPVZsStream_FullMethodName     = "/pvz.v1.PVZService/ListPVZsStream"
 )
 
 // PVZServiceClient is the client API for PVZService service.
@@ -32,6 +39,20 @@ const (
 type PVZServiceClient interface {
 	// RPC метод для получения списка всех ПВЗ
 	GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+	// Создание нового ПВЗ (аналог POST /pvz)
+	CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*CreatePVZResponse, error)
+	// Инициация новой приемки для ПВЗ (аналог POST /receptions)
+	InitiateReception(ctx context.Context, in *InitiateReceptionRequest, opts ...grpc.CallOption) (*InitiateReceptionResponse, error)
+	// Добавление товара в последнюю открытую приемку ПВЗ (аналог POST /products)
+	AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*AddProductResponse, error)
+	// Удаление последнего добавленного товара (LIFO) из открытой приемки
+	DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*DeleteLastProductResponse, error)
+	// Закрытие последней открытой приемки ПВЗ
+	CloseLastReception(ctx context.Context, in *CloseLastReceptionRequest, opts ...grpc.CallOption) (*CloseLastReceptionResponse, error)
+	// Постраничный список ПВЗ (keyset pagination по registration_date + id, как в GET /pvz)
+	ListPVZs(ctx context.Context, in *ListPVZsRequest, opts ...grpc.CallOption) (*ListPVZsResponse, error)
+	// Потоковая выдача всех ПВЗ порциями по мере чтения таблицы
+	ListPVZsStream(ctx context.Context, in *ListPVZsStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPVZsStreamResponse], error)
 }
 
 type pVZServiceClient struct {
@@ -52,6 +73,85 @@ func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest
 	return out, nil
 }
 
+func (c *pVZServiceClient) CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*CreatePVZResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePVZResponse)
+	err := c.cc.Invoke(ctx, PVZService_CreatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) InitiateReception(ctx context.Context, in *InitiateReceptionRequest, opts ...grpc.CallOption) (*InitiateReceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitiateReceptionResponse)
+	err := c.cc.Invoke(ctx, PVZService_InitiateReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*AddProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddProductResponse)
+	err := c.cc.Invoke(ctx, PVZService_AddProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*DeleteLastProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLastProductResponse)
+	err := c.cc.Invoke(ctx, PVZService_DeleteLastProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CloseLastReception(ctx context.Context, in *CloseLastReceptionRequest, opts ...grpc.CallOption) (*CloseLastReceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseLastReceptionResponse)
+	err := c.cc.Invoke(ctx, PVZService_CloseLastReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListPVZs(ctx context.Context, in *ListPVZsRequest, opts ...grpc.CallOption) (*ListPVZsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPVZsResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListPVZs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListPVZsStream(ctx context.Context, in *ListPVZsStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPVZsStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PVZService_ServiceDesc.Streams[0], PVZService_ListPVZsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListPVZsStreamRequest, ListPVZsStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_ListPVZsStreamClient = grpc.ServerStreamingClient[ListPVZsStreamResponse]
+
 // PVZServiceServer is the server API for PVZService service.
 // All implementations must embed UnimplementedPVZServiceServer
 // for forward compatibility.
@@ -60,6 +160,20 @@ func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest
 type PVZServiceServer interface {
 	// RPC метод для получения списка всех ПВЗ
 	GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error)
+	// Создание нового ПВЗ (аналог POST /pvz)
+	CreatePVZ(context.Context, *CreatePVZRequest) (*CreatePVZResponse, error)
+	// Инициация новой приемки для ПВЗ (аналог POST /receptions)
+	InitiateReception(context.Context, *InitiateReceptionRequest) (*InitiateReceptionResponse, error)
+	// Добавление товара в последнюю открытую приемку ПВЗ (аналог POST /products)
+	AddProduct(context.Context, *AddProductRequest) (*AddProductResponse, error)
+	// Удаление последнего добавленного товара (LIFO) из открытой приемки
+	DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*DeleteLastProductResponse, error)
+	// Закрытие последней открытой приемки ПВЗ
+	CloseLastReception(context.Context, *CloseLastReceptionRequest) (*CloseLastReceptionResponse, error)
+	// Постраничный список ПВЗ (keyset pagination по registration_date + id, как в GET /pvz)
+	ListPVZs(context.Context, *ListPVZsRequest) (*ListPVZsResponse, error)
+	// Потоковая выдача всех ПВЗ порциями по мере чтения таблицы
+	ListPVZsStream(*ListPVZsStreamRequest, grpc.ServerStreamingServer[ListPVZsStreamResponse]) error
 	mustEmbedUnimplementedPVZServiceServer()
 }
 
@@ -73,6 +187,27 @@ type UnimplementedPVZServiceServer struct{}
 func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
 }
+func (UnimplementedPVZServiceServer) CreatePVZ(context.Context, *CreatePVZRequest) (*CreatePVZResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) InitiateReception(context.Context, *InitiateReceptionRequest) (*InitiateReceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateReception not implemented")
+}
+func (UnimplementedPVZServiceServer) AddProduct(context.Context, *AddProductRequest) (*AddProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*DeleteLastProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLastProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) CloseLastReception(context.Context, *CloseLastReceptionRequest) (*CloseLastReceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseLastReception not implemented")
+}
+func (UnimplementedPVZServiceServer) ListPVZs(context.Context, *ListPVZsRequest) (*ListPVZsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPVZs not implemented")
+}
+func (UnimplementedPVZServiceServer) ListPVZsStream(*ListPVZsStreamRequest, grpc.ServerStreamingServer[ListPVZsStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListPVZsStream not implemented")
+}
 func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
 func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
 
@@ -112,6 +247,125 @@ func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PVZService_CreatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, req.(*CreatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_InitiateReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).InitiateReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_InitiateReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).InitiateReception(ctx, req.(*InitiateReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AddProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AddProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AddProduct(ctx, req.(*AddProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteLastProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLastProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteLastProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, req.(*DeleteLastProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CloseLastReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseLastReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CloseLastReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CloseLastReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CloseLastReception(ctx, req.(*CloseLastReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListPVZs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPVZsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListPVZs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListPVZs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListPVZs(ctx, req.(*ListPVZsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListPVZsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPVZsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PVZServiceServer).ListPVZsStream(m, &grpc.GenericServerStream[ListPVZsStreamRequest, ListPVZsStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_ListPVZsStreamServer = grpc.ServerStreamingServer[ListPVZsStreamResponse]
+
 // PVZService_ServiceDesc is the grpc.ServiceDesc for PVZService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -123,7 +377,37 @@ var PVZService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPVZList",
 			Handler:    _PVZService_GetPVZList_Handler,
 		},
+		{
+			MethodName: "CreatePVZ",
+			Handler:    _PVZService_CreatePVZ_Handler,
+		},
+		{
+			MethodName: "InitiateReception",
+			Handler:    _PVZService_InitiateReception_Handler,
+		},
+		{
+			MethodName: "AddProduct",
+			Handler:    _PVZService_AddProduct_Handler,
+		},
+		{
+			MethodName: "DeleteLastProduct",
+			Handler:    _PVZService_DeleteLastProduct_Handler,
+		},
+		{
+			MethodName: "CloseLastReception",
+			Handler:    _PVZService_CloseLastReception_Handler,
+		},
+		{
+			MethodName: "ListPVZs",
+			Handler:    _PVZService_ListPVZs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListPVZsStream",
+			Handler:       _PVZService_ListPVZsStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pvz/v1/pvz.proto",
 }
@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Artem0405/pvz-service/internal/api"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	concurrentInitiates = 20 // Параллельные попытки открыть приемку
+	concurrentAdds      = 40 // Параллельные добавления товаров
+	concurrentDeletes   = 10 // Параллельные удаления последнего товара
+	concurrentCloses    = 5  // Параллельные попытки закрыть приемку
+)
+
+// TestConcurrentReceptionWorkflow обстреливает один ПВЗ запросами из множества горутин
+// и проверяет инварианты: одна открытая приемка на ПВЗ, ни один товар не теряется
+// и не попадает в уже закрытую приемку, приемка закрывается ровно один раз.
+func TestConcurrentReceptionWorkflow(t *testing.T) {
+	client := &http.Client{Timeout: clientTimeout}
+
+	moderatorToken := getDummyToken(t, client, api.Moderator)
+	modHeaders := map[string]string{"Authorization": "Bearer " + moderatorToken}
+
+	// --- Создаем отдельный ПВЗ для теста ---
+	pvzBody, err := json.Marshal(api.PVZ{City: api.PVZCity("Москва")})
+	require.NoError(t, err)
+	statusCode, respBody := sendRequest(t, client, "POST", baseURL+"/pvz", modHeaders, bytes.NewReader(pvzBody))
+	require.Equal(t, http.StatusCreated, statusCode, "Create PVZ failed. Body: %s", string(respBody))
+	var createdPvz api.PVZ
+	require.NoError(t, json.Unmarshal(respBody, &createdPvz))
+	require.NotNil(t, createdPvz.Id)
+	pvzID := *createdPvz.Id
+	empHeaders := map[string]string{"Authorization": "Bearer " + getAssignedEmployeeToken(t, client, moderatorToken, pvzID)}
+
+	// --- Шаг 1: много параллельных попыток открыть приемку - успешна ровно одна ---
+	receptionBody, err := json.Marshal(api.InitiateReceptionRequest{PvzId: pvzID})
+	require.NoError(t, err)
+	initiateCodes := runConcurrently(concurrentInitiates, func(int) (int, error) {
+		return doRequest(client, "POST", baseURL+"/receptions", empHeaders, receptionBody)
+	})
+	assert.Equal(t, 1, initiateCodes[http.StatusCreated], "Exactly one reception must be created, got codes: %v", initiateCodes)
+	assert.Equal(t, concurrentInitiates-1, initiateCodes[http.StatusBadRequest], "Other attempts must be rejected, got codes: %v", initiateCodes)
+
+	// --- Шаг 2: параллельные добавления и удаления товаров ---
+	productBody, err := json.Marshal(api.AddProductRequest{PvzId: pvzID, Type: api.ProductType("обувь")})
+	require.NoError(t, err)
+	deleteURL := fmt.Sprintf("%s/pvz/%s/delete_last_product", baseURL, pvzID)
+
+	var added, deleted int64
+	mixedCodes := runConcurrently(concurrentAdds+concurrentDeletes, func(i int) (int, error) {
+		if i < concurrentAdds {
+			code, err := doRequest(client, "POST", baseURL+"/products", empHeaders, productBody)
+			if code == http.StatusCreated {
+				atomic.AddInt64(&added, 1)
+			}
+			return code, err
+		}
+		code, err := doRequest(client, "POST", deleteURL, empHeaders, nil)
+		if code == http.StatusOK {
+			atomic.AddInt64(&deleted, 1)
+		}
+		return code, err
+	})
+	assert.Equal(t, int64(concurrentAdds), added, "All products must be added while reception is open, got codes: %v", mixedCodes)
+	assert.Zero(t, mixedCodes[http.StatusInternalServerError], "No internal errors expected, got codes: %v", mixedCodes)
+
+	// --- Шаг 3: параллельное закрытие - успешно ровно одно ---
+	closeURL := fmt.Sprintf("%s/pvz/%s/close_last_reception", baseURL, pvzID)
+	closeCodes := runConcurrently(concurrentCloses, func(int) (int, error) {
+		return doRequest(client, "POST", closeURL, empHeaders, nil)
+	})
+	assert.Equal(t, 1, closeCodes[http.StatusOK], "Reception must be closed exactly once, got codes: %v", closeCodes)
+	assert.Equal(t, concurrentCloses-1, closeCodes[http.StatusBadRequest], "Other close attempts must be rejected, got codes: %v", closeCodes)
+
+	// --- Шаг 4: после закрытия товар добавить нельзя ---
+	code, err := doRequest(client, "POST", baseURL+"/products", empHeaders, productBody)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code, "Adding a product to a closed reception must fail")
+
+	// --- Шаг 5: в БД ровно added - deleted товаров в единственной приемке ---
+	statusCode, respBody = sendRequest(t, client, "GET", baseURL+"/pvz?limit=30", empHeaders, nil)
+	require.Equal(t, http.StatusOK, statusCode, "List PVZ failed. Body: %s", string(respBody))
+	var listResp api.PvzListResponseKeyset
+	require.NoError(t, json.Unmarshal(respBody, &listResp))
+
+	var found *api.PvzListItem
+	for i := range listResp.Items {
+		if listResp.Items[i].Pvz.Id != nil && *listResp.Items[i].Pvz.Id == pvzID {
+			found = &listResp.Items[i]
+			break
+		}
+	}
+	require.NotNil(t, found, "Created PVZ (ID: %s) was not found in the list", pvzID)
+	require.Len(t, found.Receptions, 1, "PVZ must have exactly one reception")
+	rec := found.Receptions[0]
+	require.NotNil(t, rec.Reception.Status)
+	assert.Equal(t, api.Closed, *rec.Reception.Status)
+	assert.Len(t, rec.Products, int(added-deleted), "Products count must equal added (%d) minus deleted (%d)", added, deleted)
+}
+
+// getDummyToken получает тестовый токен для роли через /dummyLogin.
+func getDummyToken(t *testing.T, client *http.Client, role api.UserRole) string {
+	t.Helper()
+	body, err := json.Marshal(api.DummyLoginRequest{Role: role})
+	require.NoError(t, err)
+	statusCode, respBody := sendRequest(t, client, "POST", baseURL+"/dummyLogin", nil, bytes.NewReader(body))
+	require.Equal(t, http.StatusOK, statusCode, "Dummy login (%s) failed. Body: %s", role, string(respBody))
+	var tokenResp TokenResponse
+	require.NoError(t, json.Unmarshal(respBody, &tokenResp))
+	require.NotEmpty(t, tokenResp.Token)
+	return tokenResp.Token
+}
+
+// getAssignedEmployeeToken регистрирует нового сотрудника, назначает его на ПВЗ и возвращает его access-токен.
+// С приемками ПВЗ работают только назначенные сотрудники, а у токена /dummyLogin пользователя нет.
+func getAssignedEmployeeToken(t *testing.T, client *http.Client, moderatorToken string, pvzID uuid.UUID) string {
+	t.Helper()
+	email := openapi_types.Email(fmt.Sprintf("employee-%s@example.com", uuid.NewString()))
+	const password = "Integr4tion-Pass"
+	role := api.Employee
+
+	body, err := json.Marshal(api.RegisterUserRequest{Email: email, Password: password, Role: &role})
+	require.NoError(t, err)
+	statusCode, respBody := sendRequest(t, client, "POST", baseURL+"/register", nil, bytes.NewReader(body))
+	require.Equal(t, http.StatusCreated, statusCode, "Register employee failed. Body: %s", string(respBody))
+	var user api.User
+	require.NoError(t, json.Unmarshal(respBody, &user))
+	require.NotNil(t, user.Id)
+
+	modHeaders := map[string]string{"Authorization": "Bearer " + moderatorToken}
+	statusCode, respBody = sendRequest(t, client, "PUT", fmt.Sprintf("%s/users/%s/pvz/%s", baseURL, *user.Id, pvzID), modHeaders, nil)
+	require.Equal(t, http.StatusNoContent, statusCode, "Assign employee to PVZ failed. Body: %s", string(respBody))
+
+	body, err = json.Marshal(api.LoginUserRequest{Email: email, Password: password})
+	require.NoError(t, err)
+	statusCode, respBody = sendRequest(t, client, "POST", baseURL+"/login", nil, bytes.NewReader(body))
+	require.Equal(t, http.StatusOK, statusCode, "Employee login failed. Body: %s", string(respBody))
+	var pair api.TokenPair
+	require.NoError(t, json.Unmarshal(respBody, &pair))
+	require.NotEmpty(t, pair.Token)
+	return pair.Token
+}
+
+// runConcurrently запускает n вызовов fn одновременно и возвращает счетчики статус-кодов.
+// Ошибки транспорта учитываются под кодом 0.
+func runConcurrently(n int, fn func(i int) (int, error)) map[int]int {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		start = make(chan struct{})
+		codes = make(map[int]int)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start // Отпускаем все горутины разом, чтобы максимизировать гонку
+			code, err := fn(i)
+			if err != nil {
+				code = 0
+			}
+			mu.Lock()
+			codes[code]++
+			mu.Unlock()
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return codes
+}
+
+// doRequest - потокобезопасный аналог sendRequest без require (его нельзя вызывать из горутин).
+func doRequest(client *http.Client, method, url string, headers map[string]string, body []byte) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
@@ -87,7 +87,7 @@ func TestIntegrationScenario(t *testing.T) {
 	// --- Шаг 1: Создание ПВЗ (Модератор) ---
 	t.Run("Create PVZ (Moderator)", func(t *testing.T) {
 		headers := map[string]string{"Authorization": "Bearer " + moderatorToken}
-		createPvzReq := api.PVZ{City: api.Казань}
+		createPvzReq := api.PVZ{City: api.PVZCity("Казань")}
 		bodyBytes, err := json.Marshal(createPvzReq)
 		require.NoError(err, t, "Create PVZ: Failed to marshal request")
 		statusCode, respBody := sendRequest(t, client, "POST", baseURL+"/pvz", headers, bytes.NewReader(bodyBytes))
@@ -103,8 +103,8 @@ func TestIntegrationScenario(t *testing.T) {
 		if *createdPvz.Id == uuid.Nil {
 			require.FailNow("Create PVZ: Created PVZ ID is zero UUID", t)
 		}
-		if createdPvz.City != api.Казань {
-			t.Errorf("Create PVZ: City mismatch: expected %s, got %s", api.Казань, createdPvz.City)
+		if createdPvz.City != api.PVZCity("Казань") {
+			t.Errorf("Create PVZ: City mismatch: expected %s, got %s", api.PVZCity("Казань"), createdPvz.City)
 		}
 		if createdPvz.RegistrationDate == nil {
 			t.Errorf("Create PVZ: Registration date should not be nil")
@@ -116,10 +116,13 @@ func TestIntegrationScenario(t *testing.T) {
 
 	require.NotEqual(t, uuid.Nil, createdPvzId, "PVZ ID was not set after creation step")
 
+	// С приемками ПВЗ работает только назначенный на него сотрудник, а у dummy-токена пользователя нет
+	employeeToken = getAssignedEmployeeToken(t, client, moderatorToken, createdPvzId)
+
 	// --- Шаг 1b: Попытка Создания ПВЗ (Сотрудник - Ошибка) ---
 	t.Run("Fail Create PVZ (Employee)", func(t *testing.T) {
 		headers := map[string]string{"Authorization": "Bearer " + employeeToken}
-		createPvzReq := api.PVZ{City: api.Москва}
+		createPvzReq := api.PVZ{City: api.PVZCity("Москва")}
 		bodyBytes, err := json.Marshal(createPvzReq)
 		require.NoError(err, t, "Fail Create PVZ: Failed to marshal request")
 		statusCode, _ := sendRequest(t, client, "POST", baseURL+"/pvz", headers, bytes.NewReader(bodyBytes))
@@ -172,7 +175,7 @@ func TestIntegrationScenario(t *testing.T) {
 	// --- Шаг 3: Добавление 50 Товаров (Сотрудник) ---
 	t.Run("Add 50 Products (Employee)", func(t *testing.T) {
 		headers := map[string]string{"Authorization": "Bearer " + employeeToken}
-		productType := api.Одежда
+		productType := api.ProductType("одежда")
 
 		t.Logf("Starting to add 50 products of type %s...", productType)
 		var lastAddedProd *api.Product
@@ -292,8 +295,8 @@ func TestIntegrationScenario(t *testing.T) {
 			require.NotNil(t, item.Pvz.Id, "List PVZ: Item %d: PVZ ID is nil", i)
 			if *item.Pvz.Id == createdPvzId {
 				foundPvz = true
-				if item.Pvz.City != api.Казань {
-					t.Errorf("List PVZ: PVZ %s: City mismatch: expected %s, got %s", createdPvzId, api.Казань, item.Pvz.City)
+				if item.Pvz.City != api.PVZCity("Казань") {
+					t.Errorf("List PVZ: PVZ %s: City mismatch: expected %s, got %s", createdPvzId, api.PVZCity("Казань"), item.Pvz.City)
 				}
 				if len(item.Receptions) == 0 {
 					t.Errorf("List PVZ: PVZ %s: Receptions should not be empty", createdPvzId)